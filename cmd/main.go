@@ -3,11 +3,13 @@ package main
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/TehilaTheStudent/SkillCode-backend/internal/config"
 	"github.com/TehilaTheStudent/SkillCode-backend/internal/dependencies"
 	"github.com/TehilaTheStudent/SkillCode-backend/internal/handler"
 	"github.com/TehilaTheStudent/SkillCode-backend/internal/middleware"
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/model"
 	"github.com/TehilaTheStudent/SkillCode-backend/internal/repository"
 	"github.com/TehilaTheStudent/SkillCode-backend/internal/service"
 	tester "github.com/TehilaTheStudent/SkillCode-backend/internal/tester"
@@ -36,7 +38,10 @@ func main() {
 		logger.Fatal("Failed to setup dependencies", zap.Error(err))
 	}
 	// Initialize handlers
-	questionHandler := initializeHandlers(mongoClient, sharedTester)
+	questionHandler, err := initializeHandlers(mongoClient, sharedTester, logger)
+	if err != nil {
+		logger.Fatal("Failed to initialize handlers", zap.Error(err))
+	}
 
 	// Setup the router with middlewares and routes
 	r := setupRouter(logger, questionHandler)
@@ -50,10 +55,49 @@ func main() {
 
 // initializeHandlers sets up the handlers for the application (repository<-service<-handler)
 // this is the dependency injection
-func initializeHandlers(client *mongo.Client, sharedTester *tester.SharedTester) *handler.QuestionHandler {
+func initializeHandlers(client *mongo.Client, sharedTester *tester.SharedTester, logger *zap.Logger) (*handler.QuestionHandler, error) {
 	questionRepo := repository.NewQuestionRepository(client.Database(config.GlobalConfigAPI.DBName))
-	questionService := service.NewQuestionService(questionRepo, sharedTester)
-	return handler.NewQuestionHandler(questionService)
+	if err := questionRepo.EnsureIndexes(); err != nil {
+		return nil, fmt.Errorf("failed to create question indexes: %w", err)
+	}
+	revisionRepo := repository.NewRevisionRepository(client.Database(config.GlobalConfigAPI.DBName))
+	if err := revisionRepo.EnsureIndexes(); err != nil {
+		return nil, fmt.Errorf("failed to create revision indexes: %w", err)
+	}
+	// Questions stored before categories were a list are migrated, and every category in use is kept in the taxonomy
+	usedCategories, err := questionRepo.MigrateCategories()
+	if err != nil {
+		return nil, fmt.Errorf("failed to migrate question categories: %w", err)
+	}
+	if err := questionRepo.MigrateStats(); err != nil {
+		return nil, fmt.Errorf("failed to migrate question stats: %w", err)
+	}
+	categoryRepo := repository.NewCategoryRepository(client.Database(config.GlobalConfigAPI.DBName))
+	categories := usedCategories
+	for _, category := range model.PredefinedCategories {
+		categories = append(categories, string(category))
+	}
+	if err := categoryRepo.EnsureCategories(categories); err != nil {
+		return nil, fmt.Errorf("failed to seed the categories: %w", err)
+	}
+	questionService := service.NewQuestionService(questionRepo, revisionRepo, categoryRepo, sharedTester)
+	go purgeTrashPeriodically(questionService, logger)
+	return handler.NewQuestionHandler(questionService), nil
+}
+
+// purgeTrashPeriodically permanently deletes the questions that outlived the trash retention, for as long as the server runs
+func purgeTrashPeriodically(questionService *service.QuestionService, logger *zap.Logger) {
+	ticker := time.NewTicker(config.GlobalConfigAPI.TrashPurgeInterval)
+	defer ticker.Stop()
+	for {
+		purged, err := questionService.PurgeTrash(config.GlobalConfigAPI.TrashRetention)
+		if err != nil {
+			logger.Error("Failed to purge the trash", zap.Error(err))
+		} else if purged > 0 {
+			logger.Info("Purged questions from the trash", zap.Int("count", purged))
+		}
+		<-ticker.C
+	}
 }
 
 // setupRouter configures the router with middlewares and routes fron ; questions, code, config
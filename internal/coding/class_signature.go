@@ -0,0 +1,222 @@
+package coding
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/model"
+)
+
+var classGenerators = map[model.PredefinedSupportedLanguage]func(model.ClassConfig) (string, error){
+	model.Python:     GeneratePythonClassSignature,
+	model.Java:       GenerateJavaClassSignature,
+	model.JavaScript: GenerateJavaScriptClassSignature,
+}
+
+// classMethod holds what the class templates need to render one method, or the constructor
+type classMethod struct {
+	Name         string
+	Params       string
+	ReturnType   string
+	DefaultValue string // Java only, returned by the skeleton so that it compiles
+	ParamsDocs   []map[string]string
+	Constraints  []string
+}
+
+// classMethods prepares the constructor and every method of classConfig for a class template
+// The constructor is described as a void function named after the class.
+func classMethods(classConfig model.ClassConfig, style func(string) string, param func(model.FunctionConfig, model.Parameter) string,
+	returnType func(model.FunctionConfig) string, constraint func(model.Parameter) string, paramType func(model.AbstractType) string) (classMethod, []classMethod) {
+	describe := func(name string, function model.FunctionConfig) classMethod {
+		method := classMethod{Name: name, ReturnType: returnType(function)}
+		paramList := []string{}
+		for _, parameter := range functionParameters(function) {
+			paramList = append(paramList, param(function, parameter))
+			method.ParamsDocs = append(method.ParamsDocs, map[string]string{
+				"Name":        style(parameter.Name),
+				"Type":        paramType(parameter.ParamType),
+				"Constraints": parameter.Constraints.String(),
+			})
+			if parameter.Constraints.String() != "" {
+				method.Constraints = append(method.Constraints, constraint(parameter))
+			}
+		}
+		method.Params = strings.Join(paramList, ", ")
+		return method
+	}
+
+	constructor := describe(classConfig.Name, model.FunctionConfig{Name: classConfig.Name, Parameters: classConfig.Constructor})
+	methods := []classMethod{}
+	for _, method := range classConfig.Methods {
+		methods = append(methods, describe(style(method.Name), method))
+	}
+	return constructor, methods
+}
+
+const pythonClassTemplate = `{{.Header}}class {{.ClassName}}:
+{{- with .Constructor}}
+{{range .Constraints}}    # {{.}}
+{{end}}    def __init__(self{{if .Params}}, {{.Params}}{{end}}):
+        pass
+{{- end}}
+{{range .Methods}}
+{{range .Constraints}}    # {{.}}
+{{end}}    def {{.Name}}(self{{if .Params}}, {{.Params}}{{end}}) -> {{.ReturnType}}:
+        pass
+{{end}}`
+
+// GeneratePythonClassSignature renders the class skeleton of a class-design question in Python
+func GeneratePythonClassSignature(classConfig model.ClassConfig) (string, error) {
+	constructor, methods := classMethods(classConfig, ToPythonStyle,
+		func(_ model.FunctionConfig, param model.Parameter) string {
+			return fmt.Sprintf("%s: %s", ToPythonStyle(param.Name), mapToPythonType(param.ParamType))
+		},
+		func(method model.FunctionConfig) string {
+			if method.IsVoid() {
+				return "None"
+			}
+			return mapToPythonType(*method.ReturnType)
+		},
+		func(param model.Parameter) string {
+			return fmt.Sprintf("%s: %s", ToPythonStyle(param.Name), param.Constraints)
+		},
+		mapToPythonType,
+	)
+	header := ""
+	if snippet := utilsImport(model.Python, classTypes(classConfig)); snippet != "" {
+		header = snippet + "\n\n\n"
+	}
+	return renderClassTemplate("pythonClass", pythonClassTemplate, header, classConfig.Name, constructor, methods)
+}
+
+const jsClassTemplate = `{{.Header}}class {{.ClassName}} {
+{{- with .Constructor}}
+{{- if .ParamsDocs}}
+    /**
+    {{- range .ParamsDocs}}
+     * @param {{.Type}} {{.Name}}{{if .Constraints}} - {{.Constraints}}{{end}}
+    {{- end}}
+     */
+{{- end}}
+    constructor({{.Params}}) {
+        // TODO: Implement the constructor
+    }
+{{- end}}
+{{- range .Methods}}
+
+    /**
+    {{- range .ParamsDocs}}
+     * @param {{.Type}} {{.Name}}{{if .Constraints}} - {{.Constraints}}{{end}}
+    {{- end}}
+     * @returns {{.ReturnType}}
+     */
+    {{.Name}}({{.Params}}) {
+        // TODO: Implement this method
+    }
+{{- end}}
+}`
+
+// GenerateJavaScriptClassSignature renders the class skeleton of a class-design question in JavaScript
+func GenerateJavaScriptClassSignature(classConfig model.ClassConfig) (string, error) {
+	constructor, methods := classMethods(classConfig, ToJSStyle,
+		func(_ model.FunctionConfig, param model.Parameter) string {
+			return ToJSStyle(param.Name)
+		},
+		func(method model.FunctionConfig) string {
+			if method.IsVoid() {
+				return "void"
+			}
+			return mapToJSType(*method.ReturnType)
+		},
+		func(param model.Parameter) string {
+			return fmt.Sprintf("%s %s", ToJSStyle(param.Name), param.Constraints)
+		},
+		mapToJSType,
+	)
+	header := ""
+	if snippet := utilsImport(model.JavaScript, classTypes(classConfig)); snippet != "" {
+		header = snippet + "\n\n"
+	}
+	return renderClassTemplate("jsClass", jsClassTemplate, header, classConfig.Name, constructor, methods)
+}
+
+const javaClassTemplate = `{{.Header}}public class {{.ClassName}} {
+{{- with .Constructor}}
+{{- if .Constraints}}
+    /**
+    {{- range .Constraints}}
+     * @param {{.}}
+    {{- end}}
+     */
+{{- end}}
+    public {{.Name}}({{.Params}}) {
+       //TODO: implement the constructor
+    }
+{{- end}}
+{{- range .Methods}}
+{{if .Constraints}}
+    /**
+    {{- range .Constraints}}
+     * @param {{.}}
+    {{- end}}
+     */
+{{- end}}
+    public {{.ReturnType}} {{.Name}}({{.Params}}) {
+       //TODO: implement this method
+{{- if .DefaultValue}}
+       return {{.DefaultValue}};
+{{- end}}
+    }
+{{- end}}
+}`
+
+// GenerateJavaClassSignature renders the class skeleton of a class-design question in Java
+func GenerateJavaClassSignature(classConfig model.ClassConfig) (string, error) {
+	constructor, methods := classMethods(classConfig, ToJavaStyle,
+		func(method model.FunctionConfig, param model.Parameter) string {
+			return fmt.Sprintf("%s %s", resolveJavaType(param.ParamType, param.JavaType, method.GetJavaTypeStyle()), ToJavaStyle(param.Name))
+		},
+		javaReturnType,
+		func(param model.Parameter) string {
+			return fmt.Sprintf("%s %s", ToJavaStyle(param.Name), param.Constraints)
+		},
+		mapToJavaType,
+	)
+	declaration := constructor.Params
+	for i := range methods {
+		if methods[i].ReturnType != "void" {
+			methods[i].DefaultValue = javaDefaultValue(methods[i].ReturnType)
+		}
+		declaration += " " + methods[i].ReturnType + " " + methods[i].Params
+	}
+	header := ""
+	for _, name := range javaImports(classTypes(classConfig), declaration) {
+		header += fmt.Sprintf("import %s;\n", name)
+	}
+	if header != "" {
+		header += "\n"
+	}
+	return renderClassTemplate("javaClass", javaClassTemplate, header, classConfig.Name, constructor, methods)
+}
+
+// renderClassTemplate renders a class skeleton, with header (the imports) before the class
+func renderClassTemplate(name, text, header, className string, constructor classMethod, methods []classMethod) (string, error) {
+	tmpl, err := template.New(name).Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	data := map[string]interface{}{
+		"Header":      header,
+		"ClassName":   className,
+		"Constructor": constructor,
+		"Methods":     methods,
+	}
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,108 @@
+package coding
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/model"
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/parser_validator"
+	"github.com/ettle/strcase"
+)
+
+var (
+	// An example block: "Input: nums = [2,7,11,15], target = 9" and "Output: [0,1]", on one line or several
+	exampleRegex = regexp.MustCompile(`(?is)\bInput\s*:\s*(.*?)\s*\bOutput\s*:\s*([^\n]*)`)
+	// Where the problem statement ends and its examples or constraints begin
+	statementEndRegex  = regexp.MustCompile(`(?im)^\s*(?:Example\s*\d*\s*:|Input\s*:|Constraints\s*:)`)
+	namedValueRegex    = regexp.MustCompile(`(?s)^\s*([A-Za-z_]\w*)\s*=\s*(.*?)\s*$`)
+	pythonLiteralRegex = regexp.MustCompile(`\b(True|False|None)\b`)
+)
+
+// ProblemStatement returns the text of a pasted problem before its first example or its constraints
+func ProblemStatement(text string) string {
+	if location := statementEndRegex.FindStringIndex(text); location != nil {
+		text = text[:location[0]]
+	}
+	return strings.TrimSpace(text)
+}
+
+// ParseExamples reads the "Input: ... Output: ..." blocks of a pasted problem into inputs and outputs of parameters.
+// Inputs are matched to parameters by name ("nums = [1,2]"), or by position when they are not named.
+// Values are reformatted as test values, Python's True, False and None read as JSON. Every example that cannot
+// be read is skipped with a warning.
+func ParseExamples(text string, parameters []model.Parameter) ([]model.InputOutput, []string) {
+	examples := []model.InputOutput{}
+	var warnings []string
+	for i, match := range exampleRegex.FindAllStringSubmatch(text, -1) {
+		example, err := parseExample(match[1], match[2], parameters)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("example %d skipped: %v", i+1, err))
+			continue
+		}
+		examples = append(examples, *example)
+	}
+	if len(examples) == 0 && len(warnings) == 0 {
+		warnings = append(warnings, `no "Input: ... Output: ..." examples found`)
+	}
+	return examples, warnings
+}
+
+func parseExample(input, output string, parameters []model.Parameter) (*model.InputOutput, error) {
+	values := splitTopLevel(strings.Join(strings.Fields(input), " "), ',')
+	if len(values) != len(parameters) {
+		return nil, fmt.Errorf("%d inputs for %d parameters", len(values), len(parameters))
+	}
+	example := &model.InputOutput{Parameters: make([]string, len(parameters))}
+	for i, value := range values {
+		position := i
+		if match := namedValueRegex.FindStringSubmatch(value); match != nil {
+			position = parameterIndex(parameters, match[1])
+			if position < 0 {
+				return nil, fmt.Errorf("no parameter is named %s", match[1])
+			}
+			value = match[2]
+		}
+		formatted, err := formatExampleValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("input %s: %w", parameters[position].Name, err)
+		}
+		example.Parameters[position] = formatted
+	}
+	formatted, err := formatExampleValue(output)
+	if err != nil {
+		return nil, fmt.Errorf("output: %w", err)
+	}
+	example.ExpectedOutput = formatted
+	return example, nil
+}
+
+// parameterIndex finds a parameter by its name as written in the example, in any case style
+func parameterIndex(parameters []model.Parameter, name string) int {
+	for i, param := range parameters {
+		if param.Name == name || param.Name == strcase.ToCamel(name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// formatExampleValue rewrites a value of an example as a test value
+func formatExampleValue(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	var converted strings.Builder
+	inString := false
+	for _, part := range strings.SplitAfter(value, `"`) {
+		// Outside string literals, Python literals become JSON ones
+		if !inString {
+			part = pythonLiteralRegex.ReplaceAllStringFunc(part, func(literal string) string {
+				return map[string]string{"True": "true", "False": "false", "None": "null"}[literal]
+			})
+		}
+		converted.WriteString(part)
+		if strings.HasSuffix(part, `"`) && !strings.HasSuffix(part, `\"`) {
+			inString = !inString
+		}
+	}
+	return parser_validator.ReformatStringOfType(converted.String())
+}
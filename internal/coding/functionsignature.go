@@ -15,6 +15,22 @@ var languageGenerators = map[model.PredefinedSupportedLanguage]func(model.Questi
 }
 
 func GenerateByQuestionAndLanguage(question model.Question, language model.PredefinedSupportedLanguage) (string, error) {
+	if question.IsClassDesign() {
+		classGenerator, exists := classGenerators[language]
+		if !exists {
+			return "", errors.New("unsupported language")
+		}
+		if question.ClassConfig == nil {
+			return "", errors.New("class question has no class configuration")
+		}
+		return classGenerator(*question.ClassConfig)
+	}
+	if question.IsInteractive() {
+		return GenerateInteractiveStarter(language)
+	}
+	if question.IsStdio() {
+		return GenerateStdioStarter(language)
+	}
 	generator, exists := languageGenerators[language]
 	if !exists {
 		return "", errors.New("unsupported language")
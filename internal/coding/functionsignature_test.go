@@ -0,0 +1,128 @@
+package coding_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/coding"
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/model"
+)
+
+var integer = model.AbstractType{Type: string(model.Integer)}
+
+func treeQuestion() model.Question {
+	tree := model.AbstractType{Type: string(model.TreeNode), TypeChildren: &integer}
+	return model.Question{
+		Title: `Sum */ """ the tree`,
+		FunctionConfig: model.FunctionConfig{
+			Name:       "sum_tree",
+			Parameters: &[]model.Parameter{{Name: "root_node", ParamType: tree, Description: "the root"}, {Name: "k", ParamType: integer}},
+			ReturnType: &integer,
+		},
+		Examples: []model.InputOutput{{Parameters: []string{"[1,2,3]", "2"}, ExpectedOutput: "6"}},
+	}
+}
+
+// checkSyntax parses code with the interpreter of language, when it is installed
+func checkSyntax(t *testing.T, language model.PredefinedSupportedLanguage, code string) {
+	t.Helper()
+	command := map[model.PredefinedSupportedLanguage][]string{
+		model.Python:     {"python3", "-c", "import ast, sys; ast.parse(open(sys.argv[1]).read())"},
+		model.JavaScript: {"node", "--check"},
+	}[language]
+	if command == nil {
+		return
+	}
+	if _, err := exec.LookPath(command[0]); err != nil {
+		t.Skipf("%s is not installed", command[0])
+	}
+	path := filepath.Join(t.TempDir(), "starter."+model.GetFileExtension(language))
+	if err := os.WriteFile(path, []byte(code), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if output, err := exec.Command(command[0], append(command[1:], path)...).CombinedOutput(); err != nil {
+		t.Errorf("the starter does not parse: %v\n%s\n%s", err, output, code)
+	}
+}
+
+func TestGenerateByQuestionAndLanguage(t *testing.T) {
+	array := model.AbstractType{Type: string(model.Array), TypeChildren: &integer}
+	void := model.Question{
+		Title:          "Reverse",
+		FunctionConfig: model.FunctionConfig{Name: "reverse", Parameters: &[]model.Parameter{{Name: "nums", ParamType: array}}, MutatedParameter: "nums"},
+	}
+	tests := []struct {
+		name     string
+		question model.Question
+		language model.PredefinedSupportedLanguage
+		want     []string
+		notWant  []string
+	}{
+		{"python", treeQuestion(), model.Python,
+			[]string{"import ds_utils as utils", "def sum_tree(root_node: utils.TreeNode[int], k: int) -> int:", `Sum */ \"\"\" the tree`,
+				"root_node (utils.TreeNode[int]) - the root", "Input: root_node = [1,2,3], k = 2", "Output: 6", "    pass"}, nil},
+		{"javascript", treeQuestion(), model.JavaScript,
+			[]string{"const utils = require('./ds_utils.js');", "function sumTree(rootNode, k) {", `Sum *\/ """ the tree`,
+				"@param {utils.TreeNode<number>} rootNode - the root", "// Input: rootNode = [1,2,3], k = 2", "// Output: 6"}, nil},
+		{"java", treeQuestion(), model.Java,
+			[]string{"import com.ds_utils.TreeNode;", "public Integer sumTree(TreeNode rootNode, Integer k) {", `Sum *\/ """ the tree`,
+				"@param rootNode - the root", "Input: rootNode = [1,2,3], k = 2", "return 0;"}, nil},
+		{"python void", void, model.Python, []string{"-> None:", "modify nums in place", "    pass"}, []string{"ds_utils", "Example"}},
+		{"javascript void", void, model.JavaScript, []string{"@returns {void} modify nums in place"}, []string{"ds_utils", "@example"}},
+		{"java void", void, model.Java, []string{"public void reverse(List<Integer> nums) {", "Modify nums in place."}, []string{"ds_utils", "return"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			starter, err := coding.GenerateByQuestionAndLanguage(test.question, test.language)
+			if err != nil {
+				t.Fatalf("failed to generate the starter: %v", err)
+			}
+			for _, want := range test.want {
+				if !strings.Contains(starter, want) {
+					t.Errorf("expected the starter to contain %q, got:\n%s", want, starter)
+				}
+			}
+			for _, notWant := range test.notWant {
+				if strings.Contains(starter, notWant) {
+					t.Errorf("expected the starter not to contain %q, got:\n%s", notWant, starter)
+				}
+			}
+			checkSyntax(t, test.language, starter)
+		})
+	}
+}
+
+func TestGenerateClassSignature(t *testing.T) {
+	tree := model.AbstractType{Type: string(model.TreeNode), TypeChildren: &integer}
+	classConfig := model.ClassConfig{
+		Name:        "Counter",
+		Constructor: &[]model.Parameter{{Name: "root", ParamType: tree}},
+		Methods:     []model.FunctionConfig{{Name: "add", Parameters: &[]model.Parameter{{Name: "value", ParamType: integer}}}, {Name: "total", ReturnType: &integer}},
+	}
+	tests := []struct {
+		language model.PredefinedSupportedLanguage
+		generate func(model.ClassConfig) (string, error)
+		want     []string
+	}{
+		{model.Python, coding.GeneratePythonClassSignature, []string{"import ds_utils as utils", "def __init__(self, root: utils.TreeNode[int]):", "def total(self) -> int:"}},
+		{model.JavaScript, coding.GenerateJavaScriptClassSignature, []string{"const utils = require('./ds_utils.js');", "constructor(root) {", "total() {"}},
+		{model.Java, coding.GenerateJavaClassSignature, []string{"public Integer total() {", "return 0;"}},
+	}
+	for _, test := range tests {
+		t.Run(string(test.language), func(t *testing.T) {
+			starter, err := test.generate(classConfig)
+			if err != nil {
+				t.Fatalf("failed to generate the class: %v", err)
+			}
+			for _, want := range test.want {
+				if !strings.Contains(starter, want) {
+					t.Errorf("expected the class to contain %q, got:\n%s", want, starter)
+				}
+			}
+			checkSyntax(t, test.language, starter)
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package coding
+
+import (
+	"errors"
+
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/model"
+)
+
+// Interactive questions are solved by full programs, which start from a note on talking with the judge
+var interactiveStarters = map[model.PredefinedSupportedLanguage]string{
+	model.Python: `# Talk with the judge over standard input and output, one line per query and answer.
+# Flush every query before reading the answer:
+#     print(query, flush=True)
+#     answer = input()
+`,
+	model.JavaScript: `// Talk with the judge over standard input and output, one line per query and answer:
+//     console.log(query);
+//     lines.on('line', (answer) => { ... });
+const readline = require('readline');
+const lines = readline.createInterface({ input: process.stdin });
+`,
+}
+
+// GenerateInteractiveStarter returns the starter program of an interactive question
+func GenerateInteractiveStarter(language model.PredefinedSupportedLanguage) (string, error) {
+	starter, exists := interactiveStarters[language]
+	if !exists {
+		return "", errors.New("unsupported language for interactive questions")
+	}
+	return starter, nil
+}
@@ -3,6 +3,7 @@ package coding
 import (
 	"bytes"
 	"fmt"
+	"sort"
 	"strings"
 	"text/template"
 
@@ -46,28 +47,100 @@ func mapToJavaType(paramType model.AbstractType) string {
 	return baseType
 }
 
-const javaFunctionTemplate = `public class {{.ClassName}} {
-    public  {{.ReturnType}} {{.FunctionName}}({{.Params}}) {
-       //TODO: implement this function
+// javaImports lists the imports a signature needs, in a stable order: the ds_utils classes of types,
+// and java.util.List when the declared Java types (the signature text) use it
+func javaImports(types []model.AbstractType, declaration string) []string {
+	needed := map[string]bool{}
+	collectTypeNames(types, func(typeName string) {
+		switch typeName {
+		case string(model.Graph), string(model.TreeNode), string(model.ListNode):
+			needed["com.ds_utils."+javaTypeMappings[typeName]] = true
+		}
+	})
+	if strings.Contains(declaration, "List") {
+		needed["java.util.List"] = true
+	}
+	imports := make([]string, 0, len(needed))
+	for name := range needed {
+		imports = append(imports, name)
+	}
+	sort.Strings(imports)
+	return imports
+}
+
+// javaDefaultValue returns a value of javaType, for starters that compile as given
+func javaDefaultValue(javaType string) string {
+	switch javaType {
+	case "Integer", "int":
+		return "0"
+	case "Long", "long":
+		return "0L"
+	case "Double", "double":
+		return "0.0"
+	case "Boolean", "boolean":
+		return "false"
+	case "char":
+		return `'\0'`
+	case "String":
+		return `""`
+	default:
+		return "null"
+	}
+}
+
+const javaFunctionTemplate = `{{range .Imports}}import {{.}};
+{{end}}{{if .Imports}}
+{{end}}public class {{.ClassName}} {
+    /**
+     * {{.Doc.Title}}
+{{- with .Doc.Mutated}}
+     * Modify {{.}} in place.
+{{- end}}
+{{- if .Doc.ExampleInput}}
+     *
+     * <pre>
+     * Input: {{.Doc.ExampleInput}}
+     * Output: {{.Doc.ExampleOutput}}
+     * </pre>
+{{- end}}
+     *
+{{- range .Doc.ParamsDocs}}
+     * @param {{.Name}}{{template "paramDoc" .}}
+{{- end}}
+{{- if .DefaultValue}}
+     * @return {@code {{.ReturnType}}}
+{{- end}}
+     */
+    public {{.ReturnType}} {{.FunctionName}}({{.Params}}) {
+        // TODO: implement this function
+{{- if .DefaultValue}}
+        return {{.DefaultValue}};
+{{- end}}
     }
-}`
+}
+`
 
 // question -> java signature
 func GenerateJavaSignature(question model.Question) (string, error) {
-	// Prepare data for template
-	paramList := []string{}
-	for _, param := range *question.FunctionConfig.Parameters {
-		paramList = append(paramList, fmt.Sprintf("%s %s", mapToJavaType(param.ParamType), ToJavaStyle(param.Name)))
+	// Prepare data for template, void functions modify the mutated parameter in place
+	params := javaParameters(question.FunctionConfig)
+	returnType := javaReturnType(question.FunctionConfig)
+	defaultValue := ""
+	if !question.FunctionConfig.IsVoid() {
+		defaultValue = javaDefaultValue(returnType)
 	}
-	data := map[string]string{
+	data := map[string]interface{}{
 		"ClassName":    "UserSolution",
+		"Imports":      javaImports(signatureTypes(question.FunctionConfig), returnType+" "+params),
 		"FunctionName": ToJavaStyle(question.FunctionConfig.Name),
-		"Params":       strings.Join(paramList, ", "),
-		"ReturnType":   mapToJavaType(*question.FunctionConfig.ReturnType),
+		"Params":       params,
+		"ReturnType":   returnType,
+		"DefaultValue": defaultValue,
+		"Doc":          describeFunction(question, ToJavaStyle, mapToJavaType, returnType, escapeBlockComment),
 	}
 
 	// Render the template
-	tmpl, err := template.New("javaFunc").Parse(javaFunctionTemplate)
+	tmpl, err := template.New("javaFunc").Parse(javaFunctionTemplate + paramDocTemplate)
 	if err != nil {
 		return "", err
 	}
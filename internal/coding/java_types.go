@@ -0,0 +1,137 @@
+package coding
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/model"
+)
+
+// Primitive Java types of the atomic types, String stays an object
+var javaPrimitiveMappings = map[string]string{
+	string(model.Integer): "int",
+	string(model.Double):  "double",
+	string(model.Boolean): "boolean",
+	string(model.String):  "String",
+}
+
+// javaArrayElement unwraps Arrays and Matrices of atomic types, e.g. a Matrix of Integers
+// is 2 dimensions of Integer. It reports false when paramType cannot be a Java array.
+func javaArrayElement(paramType model.AbstractType) (string, int, bool) {
+	dimensions := 0
+	current := paramType
+	for {
+		switch current.Type {
+		case string(model.Array):
+			dimensions++
+		case string(model.Matrix):
+			dimensions += 2
+		default:
+			_, atomic := javaPrimitiveMappings[current.Type]
+			return current.Type, dimensions, atomic
+		}
+		if current.TypeChildren == nil {
+			return "", 0, false
+		}
+		current = *current.TypeChildren
+	}
+}
+
+// mapToPrimitiveJavaType maps abstract types to Java primitives and arrays, e.g. int[][] for a Matrix of Integers.
+// Types without a primitive form keep their boxed one.
+func mapToPrimitiveJavaType(paramType model.AbstractType) string {
+	element, dimensions, ok := javaArrayElement(paramType)
+	if !ok {
+		return mapToJavaType(paramType)
+	}
+	return javaPrimitiveMappings[element] + strings.Repeat("[]", dimensions)
+}
+
+// JavaTypeOptions lists the Java types a value of paramType may be declared as: the boxed and primitive
+// forms, long instead of int, and char instead of one-character Strings (char[] for a whole String)
+func JavaTypeOptions(paramType model.AbstractType) []string {
+	boxed := mapToJavaType(paramType)
+	options := []string{boxed}
+	element, dimensions, ok := javaArrayElement(paramType)
+	if ok {
+		brackets := strings.Repeat("[]", dimensions)
+		options = append(options, javaPrimitiveMappings[element]+brackets)
+		switch element {
+		case string(model.Integer):
+			options = append(options, "long"+brackets, strings.ReplaceAll(boxed, "Integer", "Long"))
+		case string(model.String):
+			options = append(options, "char"+brackets)
+			if dimensions == 0 {
+				options = append(options, "char[]")
+			}
+		}
+	}
+	return slices.Compact(options)
+}
+
+// resolveJavaType returns the Java type a value is declared as: override when set, otherwise paramType in style
+func resolveJavaType(paramType model.AbstractType, override string, style model.JavaTypeStyle) string {
+	if override != "" {
+		return override
+	}
+	if style == model.JavaPrimitive {
+		return mapToPrimitiveJavaType(paramType)
+	}
+	return mapToJavaType(paramType)
+}
+
+// javaReturnType returns the Java return type of functionConfig, void when it returns nothing
+func javaReturnType(functionConfig model.FunctionConfig) string {
+	if functionConfig.IsVoid() {
+		return "void"
+	}
+	return resolveJavaType(*functionConfig.ReturnType, functionConfig.ReturnJavaType, functionConfig.GetJavaTypeStyle())
+}
+
+// javaParameters declares the parameters of functionConfig, e.g. "int[] nums, long target"
+func javaParameters(functionConfig model.FunctionConfig) string {
+	paramList := []string{}
+	for _, param := range functionParameters(functionConfig) {
+		javaType := resolveJavaType(param.ParamType, param.JavaType, functionConfig.GetJavaTypeStyle())
+		paramList = append(paramList, fmt.Sprintf("%s %s", javaType, ToJavaStyle(param.Name)))
+	}
+	return strings.Join(paramList, ", ")
+}
+
+// ValidateJavaTypes checks the Java type style of functionConfig and that every Java type override
+// fits its abstract type. Every issue is located relative to location (e.g. "function_config").
+func ValidateJavaTypes(functionConfig model.FunctionConfig, location string) []model.ValidationIssue {
+	var issues []model.ValidationIssue
+	if !slices.Contains(model.JavaTypeStyles, functionConfig.GetJavaTypeStyle()) {
+		issues = append(issues, model.ValidationIssue{Location: location + ".java_type_style", Value: string(functionConfig.JavaTypeStyle), Message: fmt.Sprintf("unknown Java type style, must be one of %v", model.JavaTypeStyles)})
+	}
+	issues = append(issues, ValidateParameterJavaTypes(functionParameters(functionConfig), location+".parameters")...)
+	if functionConfig.ReturnJavaType != "" {
+		if functionConfig.ReturnType == nil {
+			issues = append(issues, model.ValidationIssue{Location: location + ".return_java_type", Value: functionConfig.ReturnJavaType, Message: "void functions have no return type to override"})
+		} else {
+			issues = append(issues, validateJavaType(*functionConfig.ReturnType, functionConfig.ReturnJavaType, location+".return_java_type")...)
+		}
+	}
+	return issues
+}
+
+// ValidateParameterJavaTypes checks that the Java type override of every parameter fits its abstract type
+func ValidateParameterJavaTypes(parameters []model.Parameter, location string) []model.ValidationIssue {
+	var issues []model.ValidationIssue
+	for i, param := range parameters {
+		issues = append(issues, validateJavaType(param.ParamType, param.JavaType, fmt.Sprintf("%s[%d].java_type", location, i))...)
+	}
+	return issues
+}
+
+func validateJavaType(paramType model.AbstractType, override, location string) []model.ValidationIssue {
+	if override == "" {
+		return nil
+	}
+	if options := JavaTypeOptions(paramType); !slices.Contains(options, override) {
+		return []model.ValidationIssue{{Location: location, Expected: paramType.ToPrint(), Value: override, Message: fmt.Sprintf("Java type must be one of %v", options)}}
+	}
+	return nil
+}
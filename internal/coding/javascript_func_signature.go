@@ -49,15 +49,25 @@ func mapToJSType(paramType model.AbstractType) string {
 }
 
 
-const jsFunctionTemplate = `/**
- * {{- range .ParamsDocs }}
- * @param {{ .Type }} {{ .Name }}
- * {{- end }}
- * @returns {{ .ReturnType }}
+const jsFunctionTemplate = `{{with .UtilsImport}}{{.}}
+
+{{end}}/**
+ * {{.Doc.Title}}
+ *
+{{- range .Doc.ParamsDocs}}
+ * @param {{"{"}}{{.Type}}{{"}"}} {{.Name}}{{template "paramDoc" .}}
+{{- end}}
+ * @returns {{"{"}}{{.ReturnType}}{{"}"}}{{with .Doc.Mutated}} modify {{.}} in place{{end}}
+{{- if .Doc.ExampleInput}}
+ * @example
+ * // Input: {{.Doc.ExampleInput}}
+ * // Output: {{.Doc.ExampleOutput}}
+{{- end}}
  */
 function {{.FunctionName}}({{.Params}}) {
     // TODO: Implement this function
-}`
+}
+`
 
 const tsFunctionTemplate = `function {{.FunctionName}}({{.Params}}) {
     // TODO: Implement this function
@@ -66,27 +76,25 @@ const tsFunctionTemplate = `function {{.FunctionName}}({{.Params}}) {
 func GenerateJavaScriptSignature(question model.Question) (string, error) {
 	// Prepare data for JSDoc and function signature
 	paramList := []string{}
-	paramDocs := []map[string]string{}
-
-	for _, param := range *question.FunctionConfig.Parameters {
-		paramList = append(paramList,ToJSStyle( param.Name))
-
-		// Add JSDoc details for each parameter
-		paramDocs = append(paramDocs, map[string]string{
-			"Name": ToJSStyle(param.Name),
-			"Type": mapToJSType(param.ParamType),
-		})
+	for _, param := range functionParameters(question.FunctionConfig) {
+		paramList = append(paramList, ToJSStyle(param.Name))
 	}
 
+	// Void functions modify the mutated parameter in place
+	returnType := "void"
+	if !question.FunctionConfig.IsVoid() {
+		returnType = mapToJSType(*question.FunctionConfig.ReturnType)
+	}
 	data := map[string]interface{}{
 		"FunctionName": ToJSStyle(question.FunctionConfig.Name),
 		"Params":       strings.Join(paramList, ", "),
-		"ParamsDocs":   paramDocs,
-		"ReturnType":   mapToJSType(*question.FunctionConfig.ReturnType),
+		"ReturnType":   returnType,
+		"UtilsImport":  utilsImport(model.JavaScript, signatureTypes(question.FunctionConfig)),
+		"Doc":          describeFunction(question, ToJSStyle, mapToJSType, returnType, escapeBlockComment),
 	}
 
 	// Render the template
-	tmpl, err := template.New("jsFunc").Parse(jsFunctionTemplate)
+	tmpl, err := template.New("jsFunc").Parse(jsFunctionTemplate + paramDocTemplate)
 	if err != nil {
 		return "", err
 	}
@@ -46,24 +46,54 @@ func mapToPythonType(paramType model.AbstractType) string {
 	return baseType
 }
 
-const pythonFunctionTemplate = `def {{.FunctionName}}({{.Params}}) -> {{.ReturnType}}:`
+const pythonFunctionTemplate = `{{with .UtilsImport}}{{.}}
 
 
+{{end}}def {{.FunctionName}}({{.Params}}) -> {{.ReturnType}}:
+    """
+    {{.Doc.Title}}
+{{- with .Doc.ParamsDocs}}
+
+    Args:
+{{- range .}}
+        {{.Name}} ({{.Type}}){{template "paramDoc" .}}
+{{- end}}
+{{- end}}
+
+    Returns:
+        {{.ReturnType}}{{with .Doc.Mutated}}: modify {{.}} in place{{end}}
+{{- if .Doc.ExampleInput}}
+
+    Example:
+        Input: {{.Doc.ExampleInput}}
+        Output: {{.Doc.ExampleOutput}}
+{{- end}}
+    """
+    pass
+`
+
 // question -> python signature
 func GeneratePythonSignature(question model.Question) (string, error) {
 	// Prepare data for template
 	paramList := []string{}
-	for _, param := range *question.FunctionConfig.Parameters {
-		paramList = append(paramList, fmt.Sprintf("%s: %s",  ToPythonStyle(param.Name), mapToPythonType(param.ParamType)))
+	for _, param := range functionParameters(question.FunctionConfig) {
+		paramList = append(paramList, fmt.Sprintf("%s: %s", ToPythonStyle(param.Name), mapToPythonType(param.ParamType)))
+	}
+	// Void functions modify the mutated parameter in place
+	returnType := "None"
+	if !question.FunctionConfig.IsVoid() {
+		returnType = mapToPythonType(*question.FunctionConfig.ReturnType)
 	}
-	data := map[string]string{
+	data := map[string]interface{}{
 		"FunctionName": ToPythonStyle(question.FunctionConfig.Name),
 		"Params":       strings.Join(paramList, ", "),
-		"ReturnType":   mapToPythonType(*question.FunctionConfig.ReturnType),
+		"ReturnType":   returnType,
+		"UtilsImport":  utilsImport(model.Python, signatureTypes(question.FunctionConfig)),
+		"Doc":          describeFunction(question, ToPythonStyle, mapToPythonType, returnType, escapeDocstring),
 	}
 
 	// Render the template
-	tmpl, err := template.New("pythonFunc").Parse(pythonFunctionTemplate)
+	tmpl, err := template.New("pythonFunc").Parse(pythonFunctionTemplate + paramDocTemplate)
 	if err != nil {
 		return "", err
 	}
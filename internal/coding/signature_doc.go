@@ -0,0 +1,115 @@
+package coding
+
+import (
+	"strings"
+
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/model"
+)
+
+// signatureDoc holds what the function templates need to render the doc comment of a starter
+type signatureDoc struct {
+	Title         string
+	ParamsDocs    []map[string]string
+	ReturnType    string
+	Mutated       string // Void functions only, the parameter to modify in place
+	ExampleInput  string // Empty when the question has no examples
+	ExampleOutput string
+}
+
+// paramDocTemplate renders the description and constraints after a parameter name, e.g. " - the tree root (1 <= value <= 100)"
+const paramDocTemplate = `{{define "paramDoc"}}
+{{- if .Description}} - {{.Description}}{{if .Constraints}} ({{.Constraints}}){{end}}
+{{- else if .Constraints}} - {{.Constraints}}{{end}}
+{{- end}}`
+
+// describeFunction prepares the doc comment of question's function, with names in style and types mapped by mapType.
+// escape makes the free text (title, descriptions, example values) safe inside the language's comment.
+func describeFunction(question model.Question, style func(string) string, mapType func(model.AbstractType) string, returnType string, escape func(string) string) signatureDoc {
+	doc := signatureDoc{Title: escape(question.Title), ReturnType: returnType}
+	parameters := functionParameters(question.FunctionConfig)
+	for _, param := range parameters {
+		doc.ParamsDocs = append(doc.ParamsDocs, map[string]string{
+			"Name":        style(param.Name),
+			"Type":        mapType(param.ParamType),
+			"Description": escape(param.Description),
+			"Constraints": escape(param.Constraints.String()),
+		})
+	}
+	if question.FunctionConfig.IsVoid() && question.FunctionConfig.MutatedParameter != "" {
+		doc.Mutated = style(question.FunctionConfig.MutatedParameter)
+	}
+
+	if len(question.Examples) > 0 && len(question.Examples[0].Parameters) == len(parameters) {
+		example := question.Examples[0]
+		inputs := make([]string, len(parameters))
+		for i, param := range parameters {
+			inputs[i] = style(param.Name) + " = " + example.Parameters[i]
+		}
+		doc.ExampleInput = escape(strings.Join(inputs, ", "))
+		doc.ExampleOutput = escape(example.ExpectedOutput)
+	}
+	return doc
+}
+
+func functionParameters(functionConfig model.FunctionConfig) []model.Parameter {
+	if functionConfig.Parameters == nil {
+		return []model.Parameter{}
+	}
+	return *functionConfig.Parameters
+}
+
+// signatureTypes lists every parameter type and the return type of functionConfig
+func signatureTypes(functionConfig model.FunctionConfig) []model.AbstractType {
+	types := []model.AbstractType{}
+	for _, param := range functionParameters(functionConfig) {
+		types = append(types, param.ParamType)
+	}
+	if functionConfig.ReturnType != nil {
+		types = append(types, *functionConfig.ReturnType)
+	}
+	return types
+}
+
+// classTypes lists every constructor and method type of classConfig
+func classTypes(classConfig model.ClassConfig) []model.AbstractType {
+	types := signatureTypes(model.FunctionConfig{Parameters: classConfig.Constructor})
+	for _, method := range classConfig.Methods {
+		types = append(types, signatureTypes(method)...)
+	}
+	return types
+}
+
+// collectTypeNames walks types and their children, calling visit with every type name found
+func collectTypeNames(types []model.AbstractType, visit func(string)) {
+	for _, abstractType := range types {
+		for current := &abstractType; current != nil; current = current.TypeChildren {
+			visit(current.Type)
+			if current.WeightType != nil {
+				collectTypeNames([]model.AbstractType{*current.WeightType}, visit)
+			}
+		}
+	}
+}
+
+// usesDataStructures reports whether any of types needs the ds_utils module
+func usesDataStructures(types []model.AbstractType) bool {
+	uses := false
+	collectTypeNames(types, func(typeName string) {
+		switch typeName {
+		case string(model.Graph), string(model.TreeNode), string(model.ListNode):
+			uses = true
+		}
+	})
+	return uses
+}
+
+// escapeDocstring keeps text from ending or breaking a Python docstring
+func escapeDocstring(text string) string {
+	text = strings.ReplaceAll(text, `\`, `\\`)
+	return strings.ReplaceAll(text, `"""`, `\"\"\"`)
+}
+
+// escapeBlockComment keeps text from ending a /* */ comment
+func escapeBlockComment(text string) string {
+	return strings.ReplaceAll(text, "*/", "*\\/")
+}
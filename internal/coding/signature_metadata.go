@@ -0,0 +1,134 @@
+package coding
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/model"
+)
+
+// Imports of the ds_utils module, Java imports each data structure class instead
+const (
+	pythonUtilsImport = "import ds_utils as utils"
+	jsUtilsImport     = "const utils = require('./ds_utils.js');"
+)
+
+// signatureStyle writes names and types the way one language does
+type signatureStyle struct {
+	name       func(string) string
+	paramType  func(model.FunctionConfig, model.Parameter) string
+	returnType func(model.FunctionConfig) string
+}
+
+var signatureStyles = map[model.PredefinedSupportedLanguage]signatureStyle{
+	model.Python: {
+		name: ToPythonStyle,
+		paramType: func(_ model.FunctionConfig, param model.Parameter) string {
+			return mapToPythonType(param.ParamType)
+		},
+		returnType: func(functionConfig model.FunctionConfig) string {
+			if functionConfig.IsVoid() {
+				return "None"
+			}
+			return mapToPythonType(*functionConfig.ReturnType)
+		},
+	},
+	model.JavaScript: {
+		name: ToJSStyle,
+		paramType: func(_ model.FunctionConfig, param model.Parameter) string {
+			return mapToJSType(param.ParamType)
+		},
+		returnType: func(functionConfig model.FunctionConfig) string {
+			if functionConfig.IsVoid() {
+				return "void"
+			}
+			return mapToJSType(*functionConfig.ReturnType)
+		},
+	},
+	model.Java: {
+		name: ToJavaStyle,
+		paramType: func(functionConfig model.FunctionConfig, param model.Parameter) string {
+			return resolveJavaType(param.ParamType, param.JavaType, functionConfig.GetJavaTypeStyle())
+		},
+		returnType: javaReturnType,
+	},
+}
+
+// utilsImport returns the snippet importing the ds_utils types that types use, empty when they use none
+func utilsImport(language model.PredefinedSupportedLanguage, types []model.AbstractType) string {
+	if !usesDataStructures(types) {
+		return ""
+	}
+	switch language {
+	case model.Python:
+		return pythonUtilsImport
+	case model.JavaScript:
+		return jsUtilsImport
+	case model.Java:
+		var lines []string
+		for _, name := range javaImports(types, "") {
+			lines = append(lines, fmt.Sprintf("import %s;", name))
+		}
+		return strings.Join(lines, "\n")
+	default:
+		return ""
+	}
+}
+
+// SignatureLanguages lists the languages question can be solved in, the ones it lists or every supported
+// language, narrowed to the languages with a judge for interactive questions and to StdioLanguages for stdio ones
+func SignatureLanguages(question model.Question) []model.PredefinedSupportedLanguage {
+	languages := model.PredefinedSupportedLanguages
+	if len(question.Languages) > 0 {
+		languages = nil
+		for _, language := range question.Languages {
+			languages = append(languages, model.PredefinedSupportedLanguage(language))
+		}
+	}
+	return slices.DeleteFunc(slices.Clone(languages), func(language model.PredefinedSupportedLanguage) bool {
+		switch {
+		case question.IsInteractive():
+			return question.InteractiveConfig == nil || question.InteractiveConfig.Judge[language] == ""
+		case question.IsStdio():
+			return !slices.Contains(model.StdioLanguages, language)
+		default:
+			_, exists := languageGenerators[language]
+			return !exists
+		}
+	})
+}
+
+// DescribeSignature generates the starter code of question in language, with its names and types as written in the language
+func DescribeSignature(question model.Question, language model.PredefinedSupportedLanguage) (*model.LanguageSignature, error) {
+	starter, err := GenerateByQuestionAndLanguage(question, language)
+	if err != nil {
+		return nil, err
+	}
+	signature := &model.LanguageSignature{Language: language, FunctionSignature: starter, Parameters: []model.ParameterSignature{}}
+	if question.IsInteractive() || question.IsStdio() {
+		return signature, nil
+	}
+
+	style := signatureStyles[language]
+	function := question.FunctionConfig
+	types := signatureTypes(function)
+	signature.FunctionName = style.name(function.Name)
+	if question.IsClassDesign() {
+		// Class names are used as is in every language
+		function = model.FunctionConfig{Name: question.ClassConfig.Name, Parameters: question.ClassConfig.Constructor}
+		types = classTypes(*question.ClassConfig)
+		signature.FunctionName = question.ClassConfig.Name
+	} else {
+		signature.ReturnType = style.returnType(function)
+	}
+	for _, param := range functionParameters(function) {
+		signature.Parameters = append(signature.Parameters, model.ParameterSignature{
+			Name:         style.name(param.Name),
+			OriginalName: param.Name,
+			Type:         style.paramType(function, param),
+		})
+	}
+	signature.UtilsImport = utilsImport(language, types)
+	return signature, nil
+}
@@ -0,0 +1,83 @@
+package coding_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/coding"
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/model"
+)
+
+func TestDescribeSignature(t *testing.T) {
+	tests := []struct {
+		language    model.PredefinedSupportedLanguage
+		name        string
+		parameters  []model.ParameterSignature
+		returnType  string
+		utilsImport string
+	}{
+		{model.Python, "sum_tree", []model.ParameterSignature{
+			{Name: "root_node", OriginalName: "root_node", Type: "utils.TreeNode[int]"}, {Name: "k", OriginalName: "k", Type: "int"},
+		}, "int", "import ds_utils as utils"},
+		{model.JavaScript, "sumTree", []model.ParameterSignature{
+			{Name: "rootNode", OriginalName: "root_node", Type: "utils.TreeNode<number>"}, {Name: "k", OriginalName: "k", Type: "number"},
+		}, "number", "const utils = require('./ds_utils.js');"},
+		{model.Java, "sumTree", []model.ParameterSignature{
+			{Name: "rootNode", OriginalName: "root_node", Type: "TreeNode"}, {Name: "k", OriginalName: "k", Type: "Integer"},
+		}, "Integer", "import com.ds_utils.TreeNode;"},
+	}
+	for _, test := range tests {
+		t.Run(string(test.language), func(t *testing.T) {
+			signature, err := coding.DescribeSignature(treeQuestion(), test.language)
+			if err != nil {
+				t.Fatalf("failed to describe the signature: %v", err)
+			}
+			if signature.Language != test.language || signature.FunctionName != test.name || signature.ReturnType != test.returnType || signature.UtilsImport != test.utilsImport {
+				t.Errorf("expected %s returning %s with import %q, got %+v", test.name, test.returnType, test.utilsImport, signature)
+			}
+			if !reflect.DeepEqual(signature.Parameters, test.parameters) {
+				t.Errorf("expected parameters %+v, got %+v", test.parameters, signature.Parameters)
+			}
+			if !strings.Contains(signature.FunctionSignature, test.name+"(") {
+				t.Errorf("expected the starter code to define %s, got:\n%s", test.name, signature.FunctionSignature)
+			}
+		})
+	}
+
+	classQuestion := model.Question{
+		Kind:        model.ClassQuestion,
+		ClassConfig: &model.ClassConfig{Name: "Counter", Constructor: &[]model.Parameter{{Name: "start_value", ParamType: integer}}, Methods: []model.FunctionConfig{{Name: "total", ReturnType: &integer}}},
+	}
+	signature, err := coding.DescribeSignature(classQuestion, model.JavaScript)
+	if err != nil {
+		t.Fatalf("failed to describe the class signature: %v", err)
+	}
+	want := []model.ParameterSignature{{Name: "startValue", OriginalName: "start_value", Type: "number"}}
+	if signature.FunctionName != "Counter" || signature.ReturnType != "" || signature.UtilsImport != "" || !reflect.DeepEqual(signature.Parameters, want) {
+		t.Errorf("expected the class name and constructor parameters, got %+v", signature)
+	}
+}
+
+func TestSignatureLanguages(t *testing.T) {
+	tests := []struct {
+		name     string
+		question model.Question
+		want     []model.PredefinedSupportedLanguage
+	}{
+		{"every language", model.Question{}, model.PredefinedSupportedLanguages},
+		{"listed languages", model.Question{Languages: []string{string(model.Java), string(model.Python)}}, []model.PredefinedSupportedLanguage{model.Java, model.Python}},
+		{"interactive with a judge", model.Question{
+			Kind:              model.InteractiveQuestion,
+			InteractiveConfig: &model.InteractiveConfig{Judge: map[model.PredefinedSupportedLanguage]string{model.JavaScript: "async function judge() {}"}},
+		}, []model.PredefinedSupportedLanguage{model.JavaScript}},
+		{"stdio", model.Question{Kind: model.StdioQuestion}, model.StdioLanguages},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := coding.SignatureLanguages(test.question); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("expected %v, got %v", test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,390 @@
+package coding
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/model"
+	"github.com/ettle/strcase"
+)
+
+// SignatureSource is the language a pasted signature is written in, TypeScript included for problems copied from the web
+type SignatureSource string
+
+const (
+	PythonSource     SignatureSource = "python"
+	JavaSource       SignatureSource = "java"
+	TypeScriptSource SignatureSource = "typescript"
+)
+
+// ParsedSignature is a function configuration read back from a signature, with what could not be read exactly
+type ParsedSignature struct {
+	Source         SignatureSource
+	FunctionConfig model.FunctionConfig
+	Warnings       []string
+}
+
+var (
+	pythonSignatureRegex     = regexp.MustCompile(`(?s)def\s+(\w+)\s*\((.*)\)\s*(?:->\s*(.+?))?\s*:?\s*$`)
+	typeScriptSignatureRegex = regexp.MustCompile(`(?s)function\s+(\w+)\s*\((.*)\)\s*(?::\s*(.+?))?\s*\{?\s*$`)
+	javaSignatureRegex       = regexp.MustCompile(`(?s)^(?:(?:public|private|protected|static|final)\s+)*(.+?)\s+(\w+)\s*\((.*)\)\s*\{?\s*$`)
+	// The class header, on its own line or followed by the method on the same line as in one-line pastes
+	solutionClassRegex = regexp.MustCompile(`(?m)^\s*(?:public\s+)?class\s+\w+\s*(?:\([^)]*\))?\s*[:{]`)
+)
+
+// ParseSignature reads a LeetCode-style Python, Java or TypeScript function signature back into a function
+// configuration, e.g. "def twoSum(self, nums: List[int], target: int) -> List[int]:". The enclosing class
+// header and the body, if pasted too, are ignored, on their own lines or not. Names are converted to camelCase.
+func ParseSignature(signature string) (*ParsedSignature, error) {
+	declaration := signatureDeclaration(signature)
+	if declaration == "" {
+		return nil, fmt.Errorf("no function signature found")
+	}
+	parsed := &ParsedSignature{}
+	var name, parameters, returnType string
+	if match := pythonSignatureRegex.FindStringSubmatch(declaration); strings.HasPrefix(declaration, "def ") && match != nil {
+		parsed.Source, name, parameters, returnType = PythonSource, match[1], match[2], match[3]
+	} else if match := typeScriptSignatureRegex.FindStringSubmatch(declaration); match != nil {
+		parsed.Source, name, parameters, returnType = TypeScriptSource, match[1], match[2], match[3]
+	} else if match := javaSignatureRegex.FindStringSubmatch(declaration); match != nil {
+		parsed.Source, returnType, name, parameters = JavaSource, match[1], match[2], match[3]
+	} else {
+		return nil, fmt.Errorf("unrecognized signature: %s, expected a Python, Java or TypeScript function", declaration)
+	}
+	parsed.FunctionConfig.Name = strcase.ToCamel(name)
+
+	params := []model.Parameter{}
+	var javaTypes []string
+	for _, declared := range splitTopLevel(parameters, ',') {
+		param, javaType, err := parsed.parseParameter(strings.TrimSpace(declared))
+		if err != nil {
+			return nil, err
+		}
+		if param != nil {
+			params = append(params, *param)
+			javaTypes = append(javaTypes, javaType)
+		}
+	}
+	parsed.FunctionConfig.Parameters = &params
+
+	returnType = strings.TrimSpace(returnType)
+	switch {
+	case returnType == "" && parsed.Source != JavaSource:
+		parsed.Warnings = append(parsed.Warnings, "the signature declares no return type, the function is assumed to return nothing")
+	case returnType == "None" || returnType == "void":
+	default:
+		abstractType, err := parsed.parseType(returnType)
+		if err != nil {
+			return nil, fmt.Errorf("return type: %w", err)
+		}
+		parsed.FunctionConfig.ReturnType = abstractType
+	}
+	if parsed.FunctionConfig.IsVoid() {
+		parsed.guessMutatedParameter()
+	}
+	if parsed.Source == JavaSource {
+		parsed.keepJavaTypes(javaTypes, returnType)
+	}
+	return parsed, nil
+}
+
+// signatureDeclaration cuts the function declaration out of pasted code: the first line declaring
+// a function after an optional class header, up to its closing parenthesis and return type
+func signatureDeclaration(signature string) string {
+	signature = solutionClassRegex.ReplaceAllString(signature, "")
+	signature = strings.TrimSpace(signature)
+	depth := 0
+	for i, c := range signature {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ':', '{':
+			// The end of the declaration: Python's colon or the opening brace, after the parameters
+			// and, for TypeScript, after the return type annotation
+			if depth == 0 && strings.Contains(signature[:i], ")") {
+				if c == ':' && !strings.HasPrefix(signature, "def ") {
+					continue
+				}
+				return strings.Join(strings.Fields(signature[:i]), " ")
+			}
+		case '\n':
+			if depth == 0 && strings.Contains(signature[:i], ")") {
+				return strings.Join(strings.Fields(signature[:i]), " ")
+			}
+		}
+	}
+	return strings.Join(strings.Fields(signature), " ")
+}
+
+// parseParameter reads one declared parameter, nil for Python's self. For Java it also returns the declared type.
+func (p *ParsedSignature) parseParameter(declared string) (*model.Parameter, string, error) {
+	if declared == "" {
+		return nil, "", nil
+	}
+	var name, typeName string
+	switch p.Source {
+	case PythonSource:
+		name, typeName, _ = strings.Cut(declared, ":")
+		if name, _, _ = strings.Cut(name, "="); strings.TrimSpace(name) == "self" {
+			return nil, "", nil
+		}
+		typeName, _, _ = strings.Cut(typeName, "=")
+	case TypeScriptSource:
+		name, typeName, _ = strings.Cut(declared, ":")
+		name = strings.TrimSuffix(strings.TrimSpace(name), "?")
+	case JavaSource:
+		declared = strings.TrimPrefix(declared, "final ")
+		index := strings.LastIndexAny(declared, " \t]>")
+		if index < 0 {
+			return nil, "", fmt.Errorf("parameter %q has no type", declared)
+		}
+		typeName, name = declared[:index+1], declared[index+1:]
+	}
+	name, typeName = strings.TrimSpace(name), strings.TrimSpace(typeName)
+	if typeName == "" {
+		return nil, "", fmt.Errorf("parameter %q has no type annotation", name)
+	}
+	abstractType, err := p.parseType(typeName)
+	if err != nil {
+		return nil, "", fmt.Errorf("parameter %q: %w", name, err)
+	}
+	return &model.Parameter{Name: strcase.ToCamel(name), ParamType: *abstractType}, strings.Join(strings.Fields(typeName), ""), nil
+}
+
+// parseType reads a declared type of the signature's language into an abstract type
+func (p *ParsedSignature) parseType(declared string) (*model.AbstractType, error) {
+	reader := &typeReader{tokens: typeTokenRegex.FindAllString(declared, -1), source: p.Source}
+	abstractType, err := reader.read()
+	if err != nil {
+		return nil, fmt.Errorf("unsupported type %s: %w", declared, err)
+	}
+	if !reader.done() {
+		return nil, fmt.Errorf("unsupported type %s", declared)
+	}
+	if reader.guessedNumber {
+		p.addWarning(fmt.Sprintf("%s is read as Integer, change it to Double if it holds fractions", declared))
+	}
+	return abstractType, nil
+}
+
+func (p *ParsedSignature) addWarning(warning string) {
+	for _, existing := range p.Warnings {
+		if existing == warning {
+			return
+		}
+	}
+	p.Warnings = append(p.Warnings, warning)
+}
+
+// guessMutatedParameter takes the first parameter that can be modified in place as the one a void function modifies
+func (p *ParsedSignature) guessMutatedParameter() {
+	for _, param := range *p.FunctionConfig.Parameters {
+		switch param.ParamType.Type {
+		case string(model.Array), string(model.Matrix), string(model.ListNode), string(model.TreeNode):
+			p.FunctionConfig.MutatedParameter = param.Name
+			p.addWarning(fmt.Sprintf("the function returns nothing, %s is assumed to be the parameter it modifies", param.Name))
+			return
+		}
+	}
+	p.addWarning("the function returns nothing and modifies none of its parameters, set a return type or a mutated parameter")
+}
+
+// keepJavaTypes keeps the Java types as declared: the primitive style when the signature uses primitives,
+// and overrides for the types the style would declare differently
+func (p *ParsedSignature) keepJavaTypes(javaTypes []string, returnType string) {
+	config := &p.FunctionConfig
+	declared := append(append([]string{}, javaTypes...), strings.Join(strings.Fields(returnType), ""))
+	for _, javaType := range declared {
+		if javaPrimitiveRegex.MatchString(javaType) {
+			config.JavaTypeStyle = model.JavaPrimitive
+			break
+		}
+	}
+	override := func(abstractType model.AbstractType, javaType string) string {
+		if javaType == resolveJavaType(abstractType, "", config.GetJavaTypeStyle()) {
+			return ""
+		}
+		for _, option := range JavaTypeOptions(abstractType) {
+			if option == javaType {
+				return javaType
+			}
+		}
+		p.addWarning(fmt.Sprintf("Java type %s has no equivalent, it is declared as %s", javaType, resolveJavaType(abstractType, "", config.GetJavaTypeStyle())))
+		return ""
+	}
+	for i := range *config.Parameters {
+		param := &(*config.Parameters)[i]
+		param.JavaType = override(param.ParamType, javaTypes[i])
+	}
+	if config.ReturnType != nil {
+		config.ReturnJavaType = override(*config.ReturnType, strings.Join(strings.Fields(returnType), ""))
+	}
+}
+
+var (
+	typeTokenRegex     = regexp.MustCompile(`[A-Za-z_][\w.]*|\[\]|[\[\]<>,|?]|'[^']*'|"[^"]*"`)
+	javaPrimitiveRegex = regexp.MustCompile(`^(int|long|short|byte|double|float|boolean|char)(\[\])*$`)
+)
+
+// Atomic types by their name in each signature language
+var atomicTypeNames = map[SignatureSource]map[string]model.AtomicType{
+	PythonSource: {"int": model.Integer, "float": model.Double, "str": model.String, "bool": model.Boolean},
+	JavaSource: {
+		"int": model.Integer, "long": model.Integer, "short": model.Integer, "byte": model.Integer, "Integer": model.Integer, "Long": model.Integer,
+		"double": model.Double, "float": model.Double, "Double": model.Double, "Float": model.Double,
+		"boolean": model.Boolean, "Boolean": model.Boolean,
+		"char": model.String, "Character": model.String, "String": model.String,
+	},
+	TypeScriptSource: {"number": model.Integer, "bigint": model.Integer, "string": model.String, "boolean": model.Boolean},
+}
+
+// Generic types that hold a sequence of their type argument
+var sequenceTypeNames = map[string]bool{"List": true, "list": true, "Sequence": true, "ArrayList": true, "Array": true}
+
+// typeReader reads a declared type token by token, e.g. List [ List [ int ] ] or TreeNode | null
+type typeReader struct {
+	tokens        []string
+	position      int
+	source        SignatureSource
+	guessedNumber bool // TypeScript number, which may be an Integer or a Double
+}
+
+func (r *typeReader) done() bool {
+	return r.position >= len(r.tokens)
+}
+
+func (r *typeReader) peek() string {
+	if r.done() {
+		return ""
+	}
+	return r.tokens[r.position]
+}
+
+func (r *typeReader) next() string {
+	token := r.peek()
+	r.position++
+	return token
+}
+
+func (r *typeReader) expect(token string) error {
+	if got := r.next(); got != token {
+		return fmt.Errorf("expected %q, got %q", token, got)
+	}
+	return nil
+}
+
+// read reads a type with its array suffixes and nullable unions
+func (r *typeReader) read() (*model.AbstractType, error) {
+	abstractType, err := r.readBase()
+	if err != nil {
+		return nil, err
+	}
+	for r.peek() == "[]" {
+		r.next()
+		abstractType = sequenceOf(abstractType)
+	}
+	// TypeScript's TreeNode | null and nullable markers are the same type for test values
+	for r.peek() == "|" || r.peek() == "?" {
+		r.next()
+		if r.peek() == "null" || r.peek() == "undefined" || r.peek() == "None" {
+			r.next()
+		}
+	}
+	return abstractType, nil
+}
+
+func (r *typeReader) readBase() (*model.AbstractType, error) {
+	name := r.next()
+	name = strings.Trim(name, `'"`) // Python forward references such as 'TreeNode'
+	name = strings.TrimPrefix(strings.TrimPrefix(name, "typing."), "java.util.")
+	if name == "" {
+		return nil, fmt.Errorf("missing type")
+	}
+	if atomic, ok := atomicTypeNames[r.source][name]; ok {
+		r.guessedNumber = r.guessedNumber || name == "number"
+		return &model.AbstractType{Type: string(atomic)}, nil
+	}
+	switch {
+	case name == "Optional":
+		return r.readArgument()
+	case sequenceTypeNames[name]:
+		element, err := r.readArgument()
+		if err != nil {
+			return nil, err
+		}
+		return sequenceOf(element), nil
+	case name == "TreeNode" || name == "ListNode":
+		// Their values are Integers on LeetCode
+		return &model.AbstractType{Type: name, TypeChildren: &model.AbstractType{Type: string(model.Integer)}}, nil
+	}
+	return nil, fmt.Errorf("%s has no abstract type", name)
+}
+
+// readArgument reads the single type argument of a generic type, in [] for Python and <> otherwise
+func (r *typeReader) readArgument() (*model.AbstractType, error) {
+	open, close := "<", ">"
+	if r.source == PythonSource {
+		open, close = "[", "]"
+	}
+	if err := r.expect(open); err != nil {
+		return nil, err
+	}
+	argument, err := r.read()
+	if err != nil {
+		return nil, err
+	}
+	return argument, r.expect(close)
+}
+
+// sequenceOf returns an Array of element, a Matrix for Arrays of atomic types
+func sequenceOf(element *model.AbstractType) *model.AbstractType {
+	if element.Type == string(model.Array) && element.TypeChildren != nil && isAtomic(element.TypeChildren.Type) {
+		return &model.AbstractType{Type: string(model.Matrix), TypeChildren: element.TypeChildren}
+	}
+	return &model.AbstractType{Type: string(model.Array), TypeChildren: element}
+}
+
+func isAtomic(typeName string) bool {
+	for _, atomic := range model.AtomicTypes {
+		if typeName == string(atomic) {
+			return true
+		}
+	}
+	return false
+}
+
+// splitTopLevel splits s at every separator outside brackets, parentheses and quotes
+func splitTopLevel(s string, separator rune) []string {
+	var parts []string
+	depth, start := 0, 0
+	var quote rune
+	escaped := false
+	for i, c := range s {
+		switch {
+		case escaped:
+			escaped = false
+		case quote != 0:
+			if c == '\\' {
+				escaped = true
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '[' || c == '(' || c == '{' || c == '<':
+			depth++
+		case c == ']' || c == ')' || c == '}' || c == '>':
+			depth--
+		case c == separator && depth == 0:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	if strings.TrimSpace(s[start:]) != "" || len(parts) > 0 {
+		parts = append(parts, s[start:])
+	}
+	return parts
+}
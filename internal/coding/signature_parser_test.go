@@ -0,0 +1,127 @@
+package coding_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/coding"
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/model"
+)
+
+// printSignature prints a parsed function configuration compactly, e.g. "twoSum(nums Array < Integer >) Integer"
+func printSignature(config model.FunctionConfig) string {
+	var params []string
+	for _, param := range *config.Parameters {
+		printed := param.Name + " " + param.ParamType.ToPrint()
+		if param.JavaType != "" {
+			printed += " as " + param.JavaType
+		}
+		params = append(params, printed)
+	}
+	returnType := "void"
+	if config.ReturnType != nil {
+		returnType = config.ReturnType.ToPrint()
+	}
+	if config.ReturnJavaType != "" {
+		returnType += " as " + config.ReturnJavaType
+	}
+	if config.MutatedParameter != "" {
+		returnType += " mutating " + config.MutatedParameter
+	}
+	return config.Name + "(" + strings.Join(params, ", ") + ") " + returnType
+}
+
+func TestParseSignature(t *testing.T) {
+	cases := []struct {
+		name      string
+		signature string
+		source    coding.SignatureSource
+		printed   string
+		style     model.JavaTypeStyle
+	}{
+		{"python", "class Solution:\n    def twoSum(self, nums: List[int], target: int) -> List[int]:\n        pass",
+			coding.PythonSource, "twoSum(nums Array < Integer >, target Integer) Array < Integer >", ""},
+		{"python void", "def rotate(self, matrix: List[List[int]]) -> None:",
+			coding.PythonSource, "rotate(matrix Matrix < Integer >) void mutating matrix", ""},
+		{"python optional", "def invert_tree(self, root: Optional[TreeNode]) -> Optional[TreeNode]:",
+			coding.PythonSource, "invertTree(root TreeNode < Integer >) TreeNode < Integer >", ""},
+		{"python nested generics", "def group(self, words: List[List[List[str]]]) -> List[List[str]]:",
+			coding.PythonSource, "group(words Array < Matrix < String > >) Matrix < String >", ""},
+		{"java primitive arrays", "public int[] twoSum(int[] nums, int target) {",
+			coding.JavaSource, "twoSum(nums Array < Integer >, target Integer) Array < Integer >", model.JavaPrimitive},
+		{"java one line", "class Solution { public int[] twoSum(int[] nums, int target) { return null; } }",
+			coding.JavaSource, "twoSum(nums Array < Integer >, target Integer) Array < Integer >", model.JavaPrimitive},
+		{"java nested generics", "class Solution {\n    public List<List<Integer>> threeSum(List<Integer> nums) {\n    }\n}",
+			coding.JavaSource, "threeSum(nums Array < Integer >) Matrix < Integer >", ""},
+		{"java void", "public void moveZeroes(int[] nums)",
+			coding.JavaSource, "moveZeroes(nums Array < Integer >) void mutating nums", model.JavaPrimitive},
+		{"typescript", "function twoSum(nums: number[], target: number): number[] {",
+			coding.TypeScriptSource, "twoSum(nums Array < Integer >, target Integer) Array < Integer >", ""},
+		{"typescript nullable", "function invertTree(root: TreeNode | null): TreeNode | null {",
+			coding.TypeScriptSource, "invertTree(root TreeNode < Integer >) TreeNode < Integer >", ""},
+		{"typescript void", "function rotate(matrix: number[][]): void {",
+			coding.TypeScriptSource, "rotate(matrix Matrix < Integer >) void mutating matrix", ""},
+	}
+	for _, c := range cases {
+		parsed, err := coding.ParseSignature(c.signature)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", c.name, err)
+			continue
+		}
+		if parsed.Source != c.source {
+			t.Errorf("%s: expected a %s signature, got %s", c.name, c.source, parsed.Source)
+		}
+		if printed := printSignature(parsed.FunctionConfig); printed != c.printed {
+			t.Errorf("%s: expected %s, got %s", c.name, c.printed, printed)
+		}
+		if parsed.FunctionConfig.JavaTypeStyle != c.style {
+			t.Errorf("%s: expected the Java type style %q, got %q", c.name, c.style, parsed.FunctionConfig.JavaTypeStyle)
+		}
+	}
+}
+
+func TestParseSignatureRejectsUnknownTypes(t *testing.T) {
+	for _, signature := range []string{"def f(self, x: Dict[str, int]) -> int:", "public Map<String, Integer> count(int[] nums)", "no signature here"} {
+		if _, err := coding.ParseSignature(signature); err == nil {
+			t.Errorf("%q: expected an error", signature)
+		}
+	}
+}
+
+func TestParseExamples(t *testing.T) {
+	integer, text := model.AbstractType{Type: string(model.Integer)}, model.AbstractType{Type: string(model.String)}
+	parameters := []model.Parameter{
+		{Name: "words", ParamType: model.AbstractType{Type: string(model.Array), TypeChildren: &text}},
+		{Name: "maxWidth", ParamType: integer},
+	}
+	cases := []struct {
+		name     string
+		text     string
+		examples []model.InputOutput
+		warnings int
+	}{
+		{"named", `Input: words = ["a","b"], max_width = 3` + "\nOutput: 2",
+			[]model.InputOutput{{Parameters: []string{`["a", "b"]`, "3"}, ExpectedOutput: "2"}}, 0},
+		{"named out of order", "Input: maxWidth = 1, words = []\nOutput: 0",
+			[]model.InputOutput{{Parameters: []string{"[]", "1"}, ExpectedOutput: "0"}}, 0},
+		{"positional", `Input: ["x"], 4 Output: 1`,
+			[]model.InputOutput{{Parameters: []string{`["x"]`, "4"}, ExpectedOutput: "1"}}, 0},
+		{"commas in strings", "Input: words = [\"a, b\", \"None\"], maxWidth = 2\nOutput: \"True, False\"",
+			[]model.InputOutput{{Parameters: []string{`["a, b", "None"]`, "2"}, ExpectedOutput: `"True, False"`}}, 0},
+		{"python literals", "Input: words = [\"a\"], maxWidth = 1\nOutput: [True, None, False]",
+			[]model.InputOutput{{Parameters: []string{`["a"]`, "1"}, ExpectedOutput: "[true, null, false]"}}, 0},
+		{"skipped", "Example 1:\nInput: words = [\"a\"]\nOutput: 1\nExample 2:\nInput: words = [], maxWidth = 2\nOutput: 0",
+			[]model.InputOutput{{Parameters: []string{"[]", "2"}, ExpectedOutput: "0"}}, 1},
+		{"none", "Just a statement", []model.InputOutput{}, 1},
+	}
+	for _, c := range cases {
+		examples, warnings := coding.ParseExamples(c.text, parameters)
+		if !reflect.DeepEqual(examples, c.examples) {
+			t.Errorf("%s: expected %v, got %v", c.name, c.examples, examples)
+		}
+		if len(warnings) != c.warnings {
+			t.Errorf("%s: expected %d warnings, got %v", c.name, c.warnings, warnings)
+		}
+	}
+}
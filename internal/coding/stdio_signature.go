@@ -0,0 +1,28 @@
+package coding
+
+import (
+	"errors"
+
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/model"
+)
+
+// Stdio questions are solved by full programs, which start from reading the whole input
+var stdioStarters = map[model.PredefinedSupportedLanguage]string{
+	model.Python: `import sys
+
+data = sys.stdin.read()
+# Print the answer to standard output
+`,
+	model.JavaScript: `const data = require('fs').readFileSync(0, 'utf8');
+// Print the answer to standard output with console.log
+`,
+}
+
+// GenerateStdioStarter returns the starter program of a stdio question
+func GenerateStdioStarter(language model.PredefinedSupportedLanguage) (string, error) {
+	starter, exists := stdioStarters[language]
+	if !exists {
+		return "", errors.New("unsupported language for stdio questions")
+	}
+	return starter, nil
+}
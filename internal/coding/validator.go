@@ -16,6 +16,9 @@ func ValidateCharacters(question *model.Question) error {
 	if question == nil {
 		return errors.New("question cannot be nil")
 	}
+	if question.IsClassDesign() {
+		return validateClassCharacters(question.ClassConfig)
+	}
 
 	// Validate the function name
 	funcName := question.FunctionConfig.Name
@@ -42,3 +45,24 @@ func ValidateCharacters(question *model.Question) error {
 
 	return nil
 }
+
+// Regex for class names, used as is in every language
+var validClassNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// validateClassCharacters checks the validity of the class, method and parameter names
+func validateClassCharacters(classConfig *model.ClassConfig) error {
+	if classConfig == nil {
+		return nil
+	}
+	if !validClassNameRegex.MatchString(classConfig.Name) {
+		return fmt.Errorf("invalid class name: '%s'. Must start with a letter or underscore and contain only letters, digits, or underscores", classConfig.Name)
+	}
+	methods := []model.FunctionConfig{{Name: classConfig.Name, Parameters: classConfig.Constructor}}
+	for _, method := range append(methods, classConfig.Methods...) {
+		// A method is checked the way a function question is
+		if err := ValidateCharacters(&model.Question{FunctionConfig: method}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
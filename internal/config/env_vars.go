@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/TehilaTheStudent/SkillCode-backend/internal/model"
 )
@@ -36,6 +37,10 @@ type ConfigAPI struct {
 	ClusterConfigFile string
 	ClusterPort       string
 	KindServerUrl     string
+	SourceToken       string // Bearer token authors send to read the full question, the source endpoint is disabled without one
+
+	TrashRetention     time.Duration // How long deleted questions stay restorable before they are purged
+	TrashPurgeInterval time.Duration // How often the trash is purged
 }
 
 // NewLanguageConfig creates a new language-specific configuration for a given language.
@@ -82,6 +87,10 @@ func newConfigAPI() *ConfigAPI {
 		ClusterConfigFile: "kind-config.yaml",
 		ClusterPort:       getEnv("CLUSTER_PORT", "37000"),
 		KindServerUrl:     getEnv("KIND_SERVER_URL", "https://localhost"),
+		SourceToken:       getEnv("SOURCE_TOKEN", ""),
+
+		TrashRetention:     getDurationEnv("TRASH_RETENTION", 30*24*time.Hour),
+		TrashPurgeInterval: getDurationEnv("TRASH_PURGE_INTERVAL", time.Hour),
 	}
 }
 
@@ -95,6 +104,22 @@ func getEnv(key, defaultValue string) string {
 	return value
 }
 
+// getDurationEnv parses the environment variable named by the key as a duration such as "72h",
+// or returns the default value if the variable is not set or not a positive duration
+func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		log.Println("Using default value for", key)
+		return defaultValue
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		log.Printf("Invalid duration %q for %s, using default value %s", value, key, defaultValue)
+		return defaultValue
+	}
+	return duration
+}
+
 var (
 	GlobalConfigAPI       *ConfigAPI
 	GlobalLanguageConfigs map[model.PredefinedSupportedLanguage]*LanguageConfig
@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/model"
+	"github.com/gin-gonic/gin"
+)
+
+// ListCategories returns the category taxonomy as a tree, with the number of questions in each category
+func (h *QuestionHandler) ListCategories(c *gin.Context) {
+	categories, err := h.Service.ListCategories()
+	if err != nil {
+		LogAndRespondError(c, err, http.StatusInternalServerError)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"categories": categories})
+}
+
+// CreateCategory adds a category, or a topic of an existing category, to the taxonomy
+func (h *QuestionHandler) CreateCategory(c *gin.Context) {
+	var category model.Category
+	if err := c.ShouldBindJSON(&category); err != nil {
+		LogAndRespondError(c, err, http.StatusBadRequest)
+		return
+	}
+	created, err := h.Service.CreateCategory(category)
+	if err != nil {
+		LogAndRespondError(c, err, http.StatusInternalServerError)
+		return
+	}
+	c.JSON(http.StatusCreated, created)
+}
+
+// DeleteCategory removes an unused category from the taxonomy
+func (h *QuestionHandler) DeleteCategory(c *gin.Context) {
+	if err := h.Service.DeleteCategory(c.Param("name")); err != nil {
+		LogAndRespondError(c, err, http.StatusInternalServerError)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Category deleted successfully"})
+}
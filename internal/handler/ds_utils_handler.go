@@ -2,8 +2,10 @@ package handler
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"os"
+	"time"
 
 	"github.com/TehilaTheStudent/SkillCode-backend/internal/config"
 	"github.com/TehilaTheStudent/SkillCode-backend/internal/model"
@@ -12,6 +14,9 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// maxExamples caps the number of random examples per request
+const maxExamples = 100
+
 // ServeUtils serves the utilities file based on the language query parameter
 func ServeUtils(c *gin.Context) {
 	language, err := model.LowerToEnum(c.Query("language"))
@@ -30,21 +35,53 @@ func ServeUtils(c *gin.Context) {
 	c.Data(http.StatusOK, "text/plain", content)
 }
 
-// Handler to generate an example string for AbstractType
+// Handler to generate an example string for AbstractType.
+// With options, seed or count set, examples are random values that honor the options.
 func GenerateExampleHandler(c *gin.Context) {
-	var abstractType model.AbstractType
+	var request model.ExampleRequest
 
-	// Parse JSON input into AbstractType
-	if err := c.ShouldBindJSON(&abstractType); err != nil {
+	// Parse JSON input into AbstractType and generator settings
+	if err := c.ShouldBindJSON(&request); err != nil {
 		LogAndRespondError(c, errors.New("invalid input"+err.Error()), http.StatusBadRequest)
 		return
 	}
 
-	// Generate a valid string example
-	example := parser_validator.GenerateValidString(&abstractType)
+	if request.Options == nil && request.Seed == nil && request.Count == 0 {
+		// Generate a valid string example
+		example := parser_validator.GenerateValidString(&request.AbstractType)
+
+		// Return the generated string
+		c.JSON(http.StatusOK, gin.H{"example": example})
+		return
+	}
+
+	if request.Count < 0 || request.Count > maxExamples {
+		LogAndRespondError(c, fmt.Errorf("count must be between 1 and %d", maxExamples), http.StatusBadRequest)
+		return
+	}
+	if err := parser_validator.ValidateAbstractTypeDefinition(&request.AbstractType); err != nil {
+		LogAndRespondError(c, errors.New("invalid type: "+err.Error()), http.StatusBadRequest)
+		return
+	}
+	seed := time.Now().UnixNano()
+	if request.Seed != nil {
+		seed = *request.Seed
+	}
+	options := model.GeneratorOptions{}
+	if request.Options != nil {
+		options = *request.Options
+	}
 
-	// Return the generated string
-	c.JSON(http.StatusOK, gin.H{"example": example})
+	generator := parser_validator.NewRandomGenerator(seed)
+	examples := make([]string, max(request.Count, 1))
+	for i := range examples {
+		examples[i] = generator.GenerateRandomString(&request.AbstractType, options)
+	}
+	if err := generator.Err(); err != nil {
+		LogAndRespondError(c, model.NewValidationError([]model.ValidationIssue{{Location: "options", Message: err.Error()}}), http.StatusUnprocessableEntity)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"example": examples[0], "examples": examples, "seed": seed})
 }
 
 // RegisterQuestionRoutes sets up the routes for question-related endpoints
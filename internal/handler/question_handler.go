@@ -3,11 +3,15 @@ package handler
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/TehilaTheStudent/SkillCode-backend/internal/coding"
 	"github.com/TehilaTheStudent/SkillCode-backend/internal/config"
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/middleware"
 	"github.com/TehilaTheStudent/SkillCode-backend/internal/model"
 	"github.com/TehilaTheStudent/SkillCode-backend/internal/service"
 	"github.com/gin-gonic/gin"
@@ -29,11 +33,26 @@ func RegisterQuestionRoutes(r *gin.Engine, handler *QuestionHandler) {
 	appGroup := r.Group(config.GlobalConfigAPI.Base)
 	appGroup.POST("/questions", handler.CreateQuestion)
 	appGroup.GET("/questions/:id", handler.GetQuestionByID)
+	appGroup.GET("/questions/:id/source", middleware.RequireBearerToken(config.GlobalConfigAPI.SourceToken), handler.GetQuestionSource)
 	appGroup.GET("/questions", handler.GetAllQuestions)
+	appGroup.GET("/questions/trash", handler.ListTrash)
+	appGroup.POST("/questions/import", handler.ImportQuestions)
+	appGroup.GET("/questions/export", middleware.RequireBearerToken(config.GlobalConfigAPI.SourceToken), handler.ExportQuestions)
+	appGroup.POST("/questions/draft", handler.DraftQuestion)
+	appGroup.POST("/questions/:id/restore", handler.RestoreQuestion)
 	appGroup.PUT("/questions/:id", handler.UpdateQuestion)
+	appGroup.PATCH("/questions/:id", handler.PatchQuestion)
 	appGroup.DELETE("/questions/:id", handler.DeleteQuestion)
 	appGroup.POST("/questions/:id/test", handler.TestQuestion)
 	appGroup.GET("/questions/:id/signature", handler.GetFunctionSignature)
+	appGroup.POST("/questions/:id/test_cases/generate", handler.GenerateTestCases)
+	appGroup.GET("/questions/:id/revisions", handler.ListRevisions)
+	appGroup.GET("/questions/:id/revisions/diff", handler.DiffRevisions)
+	appGroup.GET("/questions/:id/revisions/:revision", handler.GetRevision)
+	appGroup.POST("/questions/:id/revisions/:revision/rollback", handler.RollbackQuestion)
+	appGroup.GET("/categories", handler.ListCategories)
+	appGroup.POST("/categories", handler.CreateCategory)
+	appGroup.DELETE("/categories/:name", handler.DeleteCategory)
 }
 
 // CreateQuestion creates a new question
@@ -54,7 +73,7 @@ func (h *QuestionHandler) CreateQuestion(c *gin.Context) {
 		LogAndRespondError(c, err, http.StatusInternalServerError)
 		return
 	}
-	c.JSON(http.StatusCreated, createdQuestion)
+	c.JSON(http.StatusCreated, createdQuestion.Public())
 }
 
 // GetQuestionByID retrieves a question by its ID
@@ -65,6 +84,18 @@ func (h *QuestionHandler) GetQuestionByID(c *gin.Context) {
 		LogAndRespondError(c, err, http.StatusInternalServerError)
 		return
 	}
+	c.Header("ETag", question.ETag())
+	c.JSON(http.StatusOK, question.Public())
+}
+
+// GetQuestionSource returns the full question, with its reference solution, to the authors holding the source token
+func (h *QuestionHandler) GetQuestionSource(c *gin.Context) {
+	question, err := h.Service.GetQuestionByID(c.Param("id"))
+	if err != nil {
+		LogAndRespondError(c, err, http.StatusInternalServerError)
+		return
+	}
+	c.Header("ETag", question.ETag())
 	c.JSON(http.StatusOK, question)
 }
 func splitOrEmpty(value string) []string {
@@ -74,43 +105,112 @@ func splitOrEmpty(value string) []string {
 	return strings.Split(value, ",")
 }
 
-func (h *QuestionHandler) GetAllQuestions(c *gin.Context) {
+// queryParams reads the filters, sort, page and fields of a question listing from the query string
+func queryParams(c *gin.Context) (model.QuestionQueryParams, error) {
 	categories := c.Query("categories")
 	difficulties := c.Query("difficulties")
 
-	query := model.QuestionQueryParams{
+	limit := 0
+	if value := c.Query("limit"); value != "" {
+		var err error
+		if limit, err = strconv.Atoi(value); err != nil {
+			return model.QuestionQueryParams{}, fmt.Errorf("limit must be a number")
+		}
+	}
+
+	return model.QuestionQueryParams{
 		Search:       c.Query("search"),
 		Categories:   splitOrEmpty(categories),
 		Difficulties: splitOrEmpty(difficulties),
+		Tags:         splitOrEmpty(c.Query("tags")),
+		TagMatch:     c.Query("tag_match"),
+		Companies:    splitOrEmpty(c.Query("companies")),
 		SortBy:       c.Query("sort_by"),
 		SortOrder:    c.Query("order"),
+		Limit:        limit,
+		PageToken:    c.Query("page_token"),
+		Fields:       splitOrEmpty(c.Query("fields")),
+	}, nil
+}
+
+func (h *QuestionHandler) GetAllQuestions(c *gin.Context) {
+	query, err := queryParams(c)
+	if err != nil {
+		LogAndRespondError(c, err, http.StatusBadRequest)
+		return
 	}
 
 	// Call the service layer
-	questions, err := h.Service.GetAllQuestions(query)
+	page, err := h.Service.GetAllQuestions(query)
 	if err != nil {
 		LogAndRespondError(c, err, http.StatusInternalServerError)
 		return
 	}
 
-	// Respond with the filtered, sorted questions
-	c.JSON(http.StatusOK, questions)
+	// Respond with the page of filtered, sorted question summaries, the full questions come from GET /questions/:id
+	c.JSON(http.StatusOK, page)
 }
 
-// UpdateQuestion updates an existing question
+// UpdateQuestion updates an existing question, the If-Match header must carry the ETag it was read with
 func (h *QuestionHandler) UpdateQuestion(c *gin.Context) {
 	id := c.Param("id")
+	expectedRevision, err := ifMatchRevision(c, true)
+	if err != nil {
+		LogAndRespondError(c, err, http.StatusBadRequest)
+		return
+	}
 	var question model.Question
 	if err := c.ShouldBindJSON(&question); err != nil {
 		LogAndRespondError(c, err, http.StatusBadRequest)
 		return
 	}
-	updatedQuestion, err := h.Service.UpdateQuestion(id, question)
+	updatedQuestion, err := h.Service.UpdateQuestion(id, question, expectedRevision, c.GetHeader(AuthorHeader))
+	if err != nil {
+		LogAndRespondError(c, err, http.StatusInternalServerError)
+		return
+	}
+	c.Header("ETag", updatedQuestion.ETag())
+	c.JSON(http.StatusOK, updatedQuestion.Public())
+}
+
+// PatchQuestion edits part of an existing question with a JSON Merge Patch (RFC 7396),
+// the If-Match header must carry the ETag it was read with
+func (h *QuestionHandler) PatchQuestion(c *gin.Context) {
+	id := c.Param("id")
+	expectedRevision, err := ifMatchRevision(c, true)
+	if err != nil {
+		LogAndRespondError(c, err, http.StatusBadRequest)
+		return
+	}
+	if contentType := c.ContentType(); contentType != model.MergePatchContentType && contentType != gin.MIMEJSON {
+		LogAndRespondError(c, fmt.Errorf("Content-Type must be %s", model.MergePatchContentType), http.StatusUnsupportedMediaType)
+		return
+	}
+	patch, err := c.GetRawData()
+	if err != nil {
+		LogAndRespondError(c, err, http.StatusBadRequest)
+		return
+	}
+	updatedQuestion, err := h.Service.PatchQuestion(id, patch, expectedRevision, c.GetHeader(AuthorHeader))
 	if err != nil {
 		LogAndRespondError(c, err, http.StatusInternalServerError)
 		return
 	}
-	c.JSON(http.StatusOK, updatedQuestion)
+	c.Header("ETag", updatedQuestion.ETag())
+	c.JSON(http.StatusOK, updatedQuestion.Public())
+}
+
+// ifMatchRevision returns the revision the If-Match header names. Without the header it fails
+// with 428 when required, and otherwise matches any revision.
+func ifMatchRevision(c *gin.Context, required bool) (int, error) {
+	header := c.GetHeader("If-Match")
+	if header == "" {
+		if required {
+			return 0, model.NewCustomError(http.StatusPreconditionRequired, "If-Match header is required, with the ETag of the question being changed")
+		}
+		return model.AnyRevision, nil
+	}
+	return model.ParseIfMatch(header)
 }
 
 // DeleteQuestion deletes a question by its ID
@@ -121,7 +221,33 @@ func (h *QuestionHandler) DeleteQuestion(c *gin.Context) {
 		LogAndRespondError(c, err, http.StatusInternalServerError)
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"message": "Question deleted"})
+	c.JSON(http.StatusOK, gin.H{"message": "Question moved to the trash"})
+}
+
+// ListTrash lists the deleted questions that can still be restored, with the same query parameters as GetAllQuestions
+func (h *QuestionHandler) ListTrash(c *gin.Context) {
+	query, err := queryParams(c)
+	if err != nil {
+		LogAndRespondError(c, err, http.StatusBadRequest)
+		return
+	}
+	page, err := h.Service.ListTrash(query)
+	if err != nil {
+		LogAndRespondError(c, err, http.StatusInternalServerError)
+		return
+	}
+	c.JSON(http.StatusOK, page)
+}
+
+// RestoreQuestion moves a deleted question out of the trash
+func (h *QuestionHandler) RestoreQuestion(c *gin.Context) {
+	question, err := h.Service.RestoreQuestion(c.Param("id"))
+	if err != nil {
+		LogAndRespondError(c, err, http.StatusInternalServerError)
+		return
+	}
+	c.Header("ETag", question.ETag())
+	c.JSON(http.StatusOK, question.Public())
 }
 
 // TestQuestion simulates running a user-provided function against test cases for a question.
@@ -142,6 +268,10 @@ func (h *QuestionHandler) TestQuestion(c *gin.Context) {
 		LogAndRespondError(c, err, http.StatusInternalServerError)
 		return
 	}
+	// The feedback stands even when the stats could not be updated, the failure is only logged
+	if err := h.Service.RecordSubmission(id, submission, feedback, c.GetHeader(SolverHeader)); err != nil {
+		c.Error(err)
+	}
 
 	// Serialize the Feedback struct to JSON
 	response, err := json.Marshal(feedback)
@@ -154,21 +284,42 @@ func (h *QuestionHandler) TestQuestion(c *gin.Context) {
 	c.Data(http.StatusOK, "application/json", response)
 }
 
-func (h *QuestionHandler) GetFunctionSignature(c *gin.Context) {
-	// Extract question ID and language
+// GenerateTestCases generates random test cases for a question, with expected outputs from its reference solution
+func (h *QuestionHandler) GenerateTestCases(c *gin.Context) {
 	id := c.Param("id")
-	language := c.Query("language")
-	if language == "" {
-		LogAndRespondError(c, fmt.Errorf("Language parameter is required"), http.StatusBadRequest)
+	var request model.TestCaseGenerationRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		LogAndRespondError(c, err, http.StatusBadRequest)
 		return
 	}
 
-	// Map lowercase language to PredefinedSupportedLanguage
-	langEnum, err := model.LowerToEnum(language)
+	requestID := c.GetString("request_id")
+	result, err := h.Service.GenerateTestCases(id, request, requestID)
 	if err != nil {
-		LogAndRespondError(c, fmt.Errorf("Invalid language parameter"), http.StatusBadRequest)
+		LogAndRespondError(c, err, http.StatusInternalServerError)
 		return
 	}
+	c.JSON(http.StatusOK, result)
+}
+
+// GetFunctionSignature returns the starter code of a question with its names and types as written in the language.
+// Without a language, or with language=all, it returns every language the question can be solved in.
+func (h *QuestionHandler) GetFunctionSignature(c *gin.Context) {
+	// Extract question ID and language
+	id := c.Param("id")
+	language := c.Query("language")
+	allLanguages := language == "" || strings.EqualFold(language, "all")
+
+	var langEnum model.PredefinedSupportedLanguage
+	if !allLanguages {
+		// Map lowercase language to PredefinedSupportedLanguage
+		var err error
+		langEnum, err = model.LowerToEnum(language)
+		if err != nil {
+			LogAndRespondError(c, fmt.Errorf("Invalid language parameter"), http.StatusBadRequest)
+			return
+		}
+	}
 	// Fetch the question details
 	question, err := h.Service.GetQuestionByID(id)
 	if err != nil {
@@ -176,15 +327,185 @@ func (h *QuestionHandler) GetFunctionSignature(c *gin.Context) {
 		return
 	}
 
-	// Generate the function signature
-	signature, err := coding.GenerateByQuestionAndLanguage(*question, langEnum)
-	if err != nil {
-		LogAndRespondError(c, fmt.Errorf("Failed to generate function signature for language %s: %s", language, err.Error()), http.StatusInternalServerError)
+	if !allLanguages {
+		signature, err := coding.DescribeSignature(*question, langEnum)
+		if err != nil {
+			LogAndRespondError(c, fmt.Errorf("Failed to generate function signature for language %s: %s", language, err.Error()), http.StatusInternalServerError)
+			return
+		}
+		c.JSON(http.StatusOK, signature)
 		return
 	}
 
-	// Return the function signature
+	signatures := []model.LanguageSignature{}
+	for _, lang := range coding.SignatureLanguages(*question) {
+		signature, err := coding.DescribeSignature(*question, lang)
+		if err != nil {
+			LogAndRespondError(c, fmt.Errorf("Failed to generate function signature for language %s: %s", lang, err.Error()), http.StatusInternalServerError)
+			return
+		}
+		signatures = append(signatures, *signature)
+	}
 	c.JSON(http.StatusOK, gin.H{
-		"function_signature": signature,
+		"signatures": signatures,
 	})
 }
+
+// AuthorHeader names the author of a change to a question, recorded in its revision
+const AuthorHeader = "X-Author"
+
+// SolverHeader names the user who submitted a solution, for the unique solvers of the question stats
+const SolverHeader = "X-User-ID"
+
+// parseRevision reads a revision number from the path or query
+func parseRevision(name, value string) (int, error) {
+	revision, err := strconv.Atoi(value)
+	if err != nil || revision < 1 {
+		return 0, model.NewCustomError(http.StatusBadRequest, fmt.Sprintf("%s must be a revision number, got %q", name, value))
+	}
+	return revision, nil
+}
+
+// ListRevisions lists the revisions of a question, newest first
+func (h *QuestionHandler) ListRevisions(c *gin.Context) {
+	revisions, err := h.Service.ListRevisions(c.Param("id"))
+	if err != nil {
+		LogAndRespondError(c, err, http.StatusInternalServerError)
+		return
+	}
+	for i := range revisions {
+		revisions[i] = revisions[i].Public()
+	}
+	c.JSON(http.StatusOK, gin.H{"revisions": revisions})
+}
+
+// GetRevision returns one revision of a question, with the question as it was then
+func (h *QuestionHandler) GetRevision(c *gin.Context) {
+	revision, err := parseRevision("revision", c.Param("revision"))
+	if err != nil {
+		LogAndRespondError(c, err, http.StatusBadRequest)
+		return
+	}
+	result, err := h.Service.GetRevision(c.Param("id"), revision)
+	if err != nil {
+		LogAndRespondError(c, err, http.StatusInternalServerError)
+		return
+	}
+	c.JSON(http.StatusOK, result.Public())
+}
+
+// DiffRevisions lists the changes of a question between the revisions ?from= and ?to=
+func (h *QuestionHandler) DiffRevisions(c *gin.Context) {
+	from, err := parseRevision("from", c.Query("from"))
+	if err != nil {
+		LogAndRespondError(c, err, http.StatusBadRequest)
+		return
+	}
+	to, err := parseRevision("to", c.Query("to"))
+	if err != nil {
+		LogAndRespondError(c, err, http.StatusBadRequest)
+		return
+	}
+	diff, err := h.Service.DiffRevisions(c.Param("id"), from, to)
+	if err != nil {
+		LogAndRespondError(c, err, http.StatusInternalServerError)
+		return
+	}
+	c.JSON(http.StatusOK, diff.Public())
+}
+
+// RollbackQuestion restores a question to one of its revisions, as a new revision. An If-Match header is optional.
+func (h *QuestionHandler) RollbackQuestion(c *gin.Context) {
+	revision, err := parseRevision("revision", c.Param("revision"))
+	if err != nil {
+		LogAndRespondError(c, err, http.StatusBadRequest)
+		return
+	}
+	expectedRevision, err := ifMatchRevision(c, false)
+	if err != nil {
+		LogAndRespondError(c, err, http.StatusBadRequest)
+		return
+	}
+	question, err := h.Service.RollbackQuestion(c.Param("id"), revision, expectedRevision, c.GetHeader(AuthorHeader))
+	if err != nil {
+		LogAndRespondError(c, err, http.StatusInternalServerError)
+		return
+	}
+	c.Header("ETag", question.ETag())
+	c.JSON(http.StatusOK, question.Public())
+}
+
+// Largest document POST /questions/import reads
+const maxImportBytes = 32 << 20
+
+// transferFormat returns the format named by ?format=, otherwise the one of contentType, otherwise JSON
+func transferFormat(c *gin.Context, contentType string) (model.TransferFormat, error) {
+	if format := strings.ToLower(c.Query("format")); format != "" {
+		if !slices.Contains(model.TransferFormats, model.TransferFormat(format)) {
+			return "", fmt.Errorf("invalid format: %s, must be one of %v", format, model.TransferFormats)
+		}
+		return model.TransferFormat(format), nil
+	}
+	if format, ok := model.FormatOfContentType(contentType); ok {
+		return format, nil
+	}
+	return model.JSONFormat, nil
+}
+
+// ImportQuestions creates or updates questions by title from a JSON array, NDJSON or YAML document.
+// With ?dry_run=true it only reports what it would do, with the validation issues of every question.
+func (h *QuestionHandler) ImportQuestions(c *gin.Context) {
+	format, err := transferFormat(c, c.GetHeader("Content-Type"))
+	if err != nil {
+		LogAndRespondError(c, err, http.StatusBadRequest)
+		return
+	}
+	dryRun, err := strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
+	if err != nil {
+		LogAndRespondError(c, fmt.Errorf("dry_run must be true or false"), http.StatusBadRequest)
+		return
+	}
+	data, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxImportBytes))
+	if err != nil {
+		LogAndRespondError(c, err, http.StatusRequestEntityTooLarge)
+		return
+	}
+	result, err := h.Service.ImportQuestions(data, format, dryRun, c.GetHeader(AuthorHeader))
+	if err != nil {
+		LogAndRespondError(c, err, http.StatusInternalServerError)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// ExportQuestions streams every question in the format of ?format= or the Accept header, JSON by default.
+// Exports carry the hidden fields so they import back whole, they need the token of GET /questions/:id/source.
+func (h *QuestionHandler) ExportQuestions(c *gin.Context) {
+	format, err := transferFormat(c, c.GetHeader("Accept"))
+	if err != nil {
+		LogAndRespondError(c, err, http.StatusBadRequest)
+		return
+	}
+	c.Header("Content-Type", format.ContentType())
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=questions.%s", format))
+	c.Status(http.StatusOK)
+	if err := h.Service.ExportQuestions(format, c.Writer); err != nil {
+		// The status is already sent, the truncated document tells the client the export failed
+		c.Error(err)
+	}
+}
+
+// DraftQuestion reads a problem pasted from another site into a question for review, without storing it
+func (h *QuestionHandler) DraftQuestion(c *gin.Context) {
+	var request model.DraftRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		LogAndRespondError(c, err, http.StatusBadRequest)
+		return
+	}
+	draft, err := h.Service.DraftQuestion(request)
+	if err != nil {
+		LogAndRespondError(c, err, http.StatusInternalServerError)
+		return
+	}
+	c.JSON(http.StatusOK, draft)
+}
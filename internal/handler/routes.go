@@ -1,6 +1,9 @@
 package handler
 
 import (
+	"errors"
+	"net/http"
+
 	"github.com/TehilaTheStudent/SkillCode-backend/internal/model"
 	"github.com/gin-gonic/gin"
 )
@@ -17,7 +20,10 @@ func LogAndRespondError(c *gin.Context, err error, statusCode int) {
 	c.Error(err) // Will be picked up by the middleware
 
 	// Respond with appropriate error message
-	if customErr, ok := err.(*model.CustomError); ok {
+	var validationErr *model.ValidationError
+	if errors.As(err, &validationErr) {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "validation failed", "issues": validationErr.Issues})
+	} else if customErr, ok := err.(*model.CustomError); ok {
 		c.JSON(customErr.Code, gin.H{"error": customErr.Message})
 	} else {
 		c.JSON(statusCode, gin.H{"error": err.Error()})
@@ -1,7 +1,9 @@
 package middleware
 
 import (
+	"crypto/subtle"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -31,9 +33,10 @@ func SetupMiddlewares(r *gin.Engine, logger *zap.Logger, frontendURLS []string)
 				}
 				return false
 			},
-			AllowedMethods:   []string{http.MethodGet, http.MethodPost, http.MethodDelete, http.MethodPut, http.MethodOptions},
+			AllowedMethods:   []string{http.MethodGet, http.MethodPost, http.MethodDelete, http.MethodPut, http.MethodPatch, http.MethodOptions},
 			AllowCredentials: true,
-			AllowedHeaders:   []string{"Origin", "Content-Type", "Authorization"},
+			AllowedHeaders:   []string{"Origin", "Content-Type", "Authorization", "X-Author", "X-User-ID", "If-Match"},
+			ExposedHeaders:   []string{"ETag"},
 			MaxAge:           int(12 * time.Hour / time.Second),
 		})
 		corsMiddleware.HandlerFunc(c.Writer, c.Request)
@@ -67,6 +70,23 @@ func RequestLogger(logger *zap.Logger) gin.HandlerFunc {
 	}
 }
 
+// RequireBearerToken lets through the requests whose Authorization header carries token.
+// With an empty token every request is refused, so endpoints are closed until one is configured.
+func RequireBearerToken(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if token == "" {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "this endpoint is disabled, no token is configured"})
+			return
+		}
+		provided, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "a valid bearer token is required"})
+			return
+		}
+		c.Next()
+	}
+}
+
 func RequestIDMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		requestID := c.Request.Header.Get("X-Request-ID")
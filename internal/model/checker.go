@@ -0,0 +1,31 @@
+package model
+
+// Checker grades outputs of problems with several valid answers (e.g. any topological order).
+// Code defines check(inputs, expected, actual) returning whether actual is accepted and a message;
+// the values are converted to the parameter and return types, as for the user's function.
+type Checker struct {
+	Language PredefinedSupportedLanguage `json:"language" bson:"language"`
+	Code     string                      `json:"code,omitempty" bson:"code"` // Left out of the public question
+}
+
+// CheckerLanguages lists the languages a Checker can be written in
+var CheckerLanguages = []PredefinedSupportedLanguage{Python, JavaScript}
+
+// CheckerCase is one test case as given to the checker, after the user's code ran
+type CheckerCase struct {
+	Parameters     []string `json:"parameters"`
+	ExpectedOutput string   `json:"expected_output"`
+	ActualOutput   string   `json:"actual_output"`
+}
+
+// CheckerVerdict is the checker's decision on one CheckerCase
+type CheckerVerdict struct {
+	Status  string `json:"status"` // pass or fail
+	Message string `json:"message"`
+}
+
+// CheckerFeedback is the output of a checker run
+type CheckerFeedback struct {
+	Verdicts []CheckerVerdict `json:"verdicts"`
+	Error    *string          `json:"error,omitempty"` // The checker itself failed, e.g. it does not compile
+}
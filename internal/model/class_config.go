@@ -0,0 +1,51 @@
+package model
+
+// QuestionKind tells what the user implements for a question
+type QuestionKind string
+
+const (
+	FunctionQuestion    QuestionKind = "function"    // A single function, described by FunctionConfig
+	ClassQuestion       QuestionKind = "class"       // A class with a constructor and methods, described by ClassConfig
+	InteractiveQuestion QuestionKind = "interactive" // A program talking with a judge, described by InteractiveConfig
+	StdioQuestion       QuestionKind = "stdio"       // A program reading stdin and writing stdout, described by StdioConfig
+)
+
+var QuestionKinds = []QuestionKind{FunctionQuestion, ClassQuestion, InteractiveQuestion, StdioQuestion}
+
+// ClassConfig describes the class of a class-design question, e.g. LRUCache or MinStack.
+// Its test cases are operation sequences in LeetCode form, stored in InputOutput:
+//
+//	Parameters:     ["[\"LRUCache\",\"put\",\"get\"]", "[[2],[1,1],[1]]"] // operations, then the arguments of each call
+//	ExpectedOutput: "[null,null,1]"                                       // the return value of each call
+//
+// The first operation is the constructor. It and the void methods return null.
+type ClassConfig struct {
+	Name        string           `json:"name" bson:"name"`                                   // Class name, the same in every language
+	Constructor *[]Parameter     `json:"constructor,omitempty" bson:"constructor,omitempty"` // Constructor parameters
+	Methods     []FunctionConfig `json:"methods" bson:"methods"`                             // Nil ReturnType means void
+}
+
+// GetMethod returns the method called name, or nil.
+func (c *ClassConfig) GetMethod(name string) *FunctionConfig {
+	for i := range c.Methods {
+		if c.Methods[i].Name == name {
+			return &c.Methods[i]
+		}
+	}
+	return nil
+}
+
+// ConstructorParameters returns the constructor parameters, empty when there are none.
+func (c *ClassConfig) ConstructorParameters() []Parameter {
+	if c.Constructor == nil {
+		return []Parameter{}
+	}
+	return *c.Constructor
+}
+
+// OperationSequence is a class-design test case, parsed from its InputOutput
+type OperationSequence struct {
+	Operations []string   // Operations[0] is the class name, the rest method names
+	Arguments  [][]string // The JSON encoded arguments of each operation
+	Outputs    []string   // The JSON encoded return value of each operation, null when void
+}
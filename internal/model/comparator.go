@@ -0,0 +1,42 @@
+package model
+
+// ComparatorType represents how a function's output is matched against the expected output
+type ComparatorType string
+
+const (
+	ExactComparator           ComparatorType = "exact"            // Same value, lists in the same order
+	UnorderedComparator       ComparatorType = "unordered"        // Outer list in any order, e.g. Two Sum indexes
+	UnorderedNestedComparator ComparatorType = "unordered_nested" // Every list at every depth in any order
+	FloatToleranceComparator  ComparatorType = "float_tolerance"  // Doubles equal within Tolerance
+	SetOfSetsComparator       ComparatorType = "set_of_sets"      // Outer and inner lists as sets, duplicates ignored
+	CustomComparator          ComparatorType = "custom"           // A compare(expected, actual) function per language
+)
+
+var ComparatorTypes = []ComparatorType{ExactComparator, UnorderedComparator, UnorderedNestedComparator, FloatToleranceComparator, SetOfSetsComparator, CustomComparator}
+
+// DefaultTolerance is used by FloatToleranceComparator when Tolerance is not set
+const DefaultTolerance = 1e-6
+
+// Comparator configures output matching for a question. A nil Comparator means ExactComparator.
+// Evaluators compare the JSON representations of the outputs, so custom code receives plain lists, numbers and strings.
+type Comparator struct {
+	Type      ComparatorType                         `json:"type" bson:"type"`
+	Tolerance *float64                               `json:"tolerance,omitempty" bson:"tolerance,omitempty"` // FloatToleranceComparator only, absolute or relative
+	Code      map[PredefinedSupportedLanguage]string `json:"code,omitempty" bson:"code,omitempty"`           // CustomComparator only, defines compare(expected, actual) returning a boolean
+}
+
+// GetType returns the comparator type, defaulting to ExactComparator.
+func (c *Comparator) GetType() ComparatorType {
+	if c == nil || c.Type == "" {
+		return ExactComparator
+	}
+	return c.Type
+}
+
+// GetTolerance returns the float tolerance, defaulting to DefaultTolerance.
+func (c *Comparator) GetTolerance() float64 {
+	if c == nil || c.Tolerance == nil {
+		return DefaultTolerance
+	}
+	return *c.Tolerance
+}
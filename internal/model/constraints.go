@@ -0,0 +1,127 @@
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Constraints restricts the values a parameter may take, e.g. "1 <= nums.length <= 10^5".
+// Every field is optional, a nil bound is not checked.
+type Constraints struct {
+	MinValue  *float64 `json:"min_value,omitempty" bson:"min_value,omitempty"`   // Every Integer and Double value, for Graphs the edge weights
+	MaxValue  *float64 `json:"max_value,omitempty" bson:"max_value,omitempty"`   // Every Integer and Double value, for Graphs the edge weights
+	MinLength *int     `json:"min_length,omitempty" bson:"min_length,omitempty"` // String characters, Array/ListNode elements, TreeNode nodes, Matrix rows and columns, Graph edges
+	MaxLength *int     `json:"max_length,omitempty" bson:"max_length,omitempty"` // String characters, Array/ListNode elements, TreeNode nodes, Matrix rows and columns, Graph edges
+	Charset   string   `json:"charset,omitempty" bson:"charset,omitempty"`       // Allowed characters of every String, any when empty
+	Unique    bool     `json:"unique,omitempty" bson:"unique,omitempty"`         // No repeated elements, characters, tree values or edges
+	MinHeight *int     `json:"min_height,omitempty" bson:"min_height,omitempty"` // TreeNode levels, a single node has height 1
+	MaxHeight *int     `json:"max_height,omitempty" bson:"max_height,omitempty"` // TreeNode levels, a single node has height 1
+	MinNodes  *int     `json:"min_nodes,omitempty" bson:"min_nodes,omitempty"`   // Graph nodes
+	MaxNodes  *int     `json:"max_nodes,omitempty" bson:"max_nodes,omitempty"`   // Graph nodes
+}
+
+// Describe lists the constraints in problem statement style, e.g. "1 <= length <= 100".
+func (c *Constraints) Describe() []string {
+	if c == nil {
+		return nil
+	}
+	var lines []string
+	if line := describeRange("value", c.MinValue, c.MaxValue); line != "" {
+		lines = append(lines, line)
+	}
+	if line := describeRange("length", intToFloat(c.MinLength), intToFloat(c.MaxLength)); line != "" {
+		lines = append(lines, line)
+	}
+	if c.Charset != "" {
+		lines = append(lines, fmt.Sprintf("characters in %q", c.Charset))
+	}
+	if c.Unique {
+		lines = append(lines, "unique elements")
+	}
+	if line := describeRange("height", intToFloat(c.MinHeight), intToFloat(c.MaxHeight)); line != "" {
+		lines = append(lines, line)
+	}
+	if line := describeRange("nodes", intToFloat(c.MinNodes), intToFloat(c.MaxNodes)); line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// String joins Describe with "; ", empty when there are no constraints.
+func (c *Constraints) String() string {
+	return strings.Join(c.Describe(), "; ")
+}
+
+// Validate checks that every bound pair is ordered and no count is negative.
+func (c *Constraints) Validate() error {
+	if c == nil {
+		return nil
+	}
+	if c.MinValue != nil && c.MaxValue != nil && *c.MinValue > *c.MaxValue {
+		return fmt.Errorf("min_value %s is greater than max_value %s", formatBound(*c.MinValue), formatBound(*c.MaxValue))
+	}
+	counts := []struct {
+		name     string
+		min, max *int
+	}{
+		{"length", c.MinLength, c.MaxLength},
+		{"height", c.MinHeight, c.MaxHeight},
+		{"nodes", c.MinNodes, c.MaxNodes},
+	}
+	for _, count := range counts {
+		if (count.min != nil && *count.min < 0) || (count.max != nil && *count.max < 0) {
+			return fmt.Errorf("%s bounds cannot be negative", count.name)
+		}
+		if count.min != nil && count.max != nil && *count.min > *count.max {
+			return fmt.Errorf("min_%s %d is greater than max_%s %d", count.name, *count.min, count.name, *count.max)
+		}
+	}
+	return nil
+}
+
+// GeneratorOptions converts the constraints into options that generate values satisfying them.
+func (c *Constraints) GeneratorOptions() GeneratorOptions {
+	if c == nil {
+		return GeneratorOptions{}
+	}
+	options := GeneratorOptions{
+		MinValue:  c.MinValue,
+		MaxValue:  c.MaxValue,
+		MinLength: c.MinLength,
+		MaxLength: c.MaxLength,
+		MinWeight: c.MinValue,
+		MaxWeight: c.MaxValue,
+		Charset:   c.Charset,
+		Distinct:  c.Unique,
+	}
+	// Node count bounds the generated graph size, which the generator derives from the length
+	if c.MinNodes != nil || c.MaxNodes != nil {
+		options.MinLength, options.MaxLength = c.MinNodes, c.MaxNodes
+	}
+	return options
+}
+
+func describeRange(name string, minValue, maxValue *float64) string {
+	switch {
+	case minValue != nil && maxValue != nil:
+		return fmt.Sprintf("%s <= %s <= %s", formatBound(*minValue), name, formatBound(*maxValue))
+	case minValue != nil:
+		return fmt.Sprintf("%s >= %s", name, formatBound(*minValue))
+	case maxValue != nil:
+		return fmt.Sprintf("%s <= %s", name, formatBound(*maxValue))
+	}
+	return ""
+}
+
+func formatBound(bound float64) string {
+	return strconv.FormatFloat(bound, 'f', -1, 64)
+}
+
+func intToFloat(value *int) *float64 {
+	if value == nil {
+		return nil
+	}
+	converted := float64(*value)
+	return &converted
+}
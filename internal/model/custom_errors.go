@@ -1,6 +1,9 @@
 package model
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // CustomError defines an error with a status code and message
 type CustomError struct {
@@ -24,3 +27,33 @@ func NewCustomError(code int, message string) *CustomError {
 var (
 	ErrInternal = NewCustomError(500, "internal server error")
 )
+
+// ValidationIssue describes one invalid value and where it was found
+type ValidationIssue struct {
+	Location string `json:"location"`           // JSON path, e.g. test_cases[3].parameters[1][2][0]
+	Expected string `json:"expected,omitempty"` // Expected type, as AbstractType.ToPrint
+	Value    string `json:"value,omitempty"`    // The offending value
+	Message  string `json:"message"`            // What is wrong with it
+}
+
+// ValidationError lists every problem found while validating, responded with 422
+type ValidationError struct {
+	Issues []ValidationIssue `json:"issues"`
+}
+
+// Error implements the error interface
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Issues))
+	for i, issue := range e.Issues {
+		messages[i] = fmt.Sprintf("%s: %s", issue.Location, issue.Message)
+	}
+	return fmt.Sprintf("%d validation issue(s): %s", len(e.Issues), strings.Join(messages, "; "))
+}
+
+// NewValidationError returns nil when there are no issues, so callers can return it as an error directly
+func NewValidationError(issues []ValidationIssue) error {
+	if len(issues) == 0 {
+		return nil
+	}
+	return &ValidationError{Issues: issues}
+}
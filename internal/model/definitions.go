@@ -25,6 +25,16 @@ const (
 
 var CompositeTypes = []CompositeType{Array, ListNode, TreeNode, Matrix, Graph}
 
+// GraphFormat represents how a Graph value is written in test cases.
+type GraphFormat string
+
+const (
+	EdgeList      GraphFormat = "edge_list"      // [[u, v], ...] or [[u, v, w], ...]
+	AdjacencyList GraphFormat = "adjacency_list" // [[v, ...], ...] or [[[v, w], ...], ...], indexed by node
+)
+
+var GraphFormats = []GraphFormat{EdgeList, AdjacencyList}
+
 // AtomicType represents basic types like Integer, String, etc.
 type Difficulty string
 
@@ -54,3 +64,6 @@ const (
 	LinkedListCategory         PredefinedCategory = "LinkedList"
 	MatrixCategory             PredefinedCategory = "Matrix"
 )
+
+// PredefinedCategories seed the category taxonomy of a new database
+var PredefinedCategories = []PredefinedCategory{ArrayCategory, GraphCategory, StringCategory, TreeCategory, DynamicProgrammingCategory, LinkedListCategory, MatrixCategory}
@@ -0,0 +1,23 @@
+package model
+
+// DraftRequest is a problem copied from another site, to be read into a question. Only Signature is required.
+type DraftRequest struct {
+	Title       string   `json:"title"`
+	Description string   `json:"description"`        // The problem statement, its "Input: ... Output: ..." examples are read too
+	Signature   string   `json:"signature"`          // A Python, Java or TypeScript function, e.g. "def twoSum(self, nums: List[int], target: int) -> List[int]:"
+	Examples    string   `json:"examples,omitempty"` // Example blocks pasted apart from the description
+	Difficulty  string   `json:"difficulty,omitempty"`
+	Category    string   `json:"category,omitempty"`
+	Categories  []string `json:"categories,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	Companies   []string `json:"companies,omitempty"`
+}
+
+// QuestionDraft is a question read from a DraftRequest, for an author to review before creating it.
+// It is not stored.
+type QuestionDraft struct {
+	Question        Question          `json:"question"`
+	SignatureSource string            `json:"signature_source"` // python, java or typescript
+	Warnings        []string          `json:"warnings"`         // What was guessed or could not be read
+	Issues          []ValidationIssue `json:"issues"`           // What must be fixed before the question can be created
+}
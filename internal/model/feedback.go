@@ -15,6 +15,8 @@ type Feedback struct {
     Results []Result  `json:"results"`           // Array of individual test case results
     Error   *ErrorType `json:"error,omitempty"`   // Error type: compilation, fail tests, internal server error, or null
     Details *string   `json:"details,omitempty"` // Detailed error description, or null if not applicable
+    QuestionRevision int `json:"question_revision,omitempty"` // Revision of the question the submission was graded against
+    RuntimeMs *int64 `json:"runtime_ms,omitempty"` // Time spent in the user's code, as measured by the evaluator, nil when it does not measure it
 }
 
 type Result struct {
@@ -22,5 +24,7 @@ type Result struct {
     Parameters    []string        `json:"parameters"`     // Array of strings representing input parameters
     ExpectedOutput json.RawMessage `json:"expected_output"` // Expected output (can be a string or number)
     ActualOutput   json.RawMessage `json:"actual_output"`   // Actual output (can be a string or number)
+    Message        *string         `json:"message,omitempty"` // Checker or judge message, when the question has one
+    Queries        *int            `json:"queries,omitempty"` // Queries sent to the judge, for interactive questions
 }
 
@@ -0,0 +1,56 @@
+package model
+
+// SortOrder represents the order of generated Array and ListNode elements
+type SortOrder string
+
+const (
+	Ascending  SortOrder = "asc"
+	Descending SortOrder = "desc"
+)
+
+// TreeShape represents the shape of a generated TreeNode
+type TreeShape string
+
+const (
+	RandomTree   TreeShape = "random"
+	BalancedTree TreeShape = "balanced" // Complete binary tree
+	SkewedTree   TreeShape = "skewed"   // Every node has only a right child
+)
+
+// GeneratorOptions constrains randomly generated values. Every field is optional.
+type GeneratorOptions struct {
+	MinValue  *float64  `json:"min_value,omitempty" bson:"min_value,omitempty"`   // Integer and Double values
+	MaxValue  *float64  `json:"max_value,omitempty" bson:"max_value,omitempty"`   // Integer and Double values
+	MinLength *int      `json:"min_length,omitempty" bson:"min_length,omitempty"` // Elements, tree and graph nodes, String characters
+	MaxLength *int      `json:"max_length,omitempty" bson:"max_length,omitempty"` // Elements, tree and graph nodes, String characters
+	MinWeight *float64  `json:"min_weight,omitempty" bson:"min_weight,omitempty"` // Graph edge weights
+	MaxWeight *float64  `json:"max_weight,omitempty" bson:"max_weight,omitempty"` // Graph edge weights
+	Charset   string    `json:"charset,omitempty" bson:"charset,omitempty"`       // String characters, a-z when empty
+	Distinct  bool      `json:"distinct,omitempty" bson:"distinct,omitempty"`     // No repeated Array or ListNode elements
+	Sorted    SortOrder `json:"sorted,omitempty" bson:"sorted,omitempty"`         // Array and ListNode element order
+	TreeShape TreeShape `json:"tree_shape,omitempty" bson:"tree_shape,omitempty"` // RandomTree when empty
+	Connected bool      `json:"connected,omitempty" bson:"connected,omitempty"`   // Graph has a path between every two nodes
+}
+
+// ExampleRequest is the body of POST /ds_utils/examples: an AbstractType plus optional generator settings
+type ExampleRequest struct {
+	AbstractType
+	Options *GeneratorOptions `json:"options,omitempty"`
+	Seed    *int64            `json:"seed,omitempty"`  // Same seed, same examples
+	Count   int               `json:"count,omitempty"` // Number of examples, 1 when 0
+}
+
+// TestCaseGenerationRequest is the body of POST /questions/:id/test_cases/generate
+type TestCaseGenerationRequest struct {
+	Count      int                         `json:"count" validate:"required"`
+	Seed       *int64                      `json:"seed,omitempty"`
+	Parameters map[string]GeneratorOptions `json:"parameters,omitempty"` // Options per parameter name
+	Append     bool                        `json:"append,omitempty"`     // Store the generated test cases on the question
+}
+
+// TestCaseGenerationResult lists the generated test cases and the seed that reproduces them
+type TestCaseGenerationResult struct {
+	Seed      int64         `json:"seed"`
+	TestCases []InputOutput `json:"test_cases"`
+	Appended  bool          `json:"appended"`
+}
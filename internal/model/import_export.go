@@ -0,0 +1,73 @@
+package model
+
+import "mime"
+
+// TransferFormat is the encoding of questions in bulk imports and exports
+type TransferFormat string
+
+const (
+	JSONFormat   TransferFormat = "json"   // A JSON array of questions, or a single question
+	NDJSONFormat TransferFormat = "ndjson" // One JSON question per line
+	YAMLFormat   TransferFormat = "yaml"   // A YAML sequence of questions, or a single question
+)
+
+var TransferFormats = []TransferFormat{JSONFormat, NDJSONFormat, YAMLFormat}
+
+// transferContentTypes are the media types of each format, the first one is sent with exports
+var transferContentTypes = map[TransferFormat][]string{
+	JSONFormat:   {"application/json"},
+	NDJSONFormat: {"application/x-ndjson", "application/ndjson", "application/jsonl"},
+	YAMLFormat:   {"application/yaml", "application/x-yaml", "text/yaml", "text/x-yaml"},
+}
+
+// ContentType returns the media type questions in the format are sent as
+func (f TransferFormat) ContentType() string {
+	return transferContentTypes[f][0]
+}
+
+// FormatOfContentType returns the format of a Content-Type header, false when it is none of the transfer formats
+func FormatOfContentType(contentType string) (TransferFormat, bool) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", false
+	}
+	for format, contentTypes := range transferContentTypes {
+		for _, known := range contentTypes {
+			if mediaType == known {
+				return format, true
+			}
+		}
+	}
+	return "", false
+}
+
+// ImportAction is what an import did, or in a dry run would do, with one question
+type ImportAction string
+
+const (
+	ImportCreated   ImportAction = "created"   // No live question had its title
+	ImportUpdated   ImportAction = "updated"   // Replaced the content of the question with its title, as a new revision
+	ImportUnchanged ImportAction = "unchanged" // The question with its title already had the same content
+	ImportInvalid   ImportAction = "invalid"   // Not imported, see Issues and Error
+)
+
+// ImportItemResult reports on one question of an import, in the order of the imported document
+type ImportItemResult struct {
+	Index         int               `json:"index"`
+	Title         string            `json:"title,omitempty"`
+	Action        ImportAction      `json:"action"`
+	ID            string            `json:"id,omitempty"`             // Of the created or updated question, empty in dry runs of new questions
+	ChangedFields []string          `json:"changed_fields,omitempty"` // Updated questions only, the paths of the changed values
+	Issues        []ValidationIssue `json:"issues,omitempty"`         // Why an invalid question failed validation
+	Error         string            `json:"error,omitempty"`          // Why an invalid question could not be read or stored
+}
+
+// ImportResult is the response of POST /questions/import
+type ImportResult struct {
+	DryRun    bool               `json:"dry_run"` // Nothing was stored, the actions are what the import would do
+	Created   int                `json:"created"`
+	Updated   int                `json:"updated"`
+	Unchanged int                `json:"unchanged"`
+	Invalid   int                `json:"invalid"`
+	Items     []ImportItemResult `json:"items"`
+}
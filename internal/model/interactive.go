@@ -0,0 +1,26 @@
+package model
+
+// InteractiveConfig describes an interactive question, e.g. guess the number, where the user's
+// program talks over stdin/stdout with a judge whose answers depend on the previous queries.
+// Its test cases hold one parameter, the judge's input as JSON (e.g. the hidden number).
+type InteractiveConfig struct {
+	Judge      map[PredefinedSupportedLanguage]string `json:"judge,omitempty" bson:"judge"`                       // Per runner language, defines judge(test_input, interactor) returning the verdict and a message, left out of the public question
+	QueryLimit int                                    `json:"query_limit,omitempty" bson:"query_limit,omitempty"` // Most lines the program may send per test case, 0 means unlimited
+	TimeLimit  float64                                `json:"time_limit,omitempty" bson:"time_limit,omitempty"`   // Seconds per test case, 0 means DefaultInteractiveTimeLimit
+}
+
+// InteractiveLanguages lists the languages interactive questions can be solved in
+var InteractiveLanguages = []PredefinedSupportedLanguage{Python, JavaScript}
+
+const (
+	DefaultInteractiveTimeLimit = 5.0  // Seconds per test case
+	MaxInteractiveTimeLimit     = 30.0 // Seconds per test case
+)
+
+// GetTimeLimit returns the time limit per test case, defaulting to DefaultInteractiveTimeLimit.
+func (c *InteractiveConfig) GetTimeLimit() float64 {
+	if c == nil || c.TimeLimit == 0 {
+		return DefaultInteractiveTimeLimit
+	}
+	return c.TimeLimit
+}
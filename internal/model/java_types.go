@@ -0,0 +1,19 @@
+package model
+
+// JavaTypeStyle chooses how abstract types are declared in Java signatures
+type JavaTypeStyle string
+
+const (
+	JavaBoxed     JavaTypeStyle = "boxed"     // Integer, List<Integer>, List<List<Integer>>
+	JavaPrimitive JavaTypeStyle = "primitive" // int, int[], int[][]
+)
+
+var JavaTypeStyles = []JavaTypeStyle{JavaBoxed, JavaPrimitive}
+
+// GetJavaTypeStyle returns the Java type style of the function, defaulting to JavaBoxed.
+func (f FunctionConfig) GetJavaTypeStyle() JavaTypeStyle {
+	if f.JavaTypeStyle == "" {
+		return JavaBoxed
+	}
+	return f.JavaTypeStyle
+}
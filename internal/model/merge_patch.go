@@ -0,0 +1,54 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// MergePatchContentType is the media type of JSON Merge Patch documents (RFC 7396)
+const MergePatchContentType = "application/merge-patch+json"
+
+// ApplyMergePatch returns a copy of the question with a JSON Merge Patch applied: members of the patch replace
+// the fields of the question, nested objects are merged and null removes a field. Arrays are replaced whole.
+func (q *Question) ApplyMergePatch(patch []byte) (*Question, error) {
+	var patchValue interface{}
+	if err := json.Unmarshal(patch, &patchValue); err != nil {
+		return nil, NewCustomError(400, fmt.Sprintf("invalid merge patch: %v", err))
+	}
+	if _, isObject := patchValue.(map[string]interface{}); !isObject {
+		return nil, NewCustomError(400, "a merge patch of a question must be a JSON object")
+	}
+	target, err := toJSONValue(q)
+	if err != nil {
+		return nil, err
+	}
+	merged, err := json.Marshal(mergePatch(target, patchValue))
+	if err != nil {
+		return nil, err
+	}
+	var patched Question
+	if err := json.Unmarshal(merged, &patched); err != nil {
+		return nil, NewCustomError(400, fmt.Sprintf("the patched question is invalid: %v", err))
+	}
+	return &patched, nil
+}
+
+// mergePatch implements the MergePatch algorithm of RFC 7396 on decoded JSON values
+func mergePatch(target, patch interface{}) interface{} {
+	patchObject, isObject := patch.(map[string]interface{})
+	if !isObject {
+		return patch
+	}
+	targetObject, isObject := target.(map[string]interface{})
+	if !isObject {
+		targetObject = map[string]interface{}{}
+	}
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+		} else {
+			targetObject[key] = mergePatch(targetObject[key], value)
+		}
+	}
+	return targetObject
+}
@@ -0,0 +1,75 @@
+package model_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/model"
+)
+
+func TestApplyMergePatch(t *testing.T) {
+	question := model.Question{
+		Title:          "Two Sum",
+		Difficulty:     "Easy",
+		Tags:           []string{"hash", "array"},
+		Checker:        &model.Checker{Language: model.Python, Code: "def check(): pass"},
+		FunctionConfig: model.FunctionConfig{Name: "twoSum", MutatedParameter: "nums"},
+	}
+	patched, err := question.ApplyMergePatch([]byte(`{"difficulty": "Medium", "tags": ["hash"], "checker": null, "function_config": {"mutated_parameter": null}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if patched.Difficulty != "Medium" || patched.Title != "Two Sum" {
+		t.Errorf("expected the difficulty replaced and the title kept, got %q %q", patched.Difficulty, patched.Title)
+	}
+	if !reflect.DeepEqual(patched.Tags, []string{"hash"}) {
+		t.Errorf("expected arrays to be replaced whole, got %v", patched.Tags)
+	}
+	if patched.Checker != nil {
+		t.Errorf("expected null to remove the checker, got %+v", patched.Checker)
+	}
+	if patched.FunctionConfig.Name != "twoSum" || patched.FunctionConfig.MutatedParameter != "" {
+		t.Errorf("expected nested objects to be merged, got %+v", patched.FunctionConfig)
+	}
+	if question.Difficulty != "Easy" || question.Checker == nil {
+		t.Errorf("expected the question to be left unchanged")
+	}
+
+	for _, patch := range []string{`["title"]`, `{"title": `, `{"tags": "hash"}`} {
+		if _, err := question.ApplyMergePatch([]byte(patch)); err == nil {
+			t.Errorf("%s: expected an error", patch)
+		} else if customErr, ok := err.(*model.CustomError); !ok || customErr.Code != 400 {
+			t.Errorf("%s: expected a 400, got %v", patch, err)
+		}
+	}
+}
+
+func TestParseIfMatch(t *testing.T) {
+	cases := []struct {
+		header   string
+		revision int
+		valid    bool
+	}{
+		{`"3"`, 3, true},
+		{` "0" `, 0, true},
+		{"*", model.AnyRevision, true},
+		{"3", 0, false},
+		{`W/"3"`, 0, false},
+		{`"-1"`, 0, false},
+		{`"three"`, 0, false},
+		{"", 0, false},
+	}
+	for _, c := range cases {
+		revision, err := model.ParseIfMatch(c.header)
+		if c.valid && (err != nil || revision != c.revision) {
+			t.Errorf("%q: expected revision %d, got %d %v", c.header, c.revision, revision, err)
+		}
+		if !c.valid && err == nil {
+			t.Errorf("%q: expected an error, got revision %d", c.header, revision)
+		}
+	}
+	question := model.Question{Revision: 7}
+	if revision, err := model.ParseIfMatch(question.ETag()); err != nil || revision != 7 {
+		t.Errorf("expected the ETag of a question to match its revision, got %d %v", revision, err)
+	}
+}
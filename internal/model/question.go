@@ -1,7 +1,11 @@
 package model
 
 import (
+	"encoding/json"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
@@ -10,32 +14,141 @@ import (
 type AbstractType struct {
 	Type         string        `json:"type" bson:"type" validate:"required"`                   // AtomicType or CompositeType
 	TypeChildren *AbstractType `json:"type_children,omitempty" bson:"type_children,omitempty"` // Recursive reference
+
+	// Graph only
+	Directed   *bool         `json:"directed,omitempty" bson:"directed,omitempty"`       // Nil means directed, as Graphs were before the field
+	WeightType *AbstractType `json:"weight_type,omitempty" bson:"weight_type,omitempty"` // Nil means unweighted
+	Format     GraphFormat   `json:"format,omitempty" bson:"format,omitempty"`           // Empty means EdgeList
 }
 
 // toPrint converts the AbstractType to a string representation.
 func (a *AbstractType) ToPrint() string {
 	if a.TypeChildren != nil {
 		// Recursive case: composite type with children
+		if a.Type == string(Graph) {
+			return fmt.Sprintf("%s < %s >", a.graphPrefix(), a.TypeChildren.ToPrint())
+		}
 		return fmt.Sprintf("%s < %s >", a.Type, a.TypeChildren.ToPrint())
 	}
 	// Base case: atomic type
 	return a.Type
 }
 
+// graphPrefix describes the Graph variant, e.g. "Undirected Weighted(Double) Graph"
+func (a *AbstractType) graphPrefix() string {
+	prefix := ""
+	if !a.IsDirected() {
+		prefix += "Undirected "
+	}
+	if a.WeightType != nil {
+		prefix += fmt.Sprintf("Weighted(%s) ", a.WeightType.ToPrint())
+	}
+	prefix += a.Type
+	if a.GetGraphFormat() == AdjacencyList {
+		prefix += " (adjacency list)"
+	}
+	return prefix
+}
+
+// Public returns the question as solvers may see it, without the reference solution, the checker code
+// and the judges that would give the answers away
+func (q Question) Public() Question {
+	q.ReferenceSolution = nil
+	if q.Checker != nil {
+		q.Checker = &Checker{Language: q.Checker.Language}
+	}
+	if q.InteractiveConfig != nil {
+		config := *q.InteractiveConfig
+		config.Judge = nil
+		q.InteractiveConfig = &config
+	}
+	return q
+}
+
+// KeepSource copies the reference solution, the checker code and the judges of stored into q when q leaves them out,
+// as a PUT of the public question does
+func (q *Question) KeepSource(stored *Question) {
+	if q.ReferenceSolution == nil {
+		q.ReferenceSolution = stored.ReferenceSolution
+	}
+	if q.Checker != nil && q.Checker.Code == "" && stored.Checker != nil {
+		q.Checker.Code = stored.Checker.Code
+	}
+	if q.InteractiveConfig != nil && len(q.InteractiveConfig.Judge) == 0 && stored.InteractiveConfig != nil {
+		q.InteractiveConfig.Judge = stored.InteractiveConfig.Judge
+	}
+}
+
+// IsDirected reports whether each edge of a Graph goes only from its first node to its second, the default.
+func (a *AbstractType) IsDirected() bool {
+	return a.Directed == nil || *a.Directed
+}
+
+// IsWeighted reports whether a Graph carries a weight on each edge.
+func (a *AbstractType) IsWeighted() bool {
+	return a.WeightType != nil
+}
+
+// GetGraphFormat returns the input format of a Graph, defaulting to EdgeList.
+func (a *AbstractType) GetGraphFormat() GraphFormat {
+	if a.Format == "" {
+		return EdgeList
+	}
+	return a.Format
+}
+
 
 // Parameter represents a function parameter.
 type Parameter struct {
-	Name      string       `json:"name" bson:"name" validate:"required"`             // Parameter name
-	ParamType AbstractType `json:"param_type" bson:"param_type" validate:"required"` // Parameter type
+	Name        string       `json:"name" bson:"name" validate:"required"`               // Parameter name
+	ParamType   AbstractType `json:"param_type" bson:"param_type" validate:"required"`   // Parameter type
+	Constraints *Constraints `json:"constraints,omitempty" bson:"constraints,omitempty"` // Nil means unconstrained
+	Description string       `json:"description,omitempty" bson:"description,omitempty"` // Shown in the generated doc comments
+	JavaType    string       `json:"java_type,omitempty" bson:"java_type,omitempty"`     // Overrides the Java type of the parameter, e.g. "long" or "char[][]"
 }
 
 type FunctionConfig struct {
-	Name       string        `json:"name" bson:"name" validate:"required"`               // Function name
-	Parameters *[]Parameter  `json:"parameters,omitempty" bson:"parameters,omitempty"`   // Pointer to slice for nil 
-	ReturnType *AbstractType `json:"return_type,omitempty" bson:"return_type,omitempty"` // Nil means VoidType
+	Name             string        `json:"name" bson:"name" validate:"required"`                           // Function name
+	Parameters       *[]Parameter  `json:"parameters,omitempty" bson:"parameters,omitempty"`               // Pointer to slice for nil 
+	ReturnType       *AbstractType `json:"return_type,omitempty" bson:"return_type,omitempty"`             // Nil means VoidType
+	MutatedParameter string        `json:"mutated_parameter,omitempty" bson:"mutated_parameter,omitempty"` // VoidType only, the parameter modified in place
+	JavaTypeStyle    JavaTypeStyle `json:"java_type_style,omitempty" bson:"java_type_style,omitempty"`     // Empty means JavaBoxed
+	ReturnJavaType   string        `json:"return_java_type,omitempty" bson:"return_java_type,omitempty"`   // Overrides the Java return type, e.g. "long[]"
 }
 
-// InputOutput represents example inputs and expected outputs for a function
+// IsVoid reports whether the function returns nothing and modifies MutatedParameter in place.
+func (f FunctionConfig) IsVoid() bool {
+	return f.ReturnType == nil
+}
+
+// MutatedParameterIndex returns the position of MutatedParameter, or -1 when there is none.
+func (f FunctionConfig) MutatedParameterIndex() int {
+	if f.MutatedParameter == "" || f.Parameters == nil {
+		return -1
+	}
+	for i, param := range *f.Parameters {
+		if param.Name == f.MutatedParameter {
+			return i
+		}
+	}
+	return -1
+}
+
+// OutputType returns the type expected outputs are written in: the return type, or for
+// a void function the type of the mutated parameter, whose state after the call is compared.
+// It is nil when neither is set.
+func (f FunctionConfig) OutputType() *AbstractType {
+	if f.ReturnType != nil {
+		return f.ReturnType
+	}
+	if index := f.MutatedParameterIndex(); index >= 0 {
+		return &(*f.Parameters)[index].ParamType
+	}
+	return nil
+}
+
+// InputOutput represents example inputs and expected outputs for a function.
+// Every value is JSON encoded: Strings are quoted literals ("a, b"), arrays are JSON arrays (["a", "[x]"]).
 type InputOutput struct {
 	Parameters     []string `bson:"parameters" json:"parameters" validate:"required"`           // Input parameters
 	ExpectedOutput string   `bson:"expected_output" json:"expected_output" validate:"required"` // Expected output
@@ -47,18 +160,94 @@ type Question struct {
 	Title          string             `bson:"title" json:"title" validate:"required"`                                  // Question title
 	Description    string             `bson:"description" json:"description" validate:"required"`                      // Question description
 	Difficulty     string             `bson:"difficulty" json:"difficulty" validate:"required,oneof=Easy Medium Hard"` // Difficulty level
-	Category       string             `bson:"category" json:"category" validate:"required"`                            // Question category (e.g., Tree, Array)
-	Stats          int                `bson:"stats" json:"stats"`                                                      // Submission stats
+	Category       string             `bson:"category" json:"category" validate:"required"`                            // Question category (e.g., Tree, Array), the first of Categories
+	Categories     []string           `bson:"categories" json:"categories"`                                            // Categories of the taxonomy, set to Category alone when empty
+	Tags           []string           `bson:"tags,omitempty" json:"tags,omitempty"`                                    // Free-form keywords, searched with the title and description
+	Companies      []string           `bson:"companies,omitempty" json:"companies,omitempty"`                          // Companies known to ask the question
+	Stats          QuestionStats      `bson:"stats" json:"stats"`                                                      // Graded submission stats, only changed by submissions
+	Revision       int                `bson:"revision" json:"revision"`                                                // Latest revision, 0 for questions created before revisions
+	DeletedAt      *time.Time         `bson:"deleted_at,omitempty" json:"deleted_at,omitempty"`                        // Set while the question is in the trash
 	Examples       []InputOutput      `bson:"examples" json:"examples" validate:"dive"`                                // Examples of input/output
 	TestCases      []InputOutput      `bson:"test_cases" json:"test_cases" validate:"dive"`                            // Test cases
 	FunctionConfig FunctionConfig     `bson:"function_config" json:"function_config"`                                  // Function signature configuration
 	Languages      []string           `bson:"languages" json:"languages" validate:"dive"`                              // Supported programming languages
+
+	ReferenceSolution *Submission `bson:"reference_solution,omitempty" json:"reference_solution,omitempty"` // Computes expected outputs of generated test cases
+	Comparator        *Comparator `bson:"comparator,omitempty" json:"comparator,omitempty"`                 // Output matching, nil means exact
+	Checker           *Checker    `bson:"checker,omitempty" json:"checker,omitempty"`                       // Grades outputs instead of the comparator when set
+
+	Kind              QuestionKind       `bson:"kind,omitempty" json:"kind,omitempty"`                             // Empty means FunctionQuestion
+	ClassConfig       *ClassConfig       `bson:"class_config,omitempty" json:"class_config,omitempty"`             // ClassQuestion only, replaces FunctionConfig
+	InteractiveConfig *InteractiveConfig `bson:"interactive_config,omitempty" json:"interactive_config,omitempty"` // InteractiveQuestion only, replaces FunctionConfig
+	StdioConfig       *StdioConfig       `bson:"stdio_config,omitempty" json:"stdio_config,omitempty"`             // StdioQuestion only, nil means the defaults
+}
+
+// NormalizeTaxonomy keeps Category and Categories consistent, Category being the first of Categories,
+// and trims and deduplicates the categories, tags and companies
+func (q *Question) NormalizeTaxonomy() {
+	q.Categories = normalizeLabels(q.Categories)
+	if category := strings.TrimSpace(q.Category); len(q.Categories) == 0 && category != "" {
+		q.Categories = []string{category}
+	}
+	if len(q.Categories) > 0 {
+		q.Category = q.Categories[0]
+	}
+	q.Tags = normalizeLabels(q.Tags)
+	q.Companies = normalizeLabels(q.Companies)
+}
+
+// KeepCategoryChange makes Category the first of Categories when it was changed alone from stored, as clients
+// did before questions had several categories: it is moved first when it is one of them, and replaces the first
+// otherwise. NormalizeTaxonomy would set it back to the stored first category.
+func (q *Question) KeepCategoryChange(stored *Question) {
+	category := strings.TrimSpace(q.Category)
+	if category == "" || len(q.Categories) == 0 || strings.EqualFold(category, stored.Category) || !slices.Equal(q.Categories, stored.Categories) {
+		return
+	}
+	others := q.Categories[1:]
+	if index := slices.IndexFunc(q.Categories, func(name string) bool { return strings.EqualFold(name, category) }); index > 0 {
+		others = slices.Delete(slices.Clone(q.Categories), index, index+1)
+	}
+	q.Categories = append([]string{category}, others...)
+}
+
+// normalizeLabels trims labels and drops the empty and repeated ones, case-insensitively, keeping their order
+func normalizeLabels(labels []string) []string {
+	if labels == nil {
+		return nil
+	}
+	normalized := []string{}
+	seen := map[string]bool{}
+	for _, label := range labels {
+		label = strings.Join(strings.Fields(label), " ")
+		if key := strings.ToLower(label); label != "" && !seen[key] {
+			seen[key] = true
+			normalized = append(normalized, label)
+		}
+	}
+	return normalized
+}
+
+// IsClassDesign reports whether the user implements a class rather than a function.
+func (q *Question) IsClassDesign() bool {
+	return q.Kind == ClassQuestion
+}
+
+// IsInteractive reports whether the user writes a program that talks with a judge.
+func (q *Question) IsInteractive() bool {
+	return q.Kind == InteractiveQuestion
+}
+
+// IsStdio reports whether the user writes a program that reads stdin and writes stdout.
+func (q *Question) IsStdio() bool {
+	return q.Kind == StdioQuestion
 }
 
 // Solution represents a user-provided solution for a coding question
 type Submission struct {
-	Language PredefinedSupportedLanguage `json:"language"`
-	Code     string                      `json:"code"`
+	Language        PredefinedSupportedLanguage `json:"language" bson:"language"`
+	Code            string                      `json:"code" bson:"code"`
+	CustomTestCases []InputOutput               `json:"custom_test_cases,omitempty" bson:"custom_test_cases,omitempty"` // Run instead of the question's test cases, expected outputs may be left empty when the question has a reference solution
 }
 
 type QuestionQueryParams struct {
@@ -67,4 +256,73 @@ type QuestionQueryParams struct {
 	Difficulties []string `json:"difficulties"`
 	SortBy       string   `json:"sort_by"`
 	SortOrder    string   `json:"order"`
+	Limit        int      `json:"limit"`      // Page size, 0 means DefaultPageSize
+	PageToken    string   `json:"page_token"` // From the previous page, empty for the first page
+	Fields       []string `json:"fields"`     // Of QuestionListFields, empty means QuestionSummaryFields
+	Tags         []string `json:"tags"`       // Questions with any or all of them, as TagMatch says
+	TagMatch     string   `json:"tag_match"`  // any or all, empty means any
+	Companies    []string `json:"companies"`  // Questions asked by any of them
+	Trashed      bool     `json:"trashed"`    // Lists the questions in the trash instead of the live ones
+}
+
+// QuestionSummaryFields are the fields of a question in list responses by default
+var QuestionSummaryFields = []string{"id", "title", "difficulty", "category", "categories", "tags", "companies", "stats", "languages"}
+
+// QuestionListFields are the fields list responses may select. Test cases, reference solutions,
+// checkers, judges and comparators are only returned with the full question.
+var QuestionListFields = append(slices.Clone(QuestionSummaryFields), "description", "examples", "function_config", "class_config", "kind", "deleted_at")
+
+// QuestionFields holds the selected fields of a question, by their JSON names
+type QuestionFields map[string]json.RawMessage
+
+// SelectFields returns the fields of the public question named in fields, the ones it omits are left out
+func (q *Question) SelectFields(fields []string) (QuestionFields, error) {
+	data, err := json.Marshal(q.Public())
+	if err != nil {
+		return nil, err
+	}
+	var all QuestionFields
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+	selected := QuestionFields{}
+	for _, field := range fields {
+		if value, exists := all[field]; exists {
+			selected[field] = value
+		}
+	}
+	return selected, nil
+}
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// QuestionSortKeys lists the keys questions can be sorted by. Searches default to relevance, other queries to title,
+// and the trash to deleted_at, most recently deleted first. stats sorts by attempts.
+var QuestionSortKeys = []string{"title", "stats", "attempts", "acceptance_rate", "unique_solvers", "median_runtime", "difficulty", "category", "relevance", "deleted_at"}
+
+// StatsSortFields maps the sort keys of the submission stats to their stored fields
+var StatsSortFields = map[string]string{
+	"stats":           "stats.attempts",
+	"attempts":        "stats.attempts",
+	"acceptance_rate": "stats.acceptance_rate",
+	"unique_solvers":  "stats.unique_solvers",
+	"median_runtime":  "stats.median_runtime_ms",
+}
+
+// SearchHighlight is a snippet of a searched field of a question, with the matches wrapped in <em></em>
+type SearchHighlight struct {
+	Field   string `json:"field"`   // title, tags, categories, companies or description
+	Snippet string `json:"snippet"` // HTML-escaped
+}
+
+// QuestionPage is one page of the questions matching a query
+type QuestionPage struct {
+	Questions     []QuestionFields `json:"questions"`
+	Total         int64            `json:"total"`                     // Matching questions across all pages
+	NextPageToken string           `json:"next_page_token,omitempty"` // Empty on the last page
+
+	Highlights map[string][]SearchHighlight `json:"highlights,omitempty"` // Searches only, the matching snippets by question ID
 }
@@ -0,0 +1,63 @@
+package model_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/model"
+)
+
+func TestPublicQuestionHidesTheAnswers(t *testing.T) {
+	question := model.Question{
+		Title:             "Echo",
+		ReferenceSolution: &model.Submission{Language: model.Python, Code: "def echo(x): return x"},
+		Checker:           &model.Checker{Language: model.Python, Code: "def check(inputs, expected, actual): return True"},
+		InteractiveConfig: &model.InteractiveConfig{Judge: map[model.PredefinedSupportedLanguage]string{model.Python: "def judge(secret, interactor): return True"}, QueryLimit: 10},
+	}
+	public := question.Public()
+	if public.ReferenceSolution != nil || public.Checker.Code != "" || public.InteractiveConfig.Judge != nil || public.Title != "Echo" {
+		t.Errorf("expected the public question to hide only the reference solution, the checker code and the judges, got %+v", public)
+	}
+	if public.Checker.Language != model.Python || public.InteractiveConfig.QueryLimit != 10 {
+		t.Errorf("expected the public question to keep the checker language and the query limit, got %+v %+v", public.Checker, public.InteractiveConfig)
+	}
+	if question.ReferenceSolution == nil || question.Checker.Code == "" || question.InteractiveConfig.Judge == nil {
+		t.Errorf("expected Public to leave the stored question unchanged")
+	}
+}
+
+func TestKeepSource(t *testing.T) {
+	stored := model.Question{
+		ReferenceSolution: &model.Submission{Language: model.Python, Code: "def echo(x): return x"},
+		Checker:           &model.Checker{Language: model.Python, Code: "def check(inputs, expected, actual): return True"},
+		InteractiveConfig: &model.InteractiveConfig{Judge: map[model.PredefinedSupportedLanguage]string{model.Python: "def judge(secret, interactor): return True"}},
+	}
+
+	public := stored.Public()
+	public.KeepSource(&stored)
+	if public.ReferenceSolution != stored.ReferenceSolution || public.Checker.Code != stored.Checker.Code || public.InteractiveConfig.Judge[model.Python] == "" {
+		t.Errorf("expected a question sent without its source to keep the stored one, got %+v %+v %+v", public.ReferenceSolution, public.Checker, public.InteractiveConfig)
+	}
+
+	replaced := model.Question{ReferenceSolution: &model.Submission{Language: model.JavaScript, Code: "const echo = x => x"}}
+	replaced.KeepSource(&stored)
+	if replaced.ReferenceSolution.Language != model.JavaScript {
+		t.Errorf("expected a new reference solution to replace the stored one, got %+v", replaced.ReferenceSolution)
+	}
+}
+
+func TestSelectFields(t *testing.T) {
+	question := model.Question{
+		Title:             "Two Sum",
+		Difficulty:        "Easy",
+		Stats:             model.QuestionStats{Attempts: 3},
+		ReferenceSolution: &model.Submission{Language: model.Python, Code: "def two_sum(nums, target): pass"},
+	}
+	fields, err := question.SelectFields([]string{"title", "stats", "tags", "reference_solution", "unknown"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fields) != 2 || string(fields["title"]) != `"Two Sum"` || !strings.HasPrefix(string(fields["stats"]), `{"attempts":3,`) {
+		t.Errorf("expected the title and stats only, hidden, omitted and unknown fields left out, got %v", fields)
+	}
+}
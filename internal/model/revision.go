@@ -0,0 +1,235 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AnonymousAuthor is the author of revisions made without naming one
+const AnonymousAuthor = "anonymous"
+
+// QuestionRevision is an immutable snapshot of a question, recorded on every change.
+// The first revision of a question is 1.
+type QuestionRevision struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	QuestionID primitive.ObjectID `bson:"question_id" json:"question_id"`
+	Revision   int                `bson:"revision" json:"revision"`
+	Author     string             `bson:"author" json:"author"`
+	CreatedAt  time.Time          `bson:"created_at" json:"created_at"`
+	Message    string             `bson:"message,omitempty" json:"message,omitempty"` // e.g. "rolled back to revision 3"
+	Diff       []FieldChange      `bson:"diff" json:"diff"`                           // From the previous revision, empty for the first one
+	Snapshot   *Question          `bson:"snapshot,omitempty" json:"snapshot,omitempty"`
+}
+
+// FieldChange is a changed value of a question, at a JSON path such as "test_cases[3].expected_output".
+// Values are JSON encoded, Old is empty when the value was added and New when it was removed.
+type FieldChange struct {
+	Path string `bson:"path" json:"path"`
+	Old  string `bson:"old,omitempty" json:"old,omitempty"`
+	New  string `bson:"new,omitempty" json:"new,omitempty"`
+}
+
+// Public returns the revision as solvers may see it, with the public snapshot and without the changes Public hides
+func (r QuestionRevision) Public() QuestionRevision {
+	if r.Snapshot != nil {
+		snapshot := r.Snapshot.Public()
+		r.Snapshot = &snapshot
+	}
+	r.Diff = publicChanges(r.Diff)
+	return r
+}
+
+// hiddenPaths are the JSON paths of the fields Question.Public hides
+var hiddenPaths = []string{"reference_solution", "checker.code", "interactive_config.judge"}
+
+// publicChanges leaves out the changes of hidden fields, and removes them from the values of their parents
+// (a checker added as a whole changes at "checker")
+func publicChanges(changes []FieldChange) []FieldChange {
+	public := []FieldChange{}
+	for _, change := range changes {
+		hidden := false
+		for _, path := range hiddenPaths {
+			if change.Path == path || strings.HasPrefix(change.Path, path+".") || strings.HasPrefix(change.Path, path+"[") {
+				hidden = true
+			} else if field, isParent := strings.CutPrefix(path, change.Path+"."); isParent {
+				change.Old = withoutField(change.Old, field)
+				change.New = withoutField(change.New, field)
+			}
+		}
+		if !hidden && change.Old != change.New {
+			public = append(public, change)
+		}
+	}
+	return public
+}
+
+// withoutField removes the field at the dotted path from the JSON object value
+func withoutField(value string, path string) string {
+	var decoded map[string]interface{}
+	if value == "" || json.Unmarshal([]byte(value), &decoded) != nil {
+		return value
+	}
+	parent := decoded
+	keys := strings.Split(path, ".")
+	for _, key := range keys[:len(keys)-1] {
+		child, isMap := parent[key].(map[string]interface{})
+		if !isMap {
+			return value
+		}
+		parent = child
+	}
+	delete(parent, keys[len(keys)-1])
+	data, err := json.Marshal(decoded)
+	if err != nil {
+		return value
+	}
+	return string(data)
+}
+
+// Fields that change with every revision rather than with the content
+var unversionedFields = map[string]bool{"id": true, "revision": true, "stats": true, "deleted_at": true}
+
+// DiffQuestions lists the changes from old to new, down to the changed leaves of their JSON representations
+func DiffQuestions(old, new *Question) ([]FieldChange, error) {
+	oldValue, err := toJSONValue(old)
+	if err != nil {
+		return nil, err
+	}
+	newValue, err := toJSONValue(new)
+	if err != nil {
+		return nil, err
+	}
+	for field := range unversionedFields {
+		delete(oldValue.(map[string]interface{}), field)
+		delete(newValue.(map[string]interface{}), field)
+	}
+	changes := []FieldChange{}
+	if err := diffJSON("", oldValue, newValue, &changes); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+func toJSONValue(value interface{}) (interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var decoded interface{}
+	err = json.Unmarshal(data, &decoded)
+	return decoded, err
+}
+
+func diffJSON(path string, old, new interface{}, changes *[]FieldChange) error {
+	if reflect.DeepEqual(old, new) {
+		return nil
+	}
+	oldMap, oldIsMap := old.(map[string]interface{})
+	newMap, newIsMap := new.(map[string]interface{})
+	if oldIsMap && newIsMap {
+		keys := map[string]bool{}
+		for key := range oldMap {
+			keys[key] = true
+		}
+		for key := range newMap {
+			keys[key] = true
+		}
+		sorted := make([]string, 0, len(keys))
+		for key := range keys {
+			sorted = append(sorted, key)
+		}
+		sort.Strings(sorted)
+		for _, key := range sorted {
+			childPath := key
+			if path != "" {
+				childPath = path + "." + key
+			}
+			if err := diffJSON(childPath, oldMap[key], newMap[key], changes); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	oldList, oldIsList := old.([]interface{})
+	newList, newIsList := new.([]interface{})
+	if oldIsList && newIsList {
+		for i := 0; i < max(len(oldList), len(newList)); i++ {
+			var oldItem, newItem interface{}
+			if i < len(oldList) {
+				oldItem = oldList[i]
+			}
+			if i < len(newList) {
+				newItem = newList[i]
+			}
+			if err := diffJSON(fmt.Sprintf("%s[%d]", path, i), oldItem, newItem, changes); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	change := FieldChange{Path: path}
+	if old != nil {
+		data, err := json.Marshal(old)
+		if err != nil {
+			return err
+		}
+		change.Old = string(data)
+	}
+	if new != nil {
+		data, err := json.Marshal(new)
+		if err != nil {
+			return err
+		}
+		change.New = string(data)
+	}
+	*changes = append(*changes, change)
+	return nil
+}
+
+// RevisionDiff lists the changes between two revisions of a question
+type RevisionDiff struct {
+	QuestionID primitive.ObjectID `json:"question_id"`
+	From       int                `json:"from"`
+	To         int                `json:"to"`
+	Changes    []FieldChange      `json:"changes"`
+}
+
+// Public returns the diff without the changes of the fields Question.Public hides
+func (d RevisionDiff) Public() RevisionDiff {
+	d.Changes = publicChanges(d.Changes)
+	return d
+}
+
+// AnyRevision matches every revision of a question, for changes made without a precondition
+const AnyRevision = -1
+
+// ETag is the entity tag of the question: its revision, which changes with every change of its content
+func (q *Question) ETag() string {
+	return strconv.Quote(strconv.Itoa(q.Revision))
+}
+
+// ParseIfMatch returns the revision an If-Match header names, AnyRevision for "*".
+// Only strong entity tags match, as RFC 9110 requires for If-Match.
+func ParseIfMatch(header string) (int, error) {
+	header = strings.TrimSpace(header)
+	if header == "*" {
+		return AnyRevision, nil
+	}
+	tag, err := strconv.Unquote(header)
+	if err != nil || !strings.HasPrefix(header, `"`) {
+		return 0, NewCustomError(400, fmt.Sprintf("If-Match must be a quoted entity tag such as \"3\", got %s", header))
+	}
+	revision, err := strconv.Atoi(tag)
+	if err != nil || revision < 0 {
+		return 0, NewCustomError(400, fmt.Sprintf("If-Match names no revision: %s", header))
+	}
+	return revision, nil
+}
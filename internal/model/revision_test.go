@@ -0,0 +1,76 @@
+package model_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/model"
+)
+
+func TestDiffQuestions(t *testing.T) {
+	old := model.Question{
+		Title:     "Two Sum",
+		Tags:      []string{"hash"},
+		TestCases: []model.InputOutput{{Parameters: []string{"1"}, ExpectedOutput: "2"}},
+		Revision:  3,
+		Stats:     model.QuestionStats{Attempts: 5},
+	}
+	updated := old
+	updated.Title = "Two Sum II"
+	updated.Tags = nil
+	updated.TestCases = []model.InputOutput{{Parameters: []string{"1"}, ExpectedOutput: "3"}, {Parameters: []string{"2"}, ExpectedOutput: "4"}}
+	updated.Revision, updated.Stats = 4, model.QuestionStats{Attempts: 6}
+
+	changes, err := model.DiffQuestions(&old, &updated)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []model.FieldChange{
+		{Path: "tags", Old: `["hash"]`},
+		{Path: "test_cases[0].expected_output", Old: `"2"`, New: `"3"`},
+		{Path: "test_cases[1]", New: `{"expected_output":"4","parameters":["2"]}`},
+		{Path: "title", Old: `"Two Sum"`, New: `"Two Sum II"`},
+	}
+	if !reflect.DeepEqual(changes, expected) {
+		t.Errorf("expected %+v, got %+v", expected, changes)
+	}
+
+	if changes, err := model.DiffQuestions(&old, &old); err != nil || len(changes) != 0 {
+		t.Errorf("expected no changes, got %+v %v", changes, err)
+	}
+}
+
+func TestPublicRevisionHidesTheAnswers(t *testing.T) {
+	old := model.Question{Title: "Order", ReferenceSolution: &model.Submission{Language: model.Python, Code: "v1"}}
+	updated := old
+	updated.Title = "Topological order"
+	updated.ReferenceSolution = &model.Submission{Language: model.Python, Code: "v2"}
+	updated.Checker = &model.Checker{Language: model.Python, Code: "def check(inputs, expected, actual): return True"}
+	changes, err := model.DiffQuestions(&old, &updated)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	revision := model.QuestionRevision{Revision: 2, Diff: changes, Snapshot: &updated}.Public()
+	expected := []model.FieldChange{
+		{Path: "checker", New: `{"language":"Python"}`},
+		{Path: "title", Old: `"Order"`, New: `"Topological order"`},
+	}
+	if !reflect.DeepEqual(revision.Diff, expected) {
+		t.Errorf("expected %+v, got %+v", expected, revision.Diff)
+	}
+	if revision.Snapshot.ReferenceSolution != nil || revision.Snapshot.Checker.Code != "" || updated.Checker.Code == "" {
+		t.Errorf("expected a public copy of the snapshot, got %+v", revision.Snapshot)
+	}
+
+	// A change to the checker code alone leaves nothing to show
+	edited := updated
+	edited.Checker = &model.Checker{Language: model.Python, Code: "def check(inputs, expected, actual): return False"}
+	changes, err = model.DiffQuestions(&updated, &edited)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := (model.RevisionDiff{Changes: changes}).Public(); len(changes) != 1 || len(diff.Changes) != 0 {
+		t.Errorf("expected the checker code change to be hidden, got %+v of %+v", diff.Changes, changes)
+	}
+}
@@ -0,0 +1,18 @@
+package model
+
+// ParameterSignature describes a parameter as written in one language
+type ParameterSignature struct {
+	Name         string `json:"name"`          // After case conversion, e.g. "max_depth" in Python
+	OriginalName string `json:"original_name"` // As configured in the question
+	Type         string `json:"type"`          // The mapped type, e.g. "list[int]" in Python or "int[]" in Java
+}
+
+// LanguageSignature is the starter code of a question in one language, with what editors need to switch languages
+type LanguageSignature struct {
+	Language          PredefinedSupportedLanguage `json:"language"`
+	FunctionSignature string                      `json:"function_signature"`      // The starter code
+	FunctionName      string                      `json:"function_name,omitempty"` // The class name for class questions, empty for programs
+	Parameters        []ParameterSignature        `json:"parameters"`              // The constructor parameters for class questions
+	ReturnType        string                      `json:"return_type,omitempty"`   // Empty for class questions and programs
+	UtilsImport       string                      `json:"utils_import,omitempty"`  // The ds_utils import snippet, empty when no data structure is used
+}
@@ -0,0 +1,70 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+)
+
+// QuestionStats are the statistics of the graded submissions of a question. Each submission updates the counters
+// and the acceptance rates and median runtime derived from them at once.
+type QuestionStats struct {
+	Attempts         int64                    `bson:"attempts" json:"attempts"`
+	Accepted         int64                    `bson:"accepted" json:"accepted"`
+	AcceptanceRate   float64                  `bson:"acceptance_rate" json:"acceptance_rate"`                         // Accepted / Attempts, 0 without attempts
+	UniqueSolvers    int64                    `bson:"unique_solvers" json:"unique_solvers"`                           // Users with an accepted submission, anonymous ones are not counted
+	MedianRuntimeMs  *int64                   `bson:"median_runtime_ms,omitempty" json:"median_runtime_ms,omitempty"` // Of the accepted submissions with a runtime, within 10%
+	Languages        map[string]LanguageStats `bson:"languages,omitempty" json:"languages,omitempty"`                 // By submission language
+	RuntimeHistogram map[string]int64         `bson:"runtime_histogram,omitempty" json:"-"`                           // Accepted submissions by RuntimeBucket
+}
+
+// LanguageStats are the statistics of the graded submissions of a question in one language
+type LanguageStats struct {
+	Attempts       int64   `bson:"attempts" json:"attempts"`
+	Accepted       int64   `bson:"accepted" json:"accepted"`
+	AcceptanceRate float64 `bson:"acceptance_rate" json:"acceptance_rate"`
+}
+
+// GradedSubmission is what the statistics keep of a submission graded against the test cases of a question
+type GradedSubmission struct {
+	Language  string
+	Accepted  bool
+	RuntimeMs *int64 // Time spent in the user's code, nil when the evaluator does not measure it
+	Solver    string // Empty for anonymous submissions
+}
+
+// RuntimeBucket rounds a runtime down to two significant digits, e.g. 1234 to 1200,
+// so a histogram of runtimes stays small and its median is within 10%
+func RuntimeBucket(runtimeMs int64) int64 {
+	if runtimeMs < 0 {
+		return 0
+	}
+	unit := int64(1)
+	for runtimeMs/unit >= 100 {
+		unit *= 10
+	}
+	return runtimeMs / unit * unit
+}
+
+// questionStats decodes QuestionStats without their legacy hooks
+type questionStats QuestionStats
+
+// UnmarshalJSON reads stats, or empty stats from the bare number questions had before, e.g. in older exports
+func (s *QuestionStats) UnmarshalJSON(data []byte) error {
+	if !bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		*s = QuestionStats{}
+		return nil
+	}
+	return json.Unmarshal(data, (*questionStats)(s))
+}
+
+// UnmarshalBSONValue reads stats, or empty stats from the bare number stored for questions created before
+func (s *QuestionStats) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	if t != bsontype.EmbeddedDocument {
+		*s = QuestionStats{}
+		return nil
+	}
+	return bson.Unmarshal(data, (*questionStats)(s))
+}
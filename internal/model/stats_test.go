@@ -0,0 +1,45 @@
+package model_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/model"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestRuntimeBucket(t *testing.T) {
+	cases := map[int64]int64{-5: 0, 0: 0, 7: 7, 99: 99, 100: 100, 1234: 1200, 98765: 98000}
+	for runtime, bucket := range cases {
+		if got := model.RuntimeBucket(runtime); got != bucket {
+			t.Errorf("%d: expected bucket %d, got %d", runtime, bucket, got)
+		}
+	}
+}
+
+func TestLegacyStats(t *testing.T) {
+	for _, data := range []string{`{"title": "Add", "stats": 0}`, `{"title": "Add", "stats": null}`} {
+		var question model.Question
+		if err := json.Unmarshal([]byte(data), &question); err != nil || question.Title != "Add" || question.Stats.Attempts != 0 {
+			t.Errorf("%s: expected empty stats, got %+v %v", data, question.Stats, err)
+		}
+	}
+	var question model.Question
+	if err := json.Unmarshal([]byte(`{"stats": {"attempts": 4, "accepted": 1}}`), &question); err != nil || question.Stats.Attempts != 4 || question.Stats.Accepted != 1 {
+		t.Errorf("expected the stats, got %+v %v", question.Stats, err)
+	}
+
+	for _, document := range []bson.M{{"title": "Add", "stats": int32(7)}, {"title": "Add", "stats": bson.M{"attempts": int64(4)}}} {
+		data, err := bson.Marshal(document)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var stored model.Question
+		if err := bson.Unmarshal(data, &stored); err != nil || stored.Title != "Add" {
+			t.Errorf("%v: expected the question to decode, got %+v %v", document, stored, err)
+		}
+		if _, legacy := document["stats"].(int32); legacy == (stored.Stats.Attempts != 0) {
+			t.Errorf("%v: expected a bare number to decode as empty stats, got %+v", document, stored.Stats)
+		}
+	}
+}
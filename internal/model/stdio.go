@@ -0,0 +1,45 @@
+package model
+
+// WhitespaceMode tells how the output of a standard-input/standard-output program is matched
+type WhitespaceMode string
+
+const (
+	ExactWhitespace    WhitespaceMode = "exact"    // Character for character
+	TrailingWhitespace WhitespaceMode = "trailing" // Trailing spaces and tabs of every line, and trailing blank lines, ignored
+	TokenWhitespace    WhitespaceMode = "tokens"   // Any run of whitespace, newlines included, matches any other
+)
+
+var WhitespaceModes = []WhitespaceMode{ExactWhitespace, TrailingWhitespace, TokenWhitespace}
+
+// StdioConfig describes a standard-input/standard-output question, where the user writes a full program.
+// Its test cases hold one parameter, the raw stdin text, and the raw expected stdout text as expected output;
+// unlike function questions, neither is JSON encoded.
+type StdioConfig struct {
+	Whitespace          WhitespaceMode `json:"whitespace,omitempty" bson:"whitespace,omitempty"`                       // Empty means TrailingWhitespace
+	KeepCarriageReturns bool           `json:"keep_carriage_returns,omitempty" bson:"keep_carriage_returns,omitempty"` // When false, \r\n and \r match \n
+	TimeLimit           float64        `json:"time_limit,omitempty" bson:"time_limit,omitempty"`                       // Seconds per test case, 0 means DefaultStdioTimeLimit
+}
+
+// StdioLanguages lists the languages standard-input/standard-output questions can be solved in
+var StdioLanguages = []PredefinedSupportedLanguage{Python, JavaScript}
+
+const (
+	DefaultStdioTimeLimit = 2.0  // Seconds per test case
+	MaxStdioTimeLimit     = 30.0 // Seconds per test case
+)
+
+// GetWhitespace returns the whitespace mode, defaulting to TrailingWhitespace.
+func (c *StdioConfig) GetWhitespace() WhitespaceMode {
+	if c == nil || c.Whitespace == "" {
+		return TrailingWhitespace
+	}
+	return c.Whitespace
+}
+
+// GetTimeLimit returns the time limit per test case, defaulting to DefaultStdioTimeLimit.
+func (c *StdioConfig) GetTimeLimit() float64 {
+	if c == nil || c.TimeLimit == 0 {
+		return DefaultStdioTimeLimit
+	}
+	return c.TimeLimit
+}
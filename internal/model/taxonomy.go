@@ -0,0 +1,71 @@
+package model
+
+import (
+	"sort"
+	"strings"
+)
+
+// Category is a category of the managed taxonomy. Categories with a parent are topics of it,
+// e.g. "Shortest Path" under "Graph", to any depth.
+type Category struct {
+	Name        string `bson:"_id" json:"name"`
+	Parent      string `bson:"parent,omitempty" json:"parent,omitempty"` // Empty for top-level categories
+	Description string `bson:"description,omitempty" json:"description,omitempty"`
+}
+
+// CategoryNode is a category of the taxonomy tree with the number of live questions in it
+type CategoryNode struct {
+	Category
+	Count    int64          `json:"count"` // Questions in the category itself
+	Total    int64          `json:"total"` // Questions in the category or any of its topics, each counted once
+	Children []CategoryNode `json:"children"`
+}
+
+// CategoryTree arranges categories under their parents, sorted by name, with their question counts.
+// totals counts the questions of each category including its topics, which counts cannot tell when
+// a question is in several topics of the same category.
+func CategoryTree(categories []Category, counts, totals map[string]int64) []CategoryNode {
+	children := map[string][]Category{}
+	for _, category := range categories {
+		children[category.Parent] = append(children[category.Parent], category)
+	}
+	var build func(parent string) []CategoryNode
+	build = func(parent string) []CategoryNode {
+		nodes := []CategoryNode{}
+		for _, category := range children[parent] {
+			nodes = append(nodes, CategoryNode{
+				Category: category,
+				Count:    counts[category.Name],
+				Total:    totals[category.Name],
+				Children: build(category.Name),
+			})
+		}
+		sort.Slice(nodes, func(i, j int) bool { return nodes[i].Name < nodes[j].Name })
+		return nodes
+	}
+	return build("")
+}
+
+// CategoryDescendants returns name and the names of all its topics, at any depth
+func CategoryDescendants(categories []Category, name string) []string {
+	descendants := []string{name}
+	for i := 0; i < len(descendants); i++ {
+		for _, category := range categories {
+			if category.Parent == descendants[i] {
+				descendants = append(descendants, category.Name)
+			}
+		}
+	}
+	return descendants
+}
+
+// FindCategory returns the category of the taxonomy named name, ignoring case and surrounding spaces
+func FindCategory(categories []Category, name string) (Category, bool) {
+	name = strings.TrimSpace(name)
+	for _, category := range categories {
+		if strings.EqualFold(category.Name, name) {
+			return category, true
+		}
+	}
+	return Category{}, false
+}
@@ -0,0 +1,93 @@
+package model_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/model"
+)
+
+var taxonomy = []model.Category{
+	{Name: "Graph"},
+	{Name: "Shortest Path", Parent: "Graph"},
+	{Name: "Dijkstra", Parent: "Shortest Path"},
+	{Name: "Array"},
+	{Name: "BFS", Parent: "Graph"},
+}
+
+func TestCategoryDescendants(t *testing.T) {
+	if descendants := model.CategoryDescendants(taxonomy, "Graph"); !reflect.DeepEqual(descendants, []string{"Graph", "Shortest Path", "BFS", "Dijkstra"}) {
+		t.Errorf("expected Graph and all its topics, got %v", descendants)
+	}
+	if descendants := model.CategoryDescendants(taxonomy, "Unknown"); !reflect.DeepEqual(descendants, []string{"Unknown"}) {
+		t.Errorf("expected an unknown category alone, got %v", descendants)
+	}
+}
+
+func TestCategoryTree(t *testing.T) {
+	tree := model.CategoryTree(taxonomy, map[string]int64{"Graph": 1, "Dijkstra": 2}, map[string]int64{"Graph": 3})
+	if len(tree) != 2 || tree[0].Name != "Array" || tree[1].Name != "Graph" {
+		t.Fatalf("expected the top-level categories sorted by name, got %+v", tree)
+	}
+	graph := tree[1]
+	if graph.Count != 1 || graph.Total != 3 {
+		t.Errorf("expected the counts of Graph, got %d and %d", graph.Count, graph.Total)
+	}
+	if len(graph.Children) != 2 || graph.Children[0].Name != "BFS" || graph.Children[1].Children[0].Name != "Dijkstra" {
+		t.Errorf("expected the topics under their parents, got %+v", graph.Children)
+	}
+	if tree[0].Children == nil {
+		t.Errorf("expected an empty list of topics, not null")
+	}
+}
+
+func TestNormalizeTaxonomy(t *testing.T) {
+	question := model.Question{
+		Category:   "Array",
+		Categories: []string{" Graph ", "graph", "", "Shortest  Path"},
+		Tags:       []string{"dp", "DP", " greedy"},
+	}
+	question.NormalizeTaxonomy()
+	if question.Category != "Graph" || !reflect.DeepEqual(question.Categories, []string{"Graph", "Shortest Path"}) {
+		t.Errorf("expected the categories trimmed and deduplicated, the first as category, got %q %v", question.Category, question.Categories)
+	}
+	if !reflect.DeepEqual(question.Tags, []string{"dp", "greedy"}) || question.Companies != nil {
+		t.Errorf("expected the tags deduplicated and no companies, got %v %v", question.Tags, question.Companies)
+	}
+
+	legacy := model.Question{Category: "Tree"}
+	legacy.NormalizeTaxonomy()
+	if !reflect.DeepEqual(legacy.Categories, []string{"Tree"}) {
+		t.Errorf("expected the category alone as categories, got %v", legacy.Categories)
+	}
+}
+
+func TestFindCategory(t *testing.T) {
+	if category, found := model.FindCategory(taxonomy, " shortest PATH "); !found || category.Name != "Shortest Path" {
+		t.Errorf("expected the category whatever its case, got %+v %v", category, found)
+	}
+	if _, found := model.FindCategory(taxonomy, "Shortest"); found {
+		t.Errorf("expected only whole names to match")
+	}
+}
+
+func TestKeepCategoryChange(t *testing.T) {
+	stored := model.Question{Category: "Array", Categories: []string{"Array", "Graph"}}
+	cases := []struct {
+		category   string
+		categories []string
+		expected   []string
+	}{
+		{"Tree", []string{"Array", "Graph"}, []string{"Tree", "Graph"}},   // Replaces the first
+		{"graph", []string{"Array", "Graph"}, []string{"graph", "Array"}}, // Moves first
+		{"ARRAY", []string{"Array", "Graph"}, []string{"Array", "Graph"}}, // Unchanged but for its case
+		{"Tree", []string{"Array", "Tree"}, []string{"Array", "Tree"}},    // The categories changed too and win
+	}
+	for _, c := range cases {
+		question := model.Question{Category: c.category, Categories: c.categories}
+		question.KeepCategoryChange(&stored)
+		if !reflect.DeepEqual(question.Categories, c.expected) {
+			t.Errorf("%q %v: expected %v, got %v", c.category, c.categories, c.expected, question.Categories)
+		}
+	}
+}
@@ -3,27 +3,40 @@ package parser_validator
 import (
 	"encoding/json"
 	"fmt"
-	"regexp"
+	"strings"
 
 	"github.com/TehilaTheStudent/SkillCode-backend/internal/model"
 )
 
-// Validators for atomic types
-var (
-	integerRegex = regexp.MustCompile(`^-?\d+$`)
-	doubleRegex  = regexp.MustCompile(`^-?\d+(\.\d+)?$`)
-	stringRegex  = regexp.MustCompile(`^[^\[\],]+$`)
-)
+// isIntegerLiteral matches -?digits
+func isIntegerLiteral(input string) bool {
+	input = strings.TrimPrefix(input, "-")
+	if input == "" {
+		return false
+	}
+	for i := 0; i < len(input); i++ {
+		if input[i] < '0' || input[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// isDoubleLiteral matches -?digits or -?digits.digits
+func isDoubleLiteral(input string) bool {
+	whole, fraction, hasFraction := strings.Cut(input, ".")
+	return isIntegerLiteral(whole) && (!hasFraction || (fraction != "" && fraction[0] != '-' && isIntegerLiteral(fraction)))
+}
 
 // ValidateAtomicType validates an atomic type
 func ValidateAtomicType(input string, atomicType string) error {
 	switch model.AtomicType(atomicType) {
 	case model.Integer:
-		if !integerRegex.MatchString(input) {
+		if !isIntegerLiteral(input) {
 			return fmt.Errorf("invalid Integer: %s not all digits", input)
 		}
 	case model.Double:
-		if !doubleRegex.MatchString(input) {
+		if !isDoubleLiteral(input) {
 			return fmt.Errorf("invalid Double: %s not all digits or digits.digits", input)
 		}
 	case model.Boolean:
@@ -31,110 +44,142 @@ func ValidateAtomicType(input string, atomicType string) error {
 			return fmt.Errorf("invalid Boolean: %s not true or false", input)
 		}
 	case model.String:
-		if !stringRegex.MatchString(input) {
-			return fmt.Errorf("invalid String: %s contains special characters: [ ] ,", input)
+		// Strings are JSON string literals: quoted, with \" \\ \n \uXXXX escapes
+		var value string
+		if err := json.Unmarshal([]byte(input), &value); err != nil {
+			return fmt.Errorf("invalid String: %s is not a JSON string literal (e.g. \"abc\")", input)
 		}
-		return nil
 	default:
 		return fmt.Errorf("unknown atomic type: %s", atomicType)
 	}
 	return nil
 }
 
-func ValidateCompositeType(input string, abstractType *model.AbstractType) error {
-	var parsed interface{}
+// issueCollector gathers every validation issue instead of stopping at the first one
+type issueCollector struct {
+	issues []model.ValidationIssue
+}
 
-	// Parse the input as JSON
-	if err := json.Unmarshal([]byte(input), &parsed); err != nil {
-		return fmt.Errorf("invalid composite type: %s", err)
+func (c *issueCollector) add(location *location, expected *model.AbstractType, value interface{}, format string, args ...interface{}) {
+	issue := model.ValidationIssue{
+		Location: location.String(),
+		Message:  fmt.Sprintf(format, args...),
+	}
+	if expected != nil {
+		issue.Expected = expected.ToPrint()
 	}
+	if value != nil {
+		issue.Value = toJSON(value)
+	}
+	c.issues = append(c.issues, issue)
+}
 
-	switch model.CompositeType(abstractType.Type) {
-	case model.Array, model.ListNode, model.TreeNode:
-		array, ok := parsed.([]interface{})
-		if !ok {
-			return fmt.Errorf("expected array, got: %T", parsed)
-		}
-		for _, elem := range array {
-			elemJSON, err := json.Marshal(elem)
-			if err != nil {
-				return fmt.Errorf("failed to marshal element: %v", err)
-			}
-			if err := ValidateAbstractType(string(elemJSON), abstractType.TypeChildren); err != nil {
-				return err
-			}
-		}
-	case model.Matrix:
-		matrix, ok := parsed.([]interface{})
-		if !ok {
-			return fmt.Errorf("expected 2D array for Matrix, got: %T", parsed)
-		}
-		// Check all rows are arrays of the same length
-		var rowLength int
-		for i, row := range matrix {
-			rowArray, ok := row.([]interface{})
-			if !ok {
-				return fmt.Errorf("matrix row %d is not an array", i)
-			}
-			if i == 0 {
-				rowLength = len(rowArray)
-			} else if len(rowArray) != rowLength {
-				return fmt.Errorf("matrix rows have inconsistent lengths")
-			}
-			for _, elem := range rowArray {
-				elemJSON, err := json.Marshal(elem)
-				if err != nil {
-					return fmt.Errorf("failed to marshal element: %v", err)
-				}
-				if err := ValidateAbstractType(string(elemJSON), abstractType.TypeChildren); err != nil {
-					return err
-				}
-			}
-		}
-	case model.Graph:
-		graph, ok := parsed.([]interface{})
-		if !ok {
-			return fmt.Errorf("expected array of pairs for Graph, got: %T", parsed)
-		}
-		for _, edge := range graph {
-			pair, ok := edge.([]interface{})
-			if !ok || len(pair) != 2 {
-				return fmt.Errorf("invalid edge: %v", edge)
-			}
-			for _, node := range pair {
-				nodeJSON, err := json.Marshal(node)
-				if err != nil {
-					return fmt.Errorf("failed to marshal node: %v", err)
-				}
-				if err := ValidateAbstractType(string(nodeJSON), abstractType.TypeChildren); err != nil {
-					return err
-				}
-			}
-		}
-	default:
-		return fmt.Errorf("unknown composite type: %s", abstractType.Type)
+// rawInput marks a value that is still the unparsed input text
+type rawInput string
+
+// toJSON renders a parsed value back to JSON for error reporting
+func toJSON(value interface{}) string {
+	if text, ok := value.(rawInput); ok {
+		return string(text)
 	}
-	return nil
+	valueJSON, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(valueJSON)
+}
+
+// location is a JSON path built lazily, formatting it for every element would dominate parsing
+type location struct {
+	parent *location
+	index  int
+	root   string
+}
+
+func rootLocation(root string) *location {
+	return &location{root: root}
+}
+
+func elementLocation(parent *location, index int) *location {
+	return &location{parent: parent, index: index}
+}
+
+// String renders the path, e.g. test_cases[3].parameters[1][2][0]
+func (l *location) String() string {
+	if l.parent == nil {
+		return l.root
+	}
+	return fmt.Sprintf("%s[%d]", l.parent.String(), l.index)
+}
+
+// ValidateCompositeType validates a composite type
+func ValidateCompositeType(input string, abstractType *model.AbstractType) error {
+	_, err := Parse(input, abstractType)
+	return err
 }
 
 // ValidateAbstractType validates an abstract type (atomic or composite)
 func ValidateAbstractType(input string, abstractType *model.AbstractType) error {
-	if abstractType.TypeChildren == nil {
-		// Atomic type
-		// fmt.Println("is " + input + " = " + abstractType.ToPrint())
-		err := ValidateAtomicType(input, abstractType.Type)
-		if err != nil {
-			// fmt.Println("error " + err.Error())
-			return err
+	_, err := Parse(input, abstractType)
+	return err
+}
+
+// CollectValidationIssues validates input against abstractType and returns every issue found,
+// each located relative to location (e.g. "test_cases[3].parameters[1]")
+func CollectValidationIssues(input string, abstractType *model.AbstractType, location string) []model.ValidationIssue {
+	collector := &issueCollector{}
+	collector.parseInput(input, abstractType, rootLocation(location))
+	return collector.issues
+}
+
+// validateGraphType checks the Graph variant settings themselves
+func validateGraphType(abstractType *model.AbstractType) error {
+	switch abstractType.GetGraphFormat() {
+	case model.EdgeList:
+	case model.AdjacencyList:
+		if abstractType.TypeChildren.Type != string(model.Integer) {
+			return fmt.Errorf("adjacency list Graph nodes must be Integer indexes, got: %s", abstractType.TypeChildren.ToPrint())
 		}
-	} else {
-		// Composite type
-		// fmt.Println("is " + input + " = " + abstractType.ToPrint())
-		err := ValidateCompositeType(input, abstractType)
-		if err != nil {
-			// fmt.Println("error " + err.Error())
-			return err
+	default:
+		return fmt.Errorf("unknown graph format: %s", abstractType.Format)
+	}
+	if abstractType.WeightType != nil {
+		switch model.AtomicType(abstractType.WeightType.Type) {
+		case model.Integer, model.Double:
+		default:
+			return fmt.Errorf("graph weight must be Integer or Double, got: %s", abstractType.WeightType.ToPrint())
 		}
 	}
 	return nil
 }
+
+// ValidateAbstractTypeDefinition checks that abstractType itself is well formed:
+// known type names, children exactly on composite types, valid Graph settings
+func ValidateAbstractTypeDefinition(abstractType *model.AbstractType) error {
+	if abstractType == nil {
+		return fmt.Errorf("type cannot be null")
+	}
+	for _, atomicType := range model.AtomicTypes {
+		if abstractType.Type == string(atomicType) {
+			if abstractType.TypeChildren != nil {
+				return fmt.Errorf("atomic type %s cannot have type children", abstractType.Type)
+			}
+			return nil
+		}
+	}
+	for _, compositeType := range model.CompositeTypes {
+		if abstractType.Type == string(compositeType) {
+			if abstractType.TypeChildren == nil {
+				return fmt.Errorf("composite type %s requires type children", abstractType.Type)
+			}
+			if err := ValidateAbstractTypeDefinition(abstractType.TypeChildren); err != nil {
+				return err
+			}
+			if compositeType == model.Graph {
+				return validateGraphType(abstractType)
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown type: %s", abstractType.Type)
+}
@@ -0,0 +1,91 @@
+package parser_validator
+
+import (
+	"fmt"
+
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/model"
+)
+
+// ValidateComparator checks that comparator suits returnType and, for custom comparators,
+// that every language of the question has compare code
+func ValidateComparator(comparator *model.Comparator, returnType *model.AbstractType, languages []string) error {
+	if comparator == nil {
+		return nil
+	}
+	if comparator.Tolerance != nil {
+		if comparator.GetType() != model.FloatToleranceComparator {
+			return fmt.Errorf("tolerance is only used by the %s comparator", model.FloatToleranceComparator)
+		}
+		if *comparator.Tolerance <= 0 {
+			return fmt.Errorf("tolerance must be positive")
+		}
+	}
+	if len(comparator.Code) > 0 && comparator.GetType() != model.CustomComparator {
+		return fmt.Errorf("code is only used by the %s comparator", model.CustomComparator)
+	}
+
+	switch comparator.GetType() {
+	case model.ExactComparator:
+	case model.UnorderedComparator, model.UnorderedNestedComparator:
+		if returnType == nil || !isListType(returnType) {
+			return fmt.Errorf("the %s comparator needs a list return type (Array, ListNode or Matrix), got: %s", comparator.GetType(), printType(returnType))
+		}
+	case model.SetOfSetsComparator:
+		if returnType == nil || !isListType(returnType) || returnType.Type == string(model.ListNode) ||
+			(returnType.Type == string(model.Array) && !isListType(returnType.TypeChildren)) {
+			return fmt.Errorf("the %s comparator needs a list of lists return type (Array < Array > or Matrix), got: %s", comparator.GetType(), printType(returnType))
+		}
+	case model.FloatToleranceComparator:
+		if returnType == nil || !containsDouble(returnType) {
+			return fmt.Errorf("the %s comparator needs a return type with Double values, got: %s", comparator.GetType(), printType(returnType))
+		}
+	case model.CustomComparator:
+		for language := range comparator.Code {
+			if model.GetFileExtension(language) == "" {
+				return fmt.Errorf("unsupported comparator language: %s", language)
+			}
+		}
+		required := languages
+		if len(required) == 0 {
+			for _, language := range model.PredefinedSupportedLanguages {
+				required = append(required, string(language))
+			}
+		}
+		for _, language := range required {
+			if comparator.Code[model.PredefinedSupportedLanguage(language)] == "" {
+				return fmt.Errorf("the %s comparator needs compare code for %s", comparator.GetType(), language)
+			}
+		}
+	default:
+		return fmt.Errorf("unknown comparator type: %s", comparator.Type)
+	}
+	return nil
+}
+
+func isListType(abstractType *model.AbstractType) bool {
+	if abstractType == nil || abstractType.TypeChildren == nil {
+		return false
+	}
+	switch model.CompositeType(abstractType.Type) {
+	case model.Array, model.ListNode, model.Matrix:
+		return true
+	}
+	return false
+}
+
+func containsDouble(abstractType *model.AbstractType) bool {
+	if abstractType == nil {
+		return false
+	}
+	if abstractType.Type == string(model.Double) {
+		return true
+	}
+	return containsDouble(abstractType.TypeChildren) || containsDouble(abstractType.WeightType)
+}
+
+func printType(abstractType *model.AbstractType) string {
+	if abstractType == nil {
+		return "none"
+	}
+	return abstractType.ToPrint()
+}
@@ -0,0 +1,251 @@
+package parser_validator
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/model"
+)
+
+// ValidateParameterValue validates input against the parameter type, then against its constraints.
+// Every issue is located relative to location (e.g. "test_cases[3].parameters[1]").
+func ValidateParameterValue(input string, parameter model.Parameter, location string) []model.ValidationIssue {
+	collector := &issueCollector{}
+	value := collector.parseInput(input, &parameter.ParamType, rootLocation(location))
+	if len(collector.issues) > 0 || parameter.Constraints == nil {
+		return collector.issues
+	}
+	collector.checkConstraints(value, parameter.Constraints, rootLocation(location))
+	return collector.issues
+}
+
+// CheckConstraints reports every way value breaks constraints
+func CheckConstraints(value *Value, constraints *model.Constraints, location string) []model.ValidationIssue {
+	collector := &issueCollector{}
+	if constraints != nil {
+		collector.checkConstraints(value, constraints, rootLocation(location))
+	}
+	return collector.issues
+}
+
+func (c *issueCollector) checkConstraints(value *Value, constraints *model.Constraints, location *location) {
+	if value.Type.TypeChildren == nil {
+		c.checkAtomic(value, constraints, location)
+		if value.Type.Type == string(model.String) {
+			c.checkLength(constraints, utf8.RuneCountInString(value.String), location)
+			if constraints.Unique && !uniqueRunes(value.String) {
+				c.add(location, nil, value.String, "characters must be unique")
+			}
+		}
+		return
+	}
+
+	switch model.CompositeType(value.Type.Type) {
+	case model.Array, model.ListNode:
+		c.checkLength(constraints, len(value.Elements), location)
+		c.checkElements(value.Elements, constraints, location)
+	case model.TreeNode:
+		nodes := 0
+		for _, element := range value.Elements {
+			if !element.Null {
+				nodes++
+			}
+		}
+		c.checkLength(constraints, nodes, location)
+		c.checkElements(value.Elements, constraints, location)
+		height := treeHeight(value.Elements)
+		if constraints.MinHeight != nil && height < *constraints.MinHeight {
+			c.add(location, nil, nil, "tree height %d is below the minimum %d", height, *constraints.MinHeight)
+		}
+		if constraints.MaxHeight != nil && height > *constraints.MaxHeight {
+			c.add(location, nil, nil, "tree height %d is above the maximum %d", height, *constraints.MaxHeight)
+		}
+	case model.Matrix:
+		c.checkLength(constraints, len(value.Elements), location)
+		for i, row := range value.Elements {
+			rowLocation := elementLocation(location, i)
+			c.checkLength(constraints, len(row.Elements), rowLocation)
+			for j, cell := range row.Elements {
+				c.checkAtomic(cell, constraints, elementLocation(rowLocation, j))
+			}
+		}
+		if constraints.Unique {
+			var cells []*Value
+			for _, row := range value.Elements {
+				cells = append(cells, row.Elements...)
+			}
+			if duplicate := firstDuplicate(cells); duplicate != "" {
+				c.add(location, nil, nil, "elements must be unique, %s is repeated", duplicate)
+			}
+		}
+	case model.Graph:
+		c.checkGraph(value, constraints, location)
+	}
+}
+
+// checkElements checks every element of an Array, ListNode or TreeNode, and their uniqueness
+func (c *issueCollector) checkElements(elements []*Value, constraints *model.Constraints, location *location) {
+	var present []*Value
+	for i, element := range elements {
+		if element.Null {
+			continue
+		}
+		present = append(present, element)
+		elementConstraints := *constraints
+		// Length and uniqueness describe the outer value, only value ranges and charset reach the elements
+		elementConstraints.MinLength, elementConstraints.MaxLength, elementConstraints.Unique = nil, nil, false
+		c.checkConstraints(element, &elementConstraints, elementLocation(location, i))
+	}
+	if constraints.Unique {
+		if duplicate := firstDuplicate(present); duplicate != "" {
+			c.add(location, nil, nil, "elements must be unique, %s is repeated", duplicate)
+		}
+	}
+}
+
+func (c *issueCollector) checkGraph(value *Value, constraints *model.Constraints, location *location) {
+	abstractType := value.Type
+	adjacencyList := abstractType.GetGraphFormat() == model.AdjacencyList
+
+	nodes := value.NodeCount
+	if !adjacencyList {
+		distinct := map[string]bool{}
+		for _, edge := range value.Edges {
+			distinct[edge.From.Format()], distinct[edge.To.Format()] = true, true
+		}
+		nodes = len(distinct)
+	}
+	if constraints.MinNodes != nil && nodes < *constraints.MinNodes {
+		c.add(location, nil, nil, "node count %d is below the minimum %d", nodes, *constraints.MinNodes)
+	}
+	if constraints.MaxNodes != nil && nodes > *constraints.MaxNodes {
+		c.add(location, nil, nil, "node count %d is above the maximum %d", nodes, *constraints.MaxNodes)
+	}
+
+	// Undirected adjacency lists list every edge from both ends
+	edges := make([]Edge, 0, len(value.Edges))
+	for _, edge := range value.Edges {
+		if adjacencyList && !abstractType.IsDirected() && edge.From.Integer > edge.To.Integer {
+			continue
+		}
+		edges = append(edges, edge)
+	}
+	c.checkLength(constraints, len(edges), location)
+
+	// Value ranges bound the weights, nodes are bounded by the node count
+	nodeConstraints := &model.Constraints{Charset: constraints.Charset}
+	seen := map[string]bool{}
+	for _, edge := range edges {
+		c.checkAtomic(edge.From, nodeConstraints, location)
+		c.checkAtomic(edge.To, nodeConstraints, location)
+		if edge.Weight != nil {
+			c.checkAtomic(edge.Weight, constraints, location)
+		}
+		if constraints.Unique {
+			from, to := edge.From.Format(), edge.To.Format()
+			if !abstractType.IsDirected() && to < from {
+				from, to = to, from
+			}
+			key := from + "," + to
+			if seen[key] {
+				c.add(location, nil, nil, "edges must be unique, [%s] is repeated", key)
+			}
+			seen[key] = true
+		}
+	}
+}
+
+// checkAtomic checks the value range and charset of an atomic value, composite values are skipped
+func (c *issueCollector) checkAtomic(value *Value, constraints *model.Constraints, location *location) {
+	if value == nil || value.Type.TypeChildren != nil {
+		return
+	}
+	var number float64
+	switch model.AtomicType(value.Type.Type) {
+	case model.Integer:
+		number = float64(value.Integer)
+	case model.Double:
+		number = value.Double
+	case model.String:
+		if constraints.Charset != "" {
+			for _, r := range value.String {
+				if !strings.ContainsRune(constraints.Charset, r) {
+					c.add(location, nil, value.String, "character %q is not in %q", r, constraints.Charset)
+					break
+				}
+			}
+		}
+		return
+	default:
+		return
+	}
+	if constraints.MinValue != nil && number < *constraints.MinValue {
+		c.add(location, nil, value.Interface(), "value must be >= %s", formatFloat(*constraints.MinValue))
+	}
+	if constraints.MaxValue != nil && number > *constraints.MaxValue {
+		c.add(location, nil, value.Interface(), "value must be <= %s", formatFloat(*constraints.MaxValue))
+	}
+}
+
+func (c *issueCollector) checkLength(constraints *model.Constraints, length int, location *location) {
+	if constraints.MinLength != nil && length < *constraints.MinLength {
+		c.add(location, nil, nil, "length %d is below the minimum %d", length, *constraints.MinLength)
+	}
+	if constraints.MaxLength != nil && length > *constraints.MaxLength {
+		c.add(location, nil, nil, "length %d is above the maximum %d", length, *constraints.MaxLength)
+	}
+}
+
+// treeHeight counts the levels of a level-order tree with null holes
+func treeHeight(elements []*Value) int {
+	if len(elements) == 0 || elements[0].Null {
+		return 0
+	}
+	// depths[k] is the depth of the k-th present node, slot i holds a child of present node (i-1)/2
+	depths := []int{1}
+	height := 1
+	for i := 1; i < len(elements); i++ {
+		parent := (i - 1) / 2
+		if parent >= len(depths) {
+			break
+		}
+		if elements[i].Null {
+			continue
+		}
+		depth := depths[parent] + 1
+		depths = append(depths, depth)
+		if depth > height {
+			height = depth
+		}
+	}
+	return height
+}
+
+// firstDuplicate returns the first repeated value in canonical form, empty when all differ
+func firstDuplicate(values []*Value) string {
+	seen := map[string]bool{}
+	for _, value := range values {
+		key := value.Format()
+		if seen[key] {
+			return key
+		}
+		seen[key] = true
+	}
+	return ""
+}
+
+func uniqueRunes(text string) bool {
+	seen := map[rune]bool{}
+	for _, r := range text {
+		if seen[r] {
+			return false
+		}
+		seen[r] = true
+	}
+	return true
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
@@ -1,8 +1,10 @@
 package parser_validator
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"strings"
 
 	"github.com/TehilaTheStudent/SkillCode-backend/internal/model"
@@ -22,7 +24,7 @@ func GenerateValidString(abstractType *model.AbstractType) string {
 	case string(model.Boolean):
 		return "false"
 	case string(model.String):
-		return `"str"`
+		return EncodeString("str")
 	case string(model.Integer):
 		return "1"
 	case string(model.Double):
@@ -49,40 +51,97 @@ func GenerateValidString(abstractType *model.AbstractType) string {
 		}
 		return fmt.Sprintf("[%s]", joinStrings(rowValues))
 	case string(model.Graph):
+		if abstractType.GetGraphFormat() == model.AdjacencyList {
+			return generateValidAdjacencyList(abstractType)
+		}
 		numEdges := numElements
 		edgeValues := make([]string, numEdges)
 		childValue := GenerateValidString(abstractType.TypeChildren)
 		for i := 0; i < numEdges; i++ {
-			edgeValues[i] = fmt.Sprintf("[%s, %s]", childValue, childValue)
+			if abstractType.IsWeighted() {
+				edgeValues[i] = fmt.Sprintf("[%s, %s, %s]", childValue, childValue, GenerateValidString(abstractType.WeightType))
+			} else {
+				edgeValues[i] = fmt.Sprintf("[%s, %s]", childValue, childValue)
+			}
 		}
 		return fmt.Sprintf("[%s]", joinStrings(edgeValues))
 	}
 	return ""
 }
 
+// generateValidAdjacencyList links every node to the next one: [[1], [0]]
+func generateValidAdjacencyList(abstractType *model.AbstractType) string {
+	rows := make([]string, numElements)
+	for u := 0; u < numElements; u++ {
+		neighbor := fmt.Sprintf("%d", (u+1)%numElements)
+		if abstractType.IsWeighted() {
+			neighbor = fmt.Sprintf("[%s, %s]", neighbor, GenerateValidString(abstractType.WeightType))
+		}
+		rows[u] = fmt.Sprintf("[%s]", neighbor)
+	}
+	return fmt.Sprintf("[%s]", joinStrings(rows))
+}
+
+// EncodeString returns the JSON string literal for value, the form Strings take in test cases
+func EncodeString(value string) string {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return `""`
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// DecodeString returns the value of a JSON string literal
+func DecodeString(input string) (string, error) {
+	var value string
+	if err := json.Unmarshal([]byte(input), &value); err != nil {
+		return "", fmt.Errorf("failed to parse string %s: %w", input, err)
+	}
+	return value, nil
+}
 
+// ReformatStringOfType rewrites a JSON value canonically, with a space after each separating comma.
+// Commas inside string literals are left untouched, and numbers keep their digits (e.g. integers above 2^53).
 func ReformatStringOfType(input string) (string, error) {
 	var parsed interface{}
 
 	// Parse the input string as JSON
-	if err := json.Unmarshal([]byte(input), &parsed); err != nil {
+	decoder := json.NewDecoder(strings.NewReader(input))
+	decoder.UseNumber()
+	if err := decoder.Decode(&parsed); err != nil {
 		return "", fmt.Errorf("failed to parse input: %w", err)
 	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return "", fmt.Errorf("failed to parse input: unexpected data after the value")
+	}
 
 	// Reformat into a clean JSON string
-	formatted, err := json.Marshal(parsed)
-	if err != nil {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(parsed); err != nil {
 		return "", fmt.Errorf("failed to format input: %w", err)
 	}
+	formatted := bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
 
-	// Iterate over the formatted string and add a space after each comma
-	result := ""
-	for i := 0; i < len(formatted); i++ {
-		result += string(formatted[i])
-		if formatted[i] == ',' {
-			result += " "
+	// Iterate over the formatted string and add a space after each comma outside of strings
+	var result strings.Builder
+	inString, escaped := false, false
+	for _, c := range formatted {
+		result.WriteByte(c)
+		switch {
+		case escaped:
+			escaped = false
+		case inString && c == '\\':
+			escaped = true
+		case c == '"':
+			inString = !inString
+		case !inString && c == ',':
+			result.WriteByte(' ')
 		}
 	}
 
-	return result, nil
+	return result.String(), nil
 }
@@ -0,0 +1,156 @@
+package parser_validator
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/model"
+)
+
+// ParseOperationSequence splits a class-design test case into its operations, the arguments and the output of each
+func ParseOperationSequence(inputOutput model.InputOutput) (*model.OperationSequence, error) {
+	if len(inputOutput.Parameters) != 2 {
+		return nil, fmt.Errorf("a class-design test case has 2 parameters, the operations and their arguments, got %d", len(inputOutput.Parameters))
+	}
+	var sequence model.OperationSequence
+	if err := json.Unmarshal([]byte(inputOutput.Parameters[0]), &sequence.Operations); err != nil {
+		return nil, fmt.Errorf("operations must be a JSON array of strings: %v", err)
+	}
+	if len(sequence.Operations) == 0 {
+		return nil, fmt.Errorf("operations cannot be empty, the first one is the constructor")
+	}
+
+	var arguments []json.RawMessage
+	if err := json.Unmarshal([]byte(inputOutput.Parameters[1]), &arguments); err != nil {
+		return nil, fmt.Errorf("arguments must be a JSON array of arrays: %v", err)
+	}
+	if len(arguments) != len(sequence.Operations) {
+		return nil, fmt.Errorf("got %d argument lists for %d operations", len(arguments), len(sequence.Operations))
+	}
+	sequence.Arguments = make([][]string, len(arguments))
+	for i, raw := range arguments {
+		var callArguments []json.RawMessage
+		if err := json.Unmarshal(raw, &callArguments); err != nil {
+			return nil, fmt.Errorf("arguments of operation %d must be a JSON array: %v", i, err)
+		}
+		sequence.Arguments[i] = make([]string, len(callArguments))
+		for j, argument := range callArguments {
+			sequence.Arguments[i][j] = string(argument)
+		}
+	}
+
+	var outputs []json.RawMessage
+	if err := json.Unmarshal([]byte(inputOutput.ExpectedOutput), &outputs); err != nil {
+		return nil, fmt.Errorf("expected output must be a JSON array with the output of each operation: %v", err)
+	}
+	if len(outputs) != len(sequence.Operations) {
+		return nil, fmt.Errorf("got %d outputs for %d operations", len(outputs), len(sequence.Operations))
+	}
+	sequence.Outputs = make([]string, len(outputs))
+	for i, output := range outputs {
+		sequence.Outputs[i] = string(output)
+	}
+	return &sequence, nil
+}
+
+// ValidateOperationSequence validates a class-design test case against classConfig: the operations must
+// start with the constructor and call existing methods, with arguments and outputs of the declared types.
+// Every issue is located relative to location (e.g. "test_cases[3]").
+func ValidateOperationSequence(inputOutput model.InputOutput, classConfig *model.ClassConfig, location string) []model.ValidationIssue {
+	sequence, err := ParseOperationSequence(inputOutput)
+	if err != nil {
+		return []model.ValidationIssue{{Location: location, Message: err.Error()}}
+	}
+
+	var issues []model.ValidationIssue
+	for i, operation := range sequence.Operations {
+		operationLocation := fmt.Sprintf("%s.parameters[0][%d]", location, i)
+		argumentsLocation := fmt.Sprintf("%s.parameters[1][%d]", location, i)
+		outputLocation := fmt.Sprintf("%s.expected_output[%d]", location, i)
+
+		var parameters []model.Parameter
+		var returnType *model.AbstractType
+		if i == 0 {
+			if operation != classConfig.Name {
+				issues = append(issues, model.ValidationIssue{Location: operationLocation, Expected: classConfig.Name, Value: operation, Message: "the first operation must construct the class"})
+				continue
+			}
+			parameters = classConfig.ConstructorParameters()
+		} else {
+			method := classConfig.GetMethod(operation)
+			if method == nil {
+				issues = append(issues, model.ValidationIssue{Location: operationLocation, Value: operation, Message: "unknown method"})
+				continue
+			}
+			if method.Parameters != nil {
+				parameters = *method.Parameters
+			}
+			returnType = method.ReturnType
+		}
+
+		if len(sequence.Arguments[i]) != len(parameters) {
+			issues = append(issues, model.ValidationIssue{Location: argumentsLocation, Message: fmt.Sprintf("%s takes %d arguments, got %d", operation, len(parameters), len(sequence.Arguments[i]))})
+		} else {
+			for j, parameter := range parameters {
+				issues = append(issues, ValidateParameterValue(sequence.Arguments[i][j], parameter, fmt.Sprintf("%s[%d]", argumentsLocation, j))...)
+			}
+		}
+
+		if returnType == nil {
+			if sequence.Outputs[i] != "null" {
+				issues = append(issues, model.ValidationIssue{Location: outputLocation, Value: sequence.Outputs[i], Message: fmt.Sprintf("%s returns nothing, its output must be null", operation)})
+			}
+			continue
+		}
+		issues = append(issues, CollectValidationIssues(sequence.Outputs[i], returnType, outputLocation)...)
+	}
+	return issues
+}
+
+// ValidateClassConfig checks the class and method names and every parameter and return type of classConfig
+func ValidateClassConfig(classConfig *model.ClassConfig) []model.ValidationIssue {
+	if classConfig == nil {
+		return []model.ValidationIssue{{Location: "class_config", Message: "class configuration cannot be null for a class question"}}
+	}
+	var issues []model.ValidationIssue
+	if classConfig.Name == "" {
+		issues = append(issues, model.ValidationIssue{Location: "class_config.name", Message: "class name cannot be empty"})
+	}
+	issues = append(issues, validateParameterDefinitions(classConfig.ConstructorParameters(), "class_config.constructor")...)
+	if len(classConfig.Methods) == 0 {
+		issues = append(issues, model.ValidationIssue{Location: "class_config.methods", Message: "a class needs at least one method"})
+	}
+	names := map[string]bool{classConfig.Name: true}
+	for i, method := range classConfig.Methods {
+		location := fmt.Sprintf("class_config.methods[%d]", i)
+		if names[method.Name] {
+			issues = append(issues, model.ValidationIssue{Location: location + ".name", Value: method.Name, Message: "duplicate method name"})
+		}
+		names[method.Name] = true
+		if method.MutatedParameter != "" {
+			issues = append(issues, model.ValidationIssue{Location: location + ".mutated_parameter", Message: "methods are compared on their return values, mutated parameters are not supported"})
+		}
+		if method.Parameters != nil {
+			issues = append(issues, validateParameterDefinitions(*method.Parameters, location+".parameters")...)
+		}
+		if method.ReturnType != nil {
+			if err := ValidateAbstractTypeDefinition(method.ReturnType); err != nil {
+				issues = append(issues, model.ValidationIssue{Location: location + ".return_type", Message: err.Error()})
+			}
+		}
+	}
+	return issues
+}
+
+func validateParameterDefinitions(parameters []model.Parameter, location string) []model.ValidationIssue {
+	var issues []model.ValidationIssue
+	for i, parameter := range parameters {
+		if err := ValidateAbstractTypeDefinition(&parameter.ParamType); err != nil {
+			issues = append(issues, model.ValidationIssue{Location: fmt.Sprintf("%s[%d].param_type", location, i), Message: err.Error()})
+		}
+		if err := parameter.Constraints.Validate(); err != nil {
+			issues = append(issues, model.ValidationIssue{Location: fmt.Sprintf("%s[%d].constraints", location, i), Message: err.Error()})
+		}
+	}
+	return issues
+}
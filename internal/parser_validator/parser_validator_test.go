@@ -2,8 +2,11 @@ package parser_validator_test
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
 	"math/rand"
+	"strings"
 	"testing"
 
 	"github.com/TehilaTheStudent/SkillCode-backend/internal/model"
@@ -66,3 +69,556 @@ func TestAbstractTypeValidation(t *testing.T) {
 		}
 	}
 }
+
+func TestGraphVariantsValidation(t *testing.T) {
+	integerType := &model.AbstractType{Type: string(model.Integer)}
+	doubleType := &model.AbstractType{Type: string(model.Double)}
+	directed, undirected := true, false
+	cases := []struct {
+		name      string
+		graphType *model.AbstractType
+		input     string
+		valid     bool
+	}{
+		{"edge list", &model.AbstractType{Type: string(model.Graph), TypeChildren: integerType}, "[[0, 1], [1, 2]]", true},
+		{"edge list with weight but unweighted", &model.AbstractType{Type: string(model.Graph), TypeChildren: integerType}, "[[0, 1, 5]]", false},
+		{"weighted edge list", &model.AbstractType{Type: string(model.Graph), TypeChildren: integerType, WeightType: doubleType, Directed: &directed}, "[[0, 1, 2.5], [1, 2, 3]]", true},
+		{"weighted edge list missing weight", &model.AbstractType{Type: string(model.Graph), TypeChildren: integerType, WeightType: integerType}, "[[0, 1]]", false},
+		{"weighted edge list bad weight", &model.AbstractType{Type: string(model.Graph), TypeChildren: integerType, WeightType: integerType}, "[[0, 1, 2.5]]", false},
+		{"adjacency list", &model.AbstractType{Type: string(model.Graph), TypeChildren: integerType, Format: model.AdjacencyList}, "[[1, 2], [0], [0]]", true},
+		{"undirected adjacency list", &model.AbstractType{Type: string(model.Graph), TypeChildren: integerType, Format: model.AdjacencyList, Directed: &undirected}, "[[1, 2], [0], [0]]", true},
+		{"adjacency list out of range", &model.AbstractType{Type: string(model.Graph), TypeChildren: integerType, Format: model.AdjacencyList}, "[[1], [2]]", false},
+		{"weighted adjacency list", &model.AbstractType{Type: string(model.Graph), TypeChildren: integerType, WeightType: integerType, Format: model.AdjacencyList}, "[[[1, 4]], []]", true},
+		{"weighted adjacency list missing weight", &model.AbstractType{Type: string(model.Graph), TypeChildren: integerType, WeightType: integerType, Format: model.AdjacencyList}, "[[1], []]", false},
+		{"string weight", &model.AbstractType{Type: string(model.Graph), TypeChildren: integerType, WeightType: &model.AbstractType{Type: string(model.String)}}, `[[0, 1, "a"]]`, false},
+	}
+	for _, c := range cases {
+		err := parser_validator.ValidateAbstractType(c.input, c.graphType)
+		if c.valid && err != nil {
+			t.Errorf("%s: expected %s to be a valid %s, got: %v", c.name, c.input, c.graphType.ToPrint(), err)
+		}
+		if !c.valid && err == nil {
+			t.Errorf("%s: expected %s to be an invalid %s", c.name, c.input, c.graphType.ToPrint())
+		}
+		if c.valid {
+			example := parser_validator.GenerateValidString(c.graphType)
+			if err := parser_validator.ValidateAbstractType(example, c.graphType); err != nil {
+				t.Errorf("%s: generated example %s failed validation: %v", c.name, example, err)
+			}
+		}
+	}
+}
+
+func TestStringValidation(t *testing.T) {
+	stringType := &model.AbstractType{Type: string(model.String)}
+	arrayOfStrings := &model.AbstractType{Type: string(model.Array), TypeChildren: stringType}
+	cases := []struct {
+		input      string
+		targetType *model.AbstractType
+		valid      bool
+	}{
+		{`"abc"`, stringType, true},
+		{`"a, b"`, stringType, true},
+		{`"[x]"`, stringType, true},
+		{`"say \"hi\""`, stringType, true},
+		{`"line\nbreak"`, stringType, true},
+		{`"café"`, stringType, true},
+		{`"שלום"`, stringType, true},
+		{`""`, stringType, true},
+		{`abc`, stringType, false},
+		{`"unterminated`, stringType, false},
+		{`"bad \q escape"`, stringType, false},
+		{`123`, stringType, false},
+		{`["a, b", "[x]", "\"q\""]`, arrayOfStrings, true},
+		{`["a", 1]`, arrayOfStrings, false},
+	}
+	for _, c := range cases {
+		err := parser_validator.ValidateAbstractType(c.input, c.targetType)
+		if c.valid && err != nil {
+			t.Errorf("expected %s to be a valid %s, got: %v", c.input, c.targetType.ToPrint(), err)
+		}
+		if !c.valid && err == nil {
+			t.Errorf("expected %s to be an invalid %s", c.input, c.targetType.ToPrint())
+		}
+	}
+}
+
+func TestStringEncoding(t *testing.T) {
+	values := []string{"abc", "a, b", "[x]", `say "hi"`, "line\nbreak", `back\slash`, "café", "<tag> & more", ""}
+	for _, value := range values {
+		encoded := parser_validator.EncodeString(value)
+		if err := parser_validator.ValidateAtomicType(encoded, string(model.String)); err != nil {
+			t.Errorf("encoded %q as %s which failed validation: %v", value, encoded, err)
+		}
+		decoded, err := parser_validator.DecodeString(encoded)
+		if err != nil || decoded != value {
+			t.Errorf("round trip of %q through %s gave %q, %v", value, encoded, decoded, err)
+		}
+	}
+	if encoded := parser_validator.EncodeString("<a>"); encoded != `"<a>"` {
+		t.Errorf("expected HTML characters to stay unescaped, got %s", encoded)
+	}
+}
+
+func TestReformatKeepsCommasInStrings(t *testing.T) {
+	cases := map[string]string{
+		`["a,b","c"]`:     `["a,b", "c"]`,
+		`[1,2,[3,4]]`:     `[1, 2, [3, 4]]`,
+		`["q\",", "<x>"]`: `["q\",", "<x>"]`,
+		`"unicode é,x"`:   `"unicode é,x"`,
+		`[9007199254740993,-9223372036854775808]`: `[9007199254740993, -9223372036854775808]`,
+	}
+	for input, expected := range cases {
+		formatted, err := parser_validator.ReformatStringOfType(input)
+		if err != nil {
+			t.Errorf("failed to reformat %s: %v", input, err)
+			continue
+		}
+		if formatted != expected {
+			t.Errorf("reformat %s: expected %s, got %s", input, expected, formatted)
+		}
+	}
+	if _, err := parser_validator.ReformatStringOfType(`[1] [2]`); err == nil {
+		t.Errorf("expected trailing data to be rejected")
+	}
+}
+
+func TestValidationIssuesCarryLocations(t *testing.T) {
+	matrixType := &model.AbstractType{Type: string(model.Matrix), TypeChildren: &model.AbstractType{Type: string(model.Integer)}}
+	issues := parser_validator.CollectValidationIssues(`[[1, 2], [3, "x"], [5, 6.5]]`, matrixType, "test_cases[3].parameters[1]")
+	if len(issues) != 2 {
+		t.Fatalf("expected every issue to be reported, got %d: %+v", len(issues), issues)
+	}
+	expected := []model.ValidationIssue{
+		{Location: "test_cases[3].parameters[1][1][1]", Expected: "Integer", Value: `"x"`},
+		{Location: "test_cases[3].parameters[1][2][1]", Expected: "Integer", Value: "6.5"},
+	}
+	for i, issue := range issues {
+		if issue.Location != expected[i].Location || issue.Expected != expected[i].Expected || issue.Value != expected[i].Value {
+			t.Errorf("issue %d: expected %+v, got %+v", i, expected[i], issue)
+		}
+		if issue.Message == "" {
+			t.Errorf("issue %d has no message", i)
+		}
+	}
+
+	err := parser_validator.ValidateAbstractType(`[[1, 2], [3]]`, matrixType)
+	var validationErr *model.ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Issues[0].Location != "[1]" {
+		t.Errorf("expected a ValidationError at [1], got %v", err)
+	}
+	if err := parser_validator.ValidateAbstractType(`[[1, 2]]`, matrixType); err != nil {
+		t.Errorf("expected a nil error for valid input, got %v", err)
+	}
+}
+
+func TestParseFormatsCanonically(t *testing.T) {
+	integerType := &model.AbstractType{Type: string(model.Integer)}
+	cases := []struct {
+		input      string
+		targetType *model.AbstractType
+		expected   string
+	}{
+		{"[1,2,  3]", &model.AbstractType{Type: string(model.Array), TypeChildren: integerType}, "[1, 2, 3]"},
+		{`["a,b","c"]`, &model.AbstractType{Type: string(model.Array), TypeChildren: &model.AbstractType{Type: string(model.String)}}, `["a,b", "c"]`},
+		{"[[1.50,2],[3,4]]", &model.AbstractType{Type: string(model.Matrix), TypeChildren: &model.AbstractType{Type: string(model.Double)}}, "[[1.5, 2], [3, 4]]"},
+		{"[1,null,2,3]", &model.AbstractType{Type: string(model.TreeNode), TypeChildren: integerType}, "[1, null, 2, 3]"},
+		{"[[0,1,4],[1,2,5]]", &model.AbstractType{Type: string(model.Graph), TypeChildren: integerType, WeightType: integerType}, "[[0, 1, 4], [1, 2, 5]]"},
+		{"[[1,2],[0],[0]]", &model.AbstractType{Type: string(model.Graph), TypeChildren: integerType, Format: model.AdjacencyList}, "[[1, 2], [0], [0]]"},
+		{"[[[1,4]],[],[]]", &model.AbstractType{Type: string(model.Graph), TypeChildren: integerType, WeightType: integerType, Format: model.AdjacencyList}, "[[[1, 4]], [], []]"},
+		{" 42 ", integerType, "42"},
+	}
+	for _, c := range cases {
+		value, err := parser_validator.Parse(c.input, c.targetType)
+		if err != nil {
+			t.Errorf("failed to parse %s as %s: %v", c.input, c.targetType.ToPrint(), err)
+			continue
+		}
+		if formatted := value.Format(); formatted != c.expected {
+			t.Errorf("format %s: expected %s, got %s", c.input, c.expected, formatted)
+		}
+		// The plain Go data marshals back to the same JSON
+		plain, err := json.Marshal(value.Interface())
+		if err != nil {
+			t.Errorf("failed to marshal %s: %v", c.input, err)
+			continue
+		}
+		reformatted, _ := parser_validator.ReformatStringOfType(string(plain))
+		if reformatted != c.expected {
+			t.Errorf("interface %s: expected %s, got %s", c.input, c.expected, reformatted)
+		}
+	}
+}
+
+func TestParseKeepsTypedValues(t *testing.T) {
+	value, err := parser_validator.Parse(`[[0, 1, 2.5]]`, &model.AbstractType{
+		Type:         string(model.Graph),
+		TypeChildren: &model.AbstractType{Type: string(model.Integer)},
+		WeightType:   &model.AbstractType{Type: string(model.Double)},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	edge := value.Edges[0]
+	if edge.From.Integer != 0 || edge.To.Integer != 1 || edge.Weight.Double != 2.5 {
+		t.Errorf("unexpected edge %+v", edge)
+	}
+	if _, err := parser_validator.Parse(`[1, 2]`, &model.AbstractType{Type: string(model.Integer)}); err == nil {
+		t.Errorf("expected an array to be rejected as Integer")
+	}
+	if _, err := parser_validator.Parse(`[1] [2]`, &model.AbstractType{Type: string(model.Array), TypeChildren: &model.AbstractType{Type: string(model.Integer)}}); err == nil {
+		t.Errorf("expected trailing data to be rejected")
+	}
+}
+
+func TestRandomGeneratorProducesValidValues(t *testing.T) {
+	integer := &model.AbstractType{Type: string(model.Integer)}
+	undirected := false
+	types := []*model.AbstractType{
+		{Type: string(model.Graph), TypeChildren: integer, WeightType: &model.AbstractType{Type: string(model.Double)}},
+		{Type: string(model.Graph), TypeChildren: integer, WeightType: integer, Format: model.AdjacencyList, Directed: &undirected},
+		{Type: string(model.Graph), TypeChildren: &model.AbstractType{Type: string(model.String)}, Directed: &undirected},
+	}
+	for i := 0; i < 50; i++ {
+		types = append(types, GenerateAbstractType(i%3))
+	}
+	generator := parser_validator.NewRandomGenerator(1)
+	for _, abstractType := range types {
+		for _, shape := range []model.TreeShape{model.RandomTree, model.BalancedTree, model.SkewedTree} {
+			example := generator.GenerateRandomString(abstractType, model.GeneratorOptions{TreeShape: shape, Connected: true})
+			if err := parser_validator.ValidateAbstractType(example, abstractType); err != nil {
+				t.Errorf("generated %s for %s: %v", example, abstractType.ToPrint(), err)
+			}
+		}
+	}
+}
+
+func TestRandomGeneratorIsReproducible(t *testing.T) {
+	abstractType := &model.AbstractType{Type: string(model.Matrix), TypeChildren: &model.AbstractType{Type: string(model.Double)}}
+	first := parser_validator.NewRandomGenerator(42).GenerateRandomString(abstractType, model.GeneratorOptions{})
+	second := parser_validator.NewRandomGenerator(42).GenerateRandomString(abstractType, model.GeneratorOptions{})
+	if first != second {
+		t.Errorf("same seed generated %s and %s", first, second)
+	}
+}
+
+func TestRandomGeneratorHonorsOptions(t *testing.T) {
+	minValue, maxValue := 5.0, 50.0
+	minLength, maxLength := 3, 8
+	options := model.GeneratorOptions{MinValue: &minValue, MaxValue: &maxValue, MinLength: &minLength, MaxLength: &maxLength, Distinct: true, Sorted: model.Ascending}
+	array := &model.AbstractType{Type: string(model.Array), TypeChildren: &model.AbstractType{Type: string(model.Integer)}}
+	generator := parser_validator.NewRandomGenerator(7)
+	for i := 0; i < 100; i++ {
+		var values []int
+		example := generator.GenerateRandomString(array, options)
+		if err := json.Unmarshal([]byte(example), &values); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(values) < minLength || len(values) > maxLength {
+			t.Errorf("%s: length out of range", example)
+		}
+		for j, value := range values {
+			if float64(value) < minValue || float64(value) > maxValue {
+				t.Errorf("%s: value %d out of range", example, value)
+			}
+			if j > 0 && values[j-1] >= value {
+				t.Errorf("%s: not sorted and distinct", example)
+			}
+		}
+	}
+
+	charset := "xy"
+	example := generator.GenerateRandomString(&model.AbstractType{Type: string(model.String)}, model.GeneratorOptions{Charset: charset})
+	if decoded, _ := parser_validator.DecodeString(example); strings.Trim(decoded, charset) != "" {
+		t.Errorf("%s: characters outside %q", example, charset)
+	}
+
+	skewed := generator.GenerateRandomString(&model.AbstractType{Type: string(model.TreeNode), TypeChildren: &model.AbstractType{Type: string(model.Integer)}},
+		model.GeneratorOptions{MinLength: &minLength, MaxLength: &minLength, TreeShape: model.SkewedTree})
+	if strings.Count(skewed, "null") != minLength-1 {
+		t.Errorf("%s: expected a right-skewed tree of %d nodes", skewed, minLength)
+	}
+}
+
+func TestRandomGeneratorReportsUnsatisfiableOptions(t *testing.T) {
+	integer := &model.AbstractType{Type: string(model.Integer)}
+	array := &model.AbstractType{Type: string(model.Array), TypeChildren: integer}
+	low, high := 1.2, 1.8
+	zero, two := 0.0, 2.0
+	five, huge := 5, 1000000000
+	wideLow, wideHigh := -math.Ldexp(1, 62), math.Ldexp(1, 62)
+	lowest, highest := -1e19, 1e19
+	cases := []struct {
+		name         string
+		abstractType *model.AbstractType
+		options      model.GeneratorOptions
+		fails        bool
+	}{
+		{"integer range", integer, model.GeneratorOptions{MinValue: &zero, MaxValue: &two}, false},
+		{"no integer in a fractional range", integer, model.GeneratorOptions{MinValue: &low, MaxValue: &high}, true},
+		{"fractional range of doubles", &model.AbstractType{Type: string(model.Double)}, model.GeneratorOptions{MinValue: &low, MaxValue: &high}, false},
+		{"fewer distinct values than elements", array, model.GeneratorOptions{MinValue: &zero, MaxValue: &two, MinLength: &five, MaxLength: &five, Distinct: true}, true},
+		{"repeated values allowed", array, model.GeneratorOptions{MinValue: &zero, MaxValue: &two, MinLength: &five, MaxLength: &five}, false},
+		{"range wider than 2^63", integer, model.GeneratorOptions{MinValue: &wideLow, MaxValue: &wideHigh}, false},
+		{"range beyond int64", integer, model.GeneratorOptions{MinValue: &lowest, MaxValue: &highest}, false},
+		{"length above the cap", array, model.GeneratorOptions{MinLength: &huge}, true},
+		{"string length above the cap", &model.AbstractType{Type: string(model.String)}, model.GeneratorOptions{MinLength: &huge, MaxLength: &huge}, true},
+		{"graph above the cap", &model.AbstractType{Type: string(model.Graph), TypeChildren: integer}, model.GeneratorOptions{MaxLength: &huge}, true},
+	}
+	for _, c := range cases {
+		generator := parser_validator.NewRandomGenerator(1)
+		example := generator.GenerateRandomString(c.abstractType, c.options)
+		if c.fails && generator.Err() == nil {
+			t.Errorf("%s: expected an error, generated %s", c.name, example)
+		}
+		if !c.fails && generator.Err() != nil {
+			t.Errorf("%s: unexpected error: %v", c.name, generator.Err())
+		}
+		if err := parser_validator.ValidateAbstractType(example, c.abstractType); err != nil {
+			t.Errorf("%s: generated %s which failed validation: %v", c.name, example, err)
+		}
+	}
+}
+
+func TestRandomGeneratorConnectsGraphs(t *testing.T) {
+	graphType := &model.AbstractType{Type: string(model.Graph), TypeChildren: &model.AbstractType{Type: string(model.Integer)}}
+	generator := parser_validator.NewRandomGenerator(3)
+	for i := 0; i < 50; i++ {
+		value := generator.Generate(graphType, model.GeneratorOptions{Connected: true})
+		// Union-find over the edges must leave a single component
+		parent := map[int64]int64{}
+		var find func(int64) int64
+		find = func(node int64) int64 {
+			if _, ok := parent[node]; !ok {
+				parent[node] = node
+			}
+			if parent[node] != node {
+				parent[node] = find(parent[node])
+			}
+			return parent[node]
+		}
+		nodes := map[int64]bool{}
+		for _, edge := range value.Edges {
+			if edge.From.Integer == edge.To.Integer {
+				t.Errorf("%s: self loop", value.Format())
+			}
+			nodes[edge.From.Integer], nodes[edge.To.Integer] = true, true
+			parent[find(edge.From.Integer)] = find(edge.To.Integer)
+		}
+		roots := map[int64]bool{}
+		for node := range nodes {
+			roots[find(node)] = true
+		}
+		if len(roots) > 1 {
+			t.Errorf("%s: graph is not connected", value.Format())
+		}
+	}
+}
+
+func TestParameterConstraints(t *testing.T) {
+	integer := &model.AbstractType{Type: string(model.Integer)}
+	one, hundred := 1.0, 100.0
+	two, three := 2, 3
+	undirected := false
+	cases := []struct {
+		parameter model.Parameter
+		input     string
+		issues    []string // Locations of the expected issues
+	}{
+		{model.Parameter{Name: "n", ParamType: *integer, Constraints: &model.Constraints{MinValue: &one, MaxValue: &hundred}}, `50`, nil},
+		{model.Parameter{Name: "n", ParamType: *integer, Constraints: &model.Constraints{MinValue: &one, MaxValue: &hundred}}, `101`, []string{"p"}},
+		{model.Parameter{Name: "nums", ParamType: model.AbstractType{Type: string(model.Array), TypeChildren: integer}, Constraints: &model.Constraints{MinValue: &one, MaxLength: &three, Unique: true}},
+			`[1, 0, 1, 5]`, []string{"p", "p[1]", "p"}},
+		{model.Parameter{Name: "s", ParamType: model.AbstractType{Type: string(model.String)}, Constraints: &model.Constraints{Charset: "ab", MaxLength: &two}}, `"abc"`, []string{"p", "p"}},
+		{model.Parameter{Name: "grid", ParamType: model.AbstractType{Type: string(model.Matrix), TypeChildren: integer}, Constraints: &model.Constraints{MaxValue: &hundred, MaxLength: &two}},
+			`[[1, 200], [3, 4]]`, []string{"p[0][1]"}},
+		{model.Parameter{Name: "root", ParamType: model.AbstractType{Type: string(model.TreeNode), TypeChildren: integer}, Constraints: &model.Constraints{MaxHeight: &two}},
+			`[1, null, 2, null, 3]`, []string{"p"}},
+		{model.Parameter{Name: "root", ParamType: model.AbstractType{Type: string(model.TreeNode), TypeChildren: integer}, Constraints: &model.Constraints{MaxHeight: &two}},
+			`[1, 2, 3]`, nil},
+		{model.Parameter{Name: "g", ParamType: model.AbstractType{Type: string(model.Graph), TypeChildren: integer, WeightType: integer}, Constraints: &model.Constraints{MaxNodes: &two, MinValue: &one}},
+			`[[0, 1, 5], [1, 2, 0]]`, []string{"p", "p"}},
+		{model.Parameter{Name: "g", ParamType: model.AbstractType{Type: string(model.Graph), TypeChildren: integer, Format: model.AdjacencyList, Directed: &undirected}, Constraints: &model.Constraints{MaxLength: &two, Unique: true}},
+			`[[1, 2], [0], [0]]`, nil},
+	}
+	for _, c := range cases {
+		issues := parser_validator.ValidateParameterValue(c.input, c.parameter, "p")
+		var locations []string
+		for _, issue := range issues {
+			locations = append(locations, issue.Location)
+		}
+		if strings.Join(locations, " ") != strings.Join(c.issues, " ") {
+			t.Errorf("%s %s: expected issues at %v, got %+v", c.parameter.Name, c.input, c.issues, issues)
+		}
+	}
+}
+
+func TestConstraintsGuideGeneration(t *testing.T) {
+	one, nine := 1.0, 9.0
+	three := 3
+	parameter := model.Parameter{
+		Name:        "nums",
+		ParamType:   model.AbstractType{Type: string(model.Array), TypeChildren: &model.AbstractType{Type: string(model.Integer)}},
+		Constraints: &model.Constraints{MinValue: &one, MaxValue: &nine, MinLength: &three, MaxLength: &three, Unique: true},
+	}
+	generator := parser_validator.NewRandomGenerator(5)
+	for i := 0; i < 50; i++ {
+		input := generator.GenerateRandomString(&parameter.ParamType, parameter.Constraints.GeneratorOptions())
+		if issues := parser_validator.ValidateParameterValue(input, parameter, "nums"); len(issues) > 0 {
+			t.Errorf("generated %s breaks %s: %+v", input, parameter.Constraints, issues)
+		}
+	}
+}
+
+func TestComparatorValidation(t *testing.T) {
+	integer := &model.AbstractType{Type: string(model.Integer)}
+	double := &model.AbstractType{Type: string(model.Double)}
+	array := &model.AbstractType{Type: string(model.Array), TypeChildren: integer}
+	arrayOfArrays := &model.AbstractType{Type: string(model.Array), TypeChildren: array}
+	tolerance, negative := 1e-4, -1.0
+	cases := []struct {
+		comparator *model.Comparator
+		returnType *model.AbstractType
+		languages  []string
+		valid      bool
+	}{
+		{nil, integer, nil, true},
+		{&model.Comparator{Type: model.ExactComparator}, integer, nil, true},
+		{&model.Comparator{Type: model.UnorderedComparator}, array, nil, true},
+		{&model.Comparator{Type: model.UnorderedComparator}, integer, nil, false},
+		{&model.Comparator{Type: model.UnorderedNestedComparator}, arrayOfArrays, nil, true},
+		{&model.Comparator{Type: model.SetOfSetsComparator}, arrayOfArrays, nil, true},
+		{&model.Comparator{Type: model.SetOfSetsComparator}, array, nil, false},
+		{&model.Comparator{Type: model.FloatToleranceComparator, Tolerance: &tolerance}, &model.AbstractType{Type: string(model.Array), TypeChildren: double}, nil, true},
+		{&model.Comparator{Type: model.FloatToleranceComparator, Tolerance: &negative}, double, nil, false},
+		{&model.Comparator{Type: model.FloatToleranceComparator}, array, nil, false},
+		{&model.Comparator{Type: model.UnorderedComparator, Tolerance: &tolerance}, array, nil, false},
+		{&model.Comparator{Type: model.CustomComparator, Code: map[model.PredefinedSupportedLanguage]string{model.Python: "def compare(e, a): return e == a"}}, integer, []string{"Python"}, true},
+		{&model.Comparator{Type: model.CustomComparator, Code: map[model.PredefinedSupportedLanguage]string{model.Python: "def compare(e, a): return e == a"}}, integer, []string{"Python", "JavaScript"}, false},
+		{&model.Comparator{Type: "fuzzy"}, integer, nil, false},
+	}
+	for i, c := range cases {
+		err := parser_validator.ValidateComparator(c.comparator, c.returnType, c.languages)
+		if (err == nil) != c.valid {
+			t.Errorf("case %d: expected valid=%v, got error: %v", i, c.valid, err)
+		}
+	}
+}
+
+func TestOperationSequenceValidation(t *testing.T) {
+	integer := model.AbstractType{Type: string(model.Integer)}
+	lruCache := &model.ClassConfig{
+		Name:        "LRUCache",
+		Constructor: &[]model.Parameter{{Name: "capacity", ParamType: integer}},
+		Methods: []model.FunctionConfig{
+			{Name: "get", Parameters: &[]model.Parameter{{Name: "key", ParamType: integer}}, ReturnType: &integer},
+			{Name: "put", Parameters: &[]model.Parameter{{Name: "key", ParamType: integer}, {Name: "value", ParamType: integer}}},
+		},
+	}
+	cases := []struct {
+		operations, arguments, outputs string
+		location                       string // Location of the first issue, empty when valid
+	}{
+		{`["LRUCache","put","get"]`, `[[2],[1,1],[1]]`, `[null,null,1]`, ""},
+		{`["LRUCache","get"]`, `[[2],[1]]`, `[null,-1]`, ""},
+		{`["MinStack","get"]`, `[[2],[1]]`, `[null,1]`, "t.parameters[0][0]"},
+		{`["LRUCache","pop"]`, `[[2],[]]`, `[null,1]`, "t.parameters[0][1]"},
+		{`["LRUCache","put"]`, `[[2],[1]]`, `[null,null]`, "t.parameters[1][1]"},
+		{`["LRUCache","get"]`, `[[2],["a"]]`, `[null,1]`, "t.parameters[1][1][0]"},
+		{`["LRUCache","put"]`, `[[2],[1,1]]`, `[null,1]`, "t.expected_output[1]"},
+		{`["LRUCache","get"]`, `[[2],[1]]`, `[null,"x"]`, "t.expected_output[1]"},
+		{`["LRUCache","get"]`, `[[2]]`, `[null,1]`, "t"},
+	}
+	for i, c := range cases {
+		inputOutput := model.InputOutput{Parameters: []string{c.operations, c.arguments}, ExpectedOutput: c.outputs}
+		issues := parser_validator.ValidateOperationSequence(inputOutput, lruCache, "t")
+		if c.location == "" {
+			if len(issues) > 0 {
+				t.Errorf("case %d: expected valid, got %v", i, issues)
+			}
+			continue
+		}
+		if len(issues) == 0 || issues[0].Location != c.location {
+			t.Errorf("case %d: expected an issue at %s, got %v", i, c.location, issues)
+		}
+	}
+}
+
+// validateByRemarshal is the previous validation strategy: unmarshal, then marshal every element
+// back to JSON and recurse. Kept here as the baseline for the benchmarks below.
+func validateByRemarshal(input string, abstractType *model.AbstractType) error {
+	if abstractType.TypeChildren == nil {
+		return parser_validator.ValidateAtomicType(input, abstractType.Type)
+	}
+	var parsed []interface{}
+	if err := json.Unmarshal([]byte(input), &parsed); err != nil {
+		return err
+	}
+	childType := abstractType.TypeChildren
+	if abstractType.Type == string(model.Matrix) {
+		childType = &model.AbstractType{Type: string(model.Array), TypeChildren: abstractType.TypeChildren}
+	}
+	for _, elem := range parsed {
+		elemJSON, err := json.Marshal(elem)
+		if err != nil {
+			return err
+		}
+		if err := validateByRemarshal(string(elemJSON), childType); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func largeMatrix(size int) string {
+	rows := make([]string, size)
+	for i := range rows {
+		cells := make([]string, size)
+		for j := range cells {
+			cells[j] = fmt.Sprintf("%d", i*size+j)
+		}
+		rows[i] = "[" + strings.Join(cells, ", ") + "]"
+	}
+	return "[" + strings.Join(rows, ", ") + "]"
+}
+
+var matrixOfIntegers = &model.AbstractType{Type: string(model.Matrix), TypeChildren: &model.AbstractType{Type: string(model.Integer)}}
+
+func BenchmarkValidateLargeMatrixByRemarshal(b *testing.B) {
+	input := largeMatrix(300)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := validateByRemarshal(input, matrixOfIntegers); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkValidateLargeMatrixByParse(b *testing.B) {
+	input := largeMatrix(300)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := parser_validator.Parse(input, matrixOfIntegers); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkValidateLargeGraphByParse(b *testing.B) {
+	edges := make([]string, 20000)
+	for i := range edges {
+		edges[i] = fmt.Sprintf("[%d, %d, %d]", i, (i*7+1)%20000, i%100)
+	}
+	input := "[" + strings.Join(edges, ", ") + "]"
+	graphType := &model.AbstractType{
+		Type:         string(model.Graph),
+		TypeChildren: &model.AbstractType{Type: string(model.Integer)},
+		WeightType:   &model.AbstractType{Type: string(model.Integer)},
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := parser_validator.Parse(input, graphType); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,400 @@
+package parser_validator
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/model"
+)
+
+// Defaults used when GeneratorOptions leaves a bound unset
+const (
+	defaultMinValue   = -100
+	defaultMaxValue   = 100
+	defaultMinLength  = 1
+	defaultMaxLength  = 10
+	defaultMinWeight  = 1
+	defaultMaxWeight  = 100
+	defaultCharset    = "abcdefghijklmnopqrstuvwxyz"
+	maxDistinctTries  = 100
+	maxExtraEdgeRatio = 2 // Extra random edges per node on top of a spanning tree
+
+	maxGeneratedLength = 1000    // Longest generated collection or String, and most graph nodes
+	maxGeneratedValues = 1000000 // Most elements, characters and nodes one generator builds in all
+)
+
+// RandomGenerator builds random values that satisfy an AbstractType and GeneratorOptions.
+// The same seed always produces the same sequence of values. When the options cannot be satisfied,
+// the values are still built and Err reports why they break the options.
+type RandomGenerator struct {
+	rng    *rand.Rand
+	err    error
+	budget int // Values left to generate before maxGeneratedValues is reached
+}
+
+// NewRandomGenerator creates a RandomGenerator seeded with seed
+func NewRandomGenerator(seed int64) *RandomGenerator {
+	return &RandomGenerator{rng: rand.New(rand.NewSource(seed)), budget: maxGeneratedValues}
+}
+
+// Err returns the first reason a generated value could not satisfy its options, nil when all did
+func (g *RandomGenerator) Err() error {
+	return g.err
+}
+
+func (g *RandomGenerator) fail(message string) {
+	if g.err == nil {
+		g.err = errors.New(message)
+	}
+}
+
+// GenerateRandomString generates a random valid string for abstractType, in canonical form
+func (g *RandomGenerator) GenerateRandomString(abstractType *model.AbstractType, options model.GeneratorOptions) string {
+	return g.Generate(abstractType, options).Format()
+}
+
+// Generate generates a random Value for abstractType
+func (g *RandomGenerator) Generate(abstractType *model.AbstractType, options model.GeneratorOptions) *Value {
+	if abstractType.TypeChildren == nil {
+		return g.generateAtomic(abstractType, options)
+	}
+	switch model.CompositeType(abstractType.Type) {
+	case model.Array, model.ListNode:
+		return &Value{Type: abstractType, Elements: g.generateElements(abstractType.TypeChildren, options, g.length(options))}
+	case model.Matrix:
+		rowType := &model.AbstractType{Type: string(model.Array), TypeChildren: abstractType.TypeChildren}
+		rows, cols := g.length(options), g.length(options)
+		value := &Value{Type: abstractType, Elements: make([]*Value, rows)}
+		for i := range value.Elements {
+			value.Elements[i] = &Value{Type: rowType, Elements: g.generateElements(abstractType.TypeChildren, options, cols)}
+		}
+		return value
+	case model.TreeNode:
+		return g.generateTree(abstractType, options)
+	case model.Graph:
+		return g.generateGraph(abstractType, options)
+	}
+	return &Value{Type: abstractType}
+}
+
+func (g *RandomGenerator) generateAtomic(abstractType *model.AbstractType, options model.GeneratorOptions) *Value {
+	value := &Value{Type: abstractType}
+	minValue, maxValue := bounds(options.MinValue, options.MaxValue, defaultMinValue, defaultMaxValue)
+	switch model.AtomicType(abstractType.Type) {
+	case model.Integer:
+		low, high := math.Ceil(minValue), math.Floor(maxValue)
+		if low > high {
+			g.fail(fmt.Sprintf("no Integer lies between %g and %g", minValue, maxValue))
+		}
+		value.Integer = g.integerBetween(saturatingInt64(low), saturatingInt64(high))
+	case model.Double:
+		// Two decimal places keep the examples readable
+		value.Double = math.Round((minValue+g.rng.Float64()*(maxValue-minValue))*100) / 100
+		value.Double = math.Min(math.Max(value.Double, minValue), maxValue)
+	case model.Boolean:
+		value.Boolean = g.rng.Intn(2) == 1
+	case model.String:
+		charset := []rune(options.Charset)
+		if len(charset) == 0 {
+			charset = []rune(defaultCharset)
+		}
+		runes := make([]rune, g.reserve(g.length(options)))
+		for i := range runes {
+			runes[i] = charset[g.rng.Intn(len(charset))]
+		}
+		value.String = string(runes)
+	}
+	return value
+}
+
+// generateElements generates count elements, honoring Distinct and Sorted
+func (g *RandomGenerator) generateElements(elementType *model.AbstractType, options model.GeneratorOptions, count int) []*Value {
+	// Inner levels get their own length, but share value constraints
+	elementOptions := options
+	elementOptions.Distinct, elementOptions.Sorted = false, ""
+
+	count = g.reserve(count)
+	elements := make([]*Value, 0, count)
+	seen := map[string]bool{}
+	for tries := 0; len(elements) < count && tries < count*maxDistinctTries; tries++ {
+		element := g.Generate(elementType, elementOptions)
+		if options.Distinct {
+			key := element.Format()
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+		}
+		elements = append(elements, element)
+	}
+	if len(elements) < count {
+		g.fail(fmt.Sprintf("only %d distinct values of %s could be generated, %d were asked for", len(elements), elementType.ToPrint(), count))
+	}
+	if options.Sorted != "" && elementType.TypeChildren == nil {
+		sort.SliceStable(elements, func(i, j int) bool {
+			less := atomicLess(elements[i], elements[j])
+			if options.Sorted == model.Descending {
+				return atomicLess(elements[j], elements[i])
+			}
+			return less
+		})
+	}
+	return elements
+}
+
+func atomicLess(a, b *Value) bool {
+	switch model.AtomicType(a.Type.Type) {
+	case model.Integer:
+		return a.Integer < b.Integer
+	case model.Double:
+		return a.Double < b.Double
+	case model.String:
+		return a.String < b.String
+	case model.Boolean:
+		return !a.Boolean && b.Boolean
+	}
+	return false
+}
+
+// treeNode is a generated tree node before it is written in level order
+type treeNode struct {
+	value       *Value
+	left, right *treeNode
+}
+
+func (g *RandomGenerator) generateTree(abstractType *model.AbstractType, options model.GeneratorOptions) *Value {
+	count := g.length(options)
+	values := g.generateElements(abstractType.TypeChildren, model.GeneratorOptions{
+		MinValue: options.MinValue, MaxValue: options.MaxValue, Charset: options.Charset, Distinct: options.Distinct,
+	}, count)
+	value := &Value{Type: abstractType}
+
+	switch options.TreeShape {
+	case model.BalancedTree:
+		// A complete tree is its values in level order, without holes
+		value.Elements = values
+		return value
+	case model.SkewedTree:
+		for i, element := range values {
+			if i > 0 {
+				value.Elements = append(value.Elements, &Value{Type: abstractType.TypeChildren, Null: true})
+			}
+			value.Elements = append(value.Elements, element)
+		}
+		return value
+	}
+
+	// Random shape: walk down from the root picking a side until a free slot is found
+	var root *treeNode
+	for _, element := range values {
+		node := &treeNode{value: element}
+		if root == nil {
+			root = node
+			continue
+		}
+		current := root
+		for {
+			if g.rng.Intn(2) == 0 {
+				if current.left == nil {
+					current.left = node
+					break
+				}
+				current = current.left
+			} else {
+				if current.right == nil {
+					current.right = node
+					break
+				}
+				current = current.right
+			}
+		}
+	}
+	value.Elements = levelOrder(root, abstractType.TypeChildren)
+	return value
+}
+
+// levelOrder writes a tree as LeetCode-style level order, with null holes and no trailing nulls
+func levelOrder(root *treeNode, elementType *model.AbstractType) []*Value {
+	var elements []*Value
+	queue := []*treeNode{root}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if current == nil {
+			elements = append(elements, &Value{Type: elementType, Null: true})
+			continue
+		}
+		elements = append(elements, current.value)
+		queue = append(queue, current.left, current.right)
+	}
+	for len(elements) > 0 && elements[len(elements)-1].Null {
+		elements = elements[:len(elements)-1]
+	}
+	return elements
+}
+
+func (g *RandomGenerator) generateGraph(abstractType *model.AbstractType, options model.GeneratorOptions) *Value {
+	nodeCount := g.length(options)
+	value := &Value{Type: abstractType}
+
+	// Node labels: indexes for Integer graphs, distinct random values otherwise
+	var labels []*Value
+	if abstractType.TypeChildren.Type == string(model.Integer) {
+		labels = make([]*Value, g.reserve(nodeCount))
+		nodeCount = len(labels)
+		for i := range labels {
+			labels[i] = &Value{Type: abstractType.TypeChildren, Integer: int64(i)}
+		}
+	} else {
+		labels = g.generateElements(abstractType.TypeChildren, model.GeneratorOptions{
+			MinValue: options.MinValue, MaxValue: options.MaxValue, Charset: options.Charset, Distinct: true,
+		}, nodeCount)
+		nodeCount = len(labels)
+	}
+
+	// Pairs of node indexes, without self loops or repeated edges
+	type pair struct{ u, v int }
+	used := map[pair]bool{}
+	var pairs []pair
+	addPair := func(u, v int) bool {
+		if u == v || used[pair{u, v}] || (!abstractType.IsDirected() && used[pair{v, u}]) {
+			return false
+		}
+		used[pair{u, v}] = true
+		pairs = append(pairs, pair{u, v})
+		return true
+	}
+	if options.Connected {
+		// A random spanning tree over a shuffled node order connects everything
+		order := g.rng.Perm(nodeCount)
+		for i := 1; i < nodeCount; i++ {
+			u, v := order[g.rng.Intn(i)], order[i]
+			if g.rng.Intn(2) == 0 {
+				u, v = v, u
+			}
+			addPair(u, v)
+		}
+	}
+	if nodeCount > 1 {
+		extraEdges := g.rng.Intn(nodeCount*maxExtraEdgeRatio + 1)
+		for i := 0; i < extraEdges*maxDistinctTries && extraEdges > 0; i++ {
+			if addPair(g.rng.Intn(nodeCount), g.rng.Intn(nodeCount)) {
+				extraEdges--
+			}
+		}
+	}
+
+	minWeight, maxWeight := bounds(options.MinWeight, options.MaxWeight, defaultMinWeight, defaultMaxWeight)
+	weightOptions := model.GeneratorOptions{MinValue: &minWeight, MaxValue: &maxWeight}
+	for _, p := range pairs {
+		edge := Edge{From: labels[p.u], To: labels[p.v]}
+		if abstractType.IsWeighted() {
+			edge.Weight = g.generateAtomic(abstractType.WeightType, weightOptions)
+		}
+		value.Edges = append(value.Edges, edge)
+	}
+
+	if abstractType.GetGraphFormat() == model.AdjacencyList {
+		value.NodeCount = nodeCount
+		if !abstractType.IsDirected() {
+			// Undirected adjacency lists list every edge from both ends
+			for _, edge := range value.Edges[:len(value.Edges):len(value.Edges)] {
+				value.Edges = append(value.Edges, Edge{From: edge.To, To: edge.From, Weight: edge.Weight})
+			}
+		}
+	}
+	return value
+}
+
+// length picks a length within the options, defaulting to 1..10 and never above maxGeneratedLength
+func (g *RandomGenerator) length(options model.GeneratorOptions) int {
+	minLength, maxLength := defaultMinLength, defaultMaxLength
+	if options.MinLength != nil {
+		minLength = *options.MinLength
+		if options.MaxLength == nil && maxLength < minLength {
+			maxLength = minLength
+		}
+	}
+	if options.MaxLength != nil {
+		maxLength = *options.MaxLength
+		if options.MinLength == nil && minLength > maxLength {
+			minLength = maxLength
+		}
+	}
+	if minLength < 0 {
+		minLength = 0
+	}
+	if maxLength < minLength {
+		maxLength = minLength
+	}
+	if maxLength > maxGeneratedLength {
+		g.fail(fmt.Sprintf("lengths above %d are not generated, %d was asked for", maxGeneratedLength, maxLength))
+		minLength, maxLength = min(minLength, maxGeneratedLength), maxGeneratedLength
+	}
+	return int(g.integerBetween(int64(minLength), int64(maxLength)))
+}
+
+// reserve takes n values from the budget of the generator, fewer once the budget runs out
+func (g *RandomGenerator) reserve(n int) int {
+	if n > g.budget {
+		g.fail(fmt.Sprintf("the options ask for more than %d generated values in all", maxGeneratedValues))
+		n = g.budget
+	}
+	g.budget -= n
+	return n
+}
+
+// integerBetween picks an integer in [minValue, maxValue], which may span the whole int64 range
+func (g *RandomGenerator) integerBetween(minValue, maxValue int64) int64 {
+	if maxValue <= minValue {
+		return minValue
+	}
+	span := uint64(maxValue) - uint64(minValue) + 1 // Wraps to 0 when the range holds every int64
+	if span == 0 {
+		return int64(g.rng.Uint64())
+	}
+	if span <= math.MaxInt64 {
+		return minValue + g.rng.Int63n(int64(span))
+	}
+	// Over half of the Uint64 values fall in the span, so a few draws are enough
+	for {
+		if offset := g.rng.Uint64(); offset < span {
+			return int64(uint64(minValue) + offset)
+		}
+	}
+}
+
+// saturatingInt64 converts a whole number to int64, clamped to the int64 range
+func saturatingInt64(value float64) int64 {
+	if value >= math.MaxInt64 {
+		return math.MaxInt64
+	}
+	if value <= math.MinInt64 {
+		return math.MinInt64
+	}
+	return int64(value)
+}
+
+// bounds resolves an optional range against its defaults, keeping min <= max
+func bounds(minValue, maxValue *float64, defaultMin, defaultMax float64) (float64, float64) {
+	low, high := defaultMin, defaultMax
+	if minValue != nil {
+		low = *minValue
+	}
+	if maxValue != nil {
+		high = *maxValue
+	}
+	if minValue != nil && maxValue == nil && high < low {
+		high = low
+	}
+	if maxValue != nil && minValue == nil && low > high {
+		low = high
+	}
+	if high < low {
+		high = low
+	}
+	return low, high
+}
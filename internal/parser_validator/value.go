@@ -0,0 +1,388 @@
+package parser_validator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/model"
+)
+
+// Value is a test-case value parsed once against its AbstractType.
+// Atomic values fill the field matching their type, composite values fill Elements or Edges.
+type Value struct {
+	Type *model.AbstractType
+
+	Null    bool // A hole in a TreeNode level order
+	Integer int64
+	Double  float64
+	String  string
+	Boolean bool
+
+	Elements  []*Value // Array, ListNode, TreeNode (level order) and Matrix rows
+	Edges     []Edge   // Graph edges; adjacency lists keep one arc per listed neighbor
+	NodeCount int      // Graph nodes 0..NodeCount-1, adjacency lists only
+}
+
+// Edge is one Graph edge, Weight is nil for unweighted graphs
+type Edge struct {
+	From   *Value
+	To     *Value
+	Weight *Value
+}
+
+// Parse decodes input a single time and builds the typed Value tree for abstractType.
+// Every problem found is returned together as a *model.ValidationError.
+func Parse(input string, abstractType *model.AbstractType) (*Value, error) {
+	collector := &issueCollector{}
+	value := collector.parseInput(input, abstractType, rootLocation(""))
+	if err := model.NewValidationError(collector.issues); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// decodeJSON decodes a single JSON value, keeping numbers as json.Number so Integer and Double can be told apart
+func decodeJSON(input string) (interface{}, error) {
+	decoder := json.NewDecoder(strings.NewReader(input))
+	decoder.UseNumber()
+	var parsed interface{}
+	if err := decoder.Decode(&parsed); err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, fmt.Errorf("unexpected data after the value")
+	}
+	return parsed, nil
+}
+
+func (c *issueCollector) parseInput(input string, abstractType *model.AbstractType, location *location) *Value {
+	parsed, err := decodeJSON(input)
+	if err != nil {
+		if abstractType.TypeChildren == nil {
+			if atomicErr := ValidateAtomicType(input, abstractType.Type); atomicErr != nil {
+				c.add(location, abstractType, rawInput(input), "%v", atomicErr)
+				return nil
+			}
+		}
+		c.add(location, abstractType, rawInput(input), "invalid composite type: %s", err)
+		return nil
+	}
+	return c.parse(parsed, abstractType, location)
+}
+
+// parse builds the Value for an already decoded element
+func (c *issueCollector) parse(raw interface{}, abstractType *model.AbstractType, location *location) *Value {
+	if abstractType.TypeChildren == nil {
+		return c.parseAtomic(raw, abstractType, location)
+	}
+
+	value := &Value{Type: abstractType}
+	switch model.CompositeType(abstractType.Type) {
+	case model.Array, model.ListNode, model.TreeNode:
+		array, ok := raw.([]interface{})
+		if !ok {
+			c.add(location, abstractType, raw, "expected array, got: %T", raw)
+			return nil
+		}
+		value.Elements = make([]*Value, len(array))
+		for i, elem := range array {
+			if elem == nil && abstractType.Type == string(model.TreeNode) {
+				value.Elements[i] = &Value{Type: abstractType.TypeChildren, Null: true}
+				continue
+			}
+			value.Elements[i] = c.parse(elem, abstractType.TypeChildren, elementLocation(location, i))
+		}
+	case model.Matrix:
+		matrix, ok := raw.([]interface{})
+		if !ok {
+			c.add(location, abstractType, raw, "expected 2D array for Matrix, got: %T", raw)
+			return nil
+		}
+		rowType := &model.AbstractType{Type: string(model.Array), TypeChildren: abstractType.TypeChildren}
+		value.Elements = make([]*Value, len(matrix))
+		// Check all rows are arrays of the same length
+		var rowLength int
+		for i, row := range matrix {
+			rowLocation := elementLocation(location, i)
+			rowArray, ok := row.([]interface{})
+			if !ok {
+				c.add(rowLocation, abstractType, row, "matrix row %d is not an array", i)
+				continue
+			}
+			if i == 0 {
+				rowLength = len(rowArray)
+			} else if len(rowArray) != rowLength {
+				c.add(rowLocation, abstractType, row, "matrix rows have inconsistent lengths: expected %d, got %d", rowLength, len(rowArray))
+			}
+			rowValue := &Value{Type: rowType, Elements: make([]*Value, len(rowArray))}
+			for j, elem := range rowArray {
+				rowValue.Elements[j] = c.parse(elem, abstractType.TypeChildren, elementLocation(rowLocation, j))
+			}
+			value.Elements[i] = rowValue
+		}
+	case model.Graph:
+		if err := validateGraphType(abstractType); err != nil {
+			c.add(location, abstractType, nil, "%v", err)
+			return nil
+		}
+		graph, ok := raw.([]interface{})
+		if !ok {
+			c.add(location, abstractType, raw, "expected array for Graph, got: %T", raw)
+			return nil
+		}
+		if abstractType.GetGraphFormat() == model.AdjacencyList {
+			c.parseAdjacencyList(graph, value, location)
+		} else {
+			c.parseEdgeList(graph, value, location)
+		}
+	default:
+		c.add(location, abstractType, nil, "unknown composite type: %s", abstractType.Type)
+		return nil
+	}
+	return value
+}
+
+func (c *issueCollector) parseAtomic(raw interface{}, abstractType *model.AbstractType, location *location) *Value {
+	value := &Value{Type: abstractType}
+	ok := false
+	switch model.AtomicType(abstractType.Type) {
+	case model.Integer:
+		var number json.Number
+		if number, ok = raw.(json.Number); ok && isIntegerLiteral(number.String()) {
+			integer, err := strconv.ParseInt(number.String(), 10, 64)
+			if err != nil {
+				c.add(location, abstractType, raw, "invalid Integer: %s out of range", number)
+				return nil
+			}
+			value.Integer = integer
+		} else {
+			ok = false
+		}
+	case model.Double:
+		var number json.Number
+		if number, ok = raw.(json.Number); ok && isDoubleLiteral(number.String()) {
+			double, err := strconv.ParseFloat(number.String(), 64)
+			if err != nil {
+				c.add(location, abstractType, raw, "invalid Double: %s out of range", number)
+				return nil
+			}
+			value.Double = double
+		} else {
+			ok = false
+		}
+	case model.Boolean:
+		value.Boolean, ok = raw.(bool)
+	case model.String:
+		value.String, ok = raw.(string)
+	}
+	if !ok {
+		if err := ValidateAtomicType(toJSON(raw), abstractType.Type); err != nil {
+			c.add(location, abstractType, raw, "%v", err)
+		} else {
+			c.add(location, abstractType, raw, "invalid %s", abstractType.Type)
+		}
+		return nil
+	}
+	return value
+}
+
+// parseEdgeList parses [[u, v], ...] or [[u, v, w], ...] depending on the weight type
+func (c *issueCollector) parseEdgeList(graph []interface{}, value *Value, location *location) {
+	abstractType := value.Type
+	edgeLength := 2
+	if abstractType.IsWeighted() {
+		edgeLength = 3
+	}
+	value.Edges = make([]Edge, 0, len(graph))
+	for i, raw := range graph {
+		edgeLocation := elementLocation(location, i)
+		tuple, ok := raw.([]interface{})
+		if !ok || len(tuple) != edgeLength {
+			c.add(edgeLocation, abstractType, raw, "invalid edge, expected %d elements", edgeLength)
+			continue
+		}
+		edge := Edge{
+			From: c.parse(tuple[0], abstractType.TypeChildren, elementLocation(edgeLocation, 0)),
+			To:   c.parse(tuple[1], abstractType.TypeChildren, elementLocation(edgeLocation, 1)),
+		}
+		if abstractType.IsWeighted() {
+			edge.Weight = c.parseWeight(tuple[2], abstractType.WeightType, elementLocation(edgeLocation, 2))
+		}
+		value.Edges = append(value.Edges, edge)
+	}
+}
+
+// parseAdjacencyList parses [[v, ...], ...] or [[[v, w], ...], ...] where the row index is the node
+func (c *issueCollector) parseAdjacencyList(graph []interface{}, value *Value, location *location) {
+	abstractType := value.Type
+	value.NodeCount = len(graph)
+	for u, row := range graph {
+		rowLocation := elementLocation(location, u)
+		neighbors, ok := row.([]interface{})
+		if !ok {
+			c.add(rowLocation, abstractType, row, "adjacency list row %d is not an array", u)
+			continue
+		}
+		from := &Value{Type: abstractType.TypeChildren, Integer: int64(u)}
+		for j, neighbor := range neighbors {
+			node, nodeLocation := neighbor, elementLocation(rowLocation, j)
+			edge := Edge{From: from}
+			if abstractType.IsWeighted() {
+				pair, ok := neighbor.([]interface{})
+				if !ok || len(pair) != 2 {
+					c.add(nodeLocation, abstractType, neighbor, "invalid weighted neighbor of node %d, expected [node, weight]", u)
+					continue
+				}
+				edge.Weight = c.parseWeight(pair[1], abstractType.WeightType, elementLocation(nodeLocation, 1))
+				node, nodeLocation = pair[0], elementLocation(nodeLocation, 0)
+			}
+			edge.To = c.parse(node, abstractType.TypeChildren, nodeLocation)
+			if edge.To == nil {
+				continue
+			}
+			if index := edge.To.Integer; index < 0 || index >= int64(value.NodeCount) {
+				c.add(nodeLocation, abstractType.TypeChildren, node, "neighbor of node %d is out of range [0, %d)", u, value.NodeCount)
+				continue
+			}
+			value.Edges = append(value.Edges, edge)
+		}
+	}
+}
+
+func (c *issueCollector) parseWeight(raw interface{}, weightType *model.AbstractType, location *location) *Value {
+	before := len(c.issues)
+	weight := c.parseAtomic(raw, weightType, location)
+	for i := before; i < len(c.issues); i++ {
+		c.issues[i].Message = "invalid edge weight: " + c.issues[i].Message
+	}
+	return weight
+}
+
+// Format writes the value back in canonical form: JSON with ", " separators and JSON string literals
+func (v *Value) Format() string {
+	var buf bytes.Buffer
+	v.writeTo(&buf)
+	return buf.String()
+}
+
+func (v *Value) writeTo(buf *bytes.Buffer) {
+	if v.Null {
+		buf.WriteString("null")
+		return
+	}
+	switch v.Type.Type {
+	case string(model.Integer):
+		buf.WriteString(strconv.FormatInt(v.Integer, 10))
+	case string(model.Double):
+		buf.WriteString(strconv.FormatFloat(v.Double, 'f', -1, 64))
+	case string(model.Boolean):
+		buf.WriteString(strconv.FormatBool(v.Boolean))
+	case string(model.String):
+		buf.WriteString(EncodeString(v.String))
+	case string(model.Graph):
+		v.writeGraphTo(buf)
+	default:
+		writeList(buf, len(v.Elements), func(i int) { v.Elements[i].writeTo(buf) })
+	}
+}
+
+func (v *Value) writeGraphTo(buf *bytes.Buffer) {
+	writeEdge := func(values ...*Value) {
+		writeList(buf, len(values), func(i int) { values[i].writeTo(buf) })
+	}
+	if v.Type.GetGraphFormat() != model.AdjacencyList {
+		writeList(buf, len(v.Edges), func(i int) {
+			edge := v.Edges[i]
+			if edge.Weight != nil {
+				writeEdge(edge.From, edge.To, edge.Weight)
+			} else {
+				writeEdge(edge.From, edge.To)
+			}
+		})
+		return
+	}
+	rows := v.adjacencyRows()
+	writeList(buf, len(rows), func(u int) {
+		writeList(buf, len(rows[u]), func(j int) {
+			edge := rows[u][j]
+			if edge.Weight != nil {
+				writeEdge(edge.To, edge.Weight)
+			} else {
+				edge.To.writeTo(buf)
+			}
+		})
+	})
+}
+
+// adjacencyRows groups the arcs of an adjacency list Graph back by their source node
+func (v *Value) adjacencyRows() [][]Edge {
+	rows := make([][]Edge, v.NodeCount)
+	for _, edge := range v.Edges {
+		rows[edge.From.Integer] = append(rows[edge.From.Integer], edge)
+	}
+	return rows
+}
+
+func writeList(buf *bytes.Buffer, length int, writeElement func(i int)) {
+	buf.WriteByte('[')
+	for i := 0; i < length; i++ {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		writeElement(i)
+	}
+	buf.WriteByte(']')
+}
+
+// Interface returns the value as plain Go data (int64, float64, string, bool, nil and []interface{}),
+// in the same shape as its JSON form
+func (v *Value) Interface() interface{} {
+	if v.Null {
+		return nil
+	}
+	switch v.Type.Type {
+	case string(model.Integer):
+		return v.Integer
+	case string(model.Double):
+		return v.Double
+	case string(model.Boolean):
+		return v.Boolean
+	case string(model.String):
+		return v.String
+	case string(model.Graph):
+		if v.Type.GetGraphFormat() == model.AdjacencyList {
+			rows := v.adjacencyRows()
+			result := make([]interface{}, len(rows))
+			for u, row := range rows {
+				neighbors := make([]interface{}, len(row))
+				for j, edge := range row {
+					neighbors[j] = edge.To.Interface()
+					if edge.Weight != nil {
+						neighbors[j] = []interface{}{edge.To.Interface(), edge.Weight.Interface()}
+					}
+				}
+				result[u] = neighbors
+			}
+			return result
+		}
+		result := make([]interface{}, len(v.Edges))
+		for i, edge := range v.Edges {
+			tuple := []interface{}{edge.From.Interface(), edge.To.Interface()}
+			if edge.Weight != nil {
+				tuple = append(tuple, edge.Weight.Interface())
+			}
+			result[i] = tuple
+		}
+		return result
+	default:
+		result := make([]interface{}, len(v.Elements))
+		for i, elem := range v.Elements {
+			result[i] = elem.Interface()
+		}
+		return result
+	}
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// CategoryRepositoryInterface stores the taxonomy questions are categorized by
+type CategoryRepositoryInterface interface {
+	ListCategories() ([]model.Category, error)                       // Sorted by name
+	CreateCategory(category model.Category) (*model.Category, error) // Fails with 400 when the name is taken
+	DeleteCategory(name string) error
+}
+
+type CategoryRepository struct {
+	collection *mongo.Collection
+}
+
+// NewCategoryRepository creates a new CategoryRepository with the provided MongoDB database.
+func NewCategoryRepository(db *mongo.Database) *CategoryRepository {
+	return &CategoryRepository{
+		collection: db.Collection("categories"),
+	}
+}
+
+// ListCategories returns every category of the taxonomy
+func (r *CategoryRepository) ListCategories() ([]model.Category, error) {
+	ctx := context.Background()
+	cursor, err := r.collection.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	categories := []model.Category{}
+	if err := cursor.All(ctx, &categories); err != nil {
+		return nil, err
+	}
+	return categories, nil
+}
+
+// CreateCategory inserts a category, its name is its ID
+func (r *CategoryRepository) CreateCategory(category model.Category) (*model.Category, error) {
+	_, err := r.collection.InsertOne(context.Background(), category)
+	if mongo.IsDuplicateKeyError(err) {
+		return nil, model.NewCustomError(400, "Category already exists: "+category.Name)
+	} else if err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+// DeleteCategory deletes a category by its name
+func (r *CategoryRepository) DeleteCategory(name string) error {
+	result, err := r.collection.DeleteOne(context.Background(), bson.M{"_id": name})
+	if err != nil {
+		return model.ErrInternal
+	}
+	if result.DeletedCount == 0 {
+		return model.NewCustomError(404, "Category not found: "+name)
+	}
+	return nil
+}
+
+// EnsureCategories adds the named top-level categories the taxonomy is missing, ignoring case so a category
+// is never added twice in different cases. It is safe to call on every startup.
+func (r *CategoryRepository) EnsureCategories(names []string) error {
+	categories, err := r.ListCategories()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	for _, name := range names {
+		if _, found := model.FindCategory(categories, name); found {
+			continue
+		}
+		category := model.Category{Name: name}
+		_, err := r.collection.UpdateOne(ctx, bson.M{"_id": name}, bson.M{"$setOnInsert": category}, options.Update().SetUpsert(true))
+		if err != nil {
+			return err
+		}
+		categories = append(categories, category)
+	}
+	return nil
+}
@@ -0,0 +1,312 @@
+package repository
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Difficulties in sort order, Mongo would sort them alphabetically
+var difficultyOrder = bson.A{"Easy", "Medium", "Hard"}
+
+// Titles and categories sort and match case-insensitively
+var caseInsensitive = &options.Collation{Locale: "en", Strength: 2}
+
+// pageToken is the position of the next page, encoded as opaque base64 JSON
+type pageToken struct {
+	Offset int64 `json:"offset"`
+}
+
+func encodePageToken(token pageToken) string {
+	data, _ := json.Marshal(token)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodePageToken(encoded string) (pageToken, error) {
+	var token pageToken
+	if encoded == "" {
+		return token, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err == nil {
+		err = json.Unmarshal(data, &token)
+	}
+	if err != nil || token.Offset < 0 {
+		return pageToken{}, model.NewCustomError(400, "invalid page token")
+	}
+	return token, nil
+}
+
+// questionFilter translates the search, category, difficulty, tag and company filters of params into a Mongo filter,
+// of the questions in the trash or of the live ones
+func questionFilter(params model.QuestionQueryParams, search searchQuery) bson.M {
+	filter := bson.M{}
+	if !search.isEmpty() {
+		filter = search.filter()
+	}
+	if categories := nonEmpty(params.Categories); len(categories) > 0 {
+		filter["categories"] = bson.M{"$in": categories}
+	}
+	if tags := nonEmpty(params.Tags); len(tags) > 0 {
+		if strings.ToLower(params.TagMatch) == "all" {
+			filter["tags"] = bson.M{"$all": tags}
+		} else {
+			filter["tags"] = bson.M{"$in": tags}
+		}
+	}
+	if companies := nonEmpty(params.Companies); len(companies) > 0 {
+		filter["companies"] = bson.M{"$in": companies}
+	}
+	if difficulties := nonEmpty(params.Difficulties); len(difficulties) > 0 {
+		filter["difficulty"] = bson.M{"$in": difficulties}
+	}
+	filter["deleted_at"] = bson.M{"$exists": params.Trashed}
+	return filter
+}
+
+// questionSort translates the sort key and order of params into a Mongo sort, ties broken by _id so pages are stable.
+// Text searches sort by relevance unless another key is asked for, most relevant first.
+func questionSort(params model.QuestionQueryParams, search searchQuery) bson.D {
+	sortBy := params.SortBy
+	if sortBy == "" && search.usesTextIndex() {
+		sortBy = "relevance"
+	}
+	direction := 1
+	if strings.ToLower(params.SortOrder) == "desc" {
+		direction = -1
+	}
+	field := "title"
+	switch sortBy {
+	case "category", "deleted_at":
+		field = sortBy
+	case "difficulty":
+		field = "difficulty_rank"
+	case "relevance":
+		if search.usesTextIndex() {
+			// Ascending relevance makes little sense, the order only applies to ties
+			return bson.D{{Key: "score", Value: bson.M{"$meta": "textScore"}}, {Key: "_id", Value: direction}}
+		}
+	default:
+		if statsField, ok := model.StatsSortFields[sortBy]; ok {
+			field = statsField
+		}
+	}
+	return bson.D{{Key: field, Value: direction}, {Key: "_id", Value: direction}}
+}
+
+// questionProjection keeps the selected fields of the listed questions, and the searched ones for highlighting
+func questionProjection(fields []string, search searchQuery) bson.M {
+	projection := bson.M{"_id": 1}
+	for _, field := range fields {
+		if field != "id" {
+			projection[field] = 1
+		}
+	}
+	if !search.isEmpty() {
+		for _, field := range searchFields {
+			projection[field.Key] = 1
+		}
+	}
+	return projection
+}
+
+func nonEmpty(values []string) []string {
+	var kept []string
+	for _, value := range values {
+		if value != "" {
+			kept = append(kept, value)
+		}
+	}
+	return kept
+}
+
+// ListQuestions returns the page of questions matching params, with the total count and the next page token.
+func (r *QuestionRepository) ListQuestions(params model.QuestionQueryParams) (*model.QuestionPage, error) {
+	token, err := decodePageToken(params.PageToken)
+	if err != nil {
+		return nil, err
+	}
+	limit := int64(params.Limit)
+	if limit <= 0 {
+		limit = model.DefaultPageSize
+	}
+
+	ctx := context.Background()
+	search := parseSearchQuery(params.Search)
+	filter := questionFilter(params, search)
+	// Text searches only run with the simple collation
+	countOptions, aggregateOptions := options.Count(), options.Aggregate()
+	if !search.usesTextIndex() {
+		countOptions.SetCollation(caseInsensitive)
+		aggregateOptions.SetCollation(caseInsensitive)
+	}
+	total, err := r.collection.CountDocuments(ctx, filter, countOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$addFields", Value: bson.M{"difficulty_rank": bson.M{"$indexOfArray": bson.A{difficultyOrder, "$difficulty"}}}}},
+		{{Key: "$sort", Value: questionSort(params, search)}},
+		{{Key: "$skip", Value: token.Offset}},
+		{{Key: "$limit", Value: limit}},
+		{{Key: "$project", Value: questionProjection(params.Fields, search)}},
+	}
+	cursor, err := r.collection.Aggregate(ctx, pipeline, aggregateOptions)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var questions []model.Question
+	if err := cursor.All(ctx, &questions); err != nil {
+		return nil, err
+	}
+	page := &model.QuestionPage{Questions: []model.QuestionFields{}, Total: total}
+	if next := token.Offset + int64(len(questions)); next < total && len(questions) > 0 {
+		page.NextPageToken = encodePageToken(pageToken{Offset: next})
+	}
+	if !search.isEmpty() {
+		page.Highlights = map[string][]model.SearchHighlight{}
+	}
+	for _, question := range questions {
+		if page.Highlights != nil {
+			page.Highlights[question.ID.Hex()] = search.highlight(question)
+		}
+		fields, err := question.SelectFields(params.Fields)
+		if err != nil {
+			return nil, err
+		}
+		page.Questions = append(page.Questions, fields)
+	}
+	return page, nil
+}
+
+func textIndexKeys() bson.D {
+	keys := bson.D{}
+	for _, field := range searchFields {
+		keys = append(keys, bson.E{Key: field.Key, Value: "text"})
+	}
+	return keys
+}
+
+// EnsureIndexes creates the indexes the question queries rely on, it is safe to call on every startup.
+// They share the collation of the queries, which Mongo requires to use them.
+func (r *QuestionRepository) EnsureIndexes() error {
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "title", Value: 1}}, Options: options.Index().SetCollation(caseInsensitive)},
+		{Keys: bson.D{{Key: "category", Value: 1}, {Key: "difficulty", Value: 1}}, Options: options.Index().SetCollation(caseInsensitive)},
+		{Keys: bson.D{{Key: "difficulty", Value: 1}}, Options: options.Index().SetCollation(caseInsensitive)},
+		{Keys: bson.D{{Key: "stats.attempts", Value: -1}}, Options: options.Index().SetCollation(caseInsensitive)},
+		{Keys: bson.D{{Key: "stats.acceptance_rate", Value: -1}}, Options: options.Index().SetCollation(caseInsensitive)},
+		{Keys: bson.D{{Key: "stats.unique_solvers", Value: -1}}, Options: options.Index().SetCollation(caseInsensitive)},
+		{Keys: bson.D{{Key: "stats.median_runtime_ms", Value: 1}}, Options: options.Index().SetCollation(caseInsensitive)},
+		{Keys: bson.D{{Key: "deleted_at", Value: 1}}, Options: options.Index().SetSparse(true)},
+		{Keys: bson.D{{Key: "categories", Value: 1}}, Options: options.Index().SetCollation(caseInsensitive)},
+		{Keys: bson.D{{Key: "tags", Value: 1}}, Options: options.Index().SetCollation(caseInsensitive)},
+		{Keys: bson.D{{Key: "companies", Value: 1}}, Options: options.Index().SetCollation(caseInsensitive)},
+		// Text indexes only support the simple collation
+		{Keys: textIndexKeys(), Options: options.Index().SetName("question_text").SetWeights(searchFields).SetDefaultLanguage("english")},
+	}
+	if err := r.dropStaleTextIndex(); err != nil {
+		return err
+	}
+	if _, err := r.collection.Indexes().CreateMany(context.Background(), indexes); err != nil {
+		return err
+	}
+	_, err := r.solvers.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys:    bson.D{{Key: "question_id", Value: 1}, {Key: "solver", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	return err
+}
+
+// dropStaleTextIndex drops the text index when it covers other fields than searchFields, as it did before
+// categories and companies were searched. A collection has a single text index, so it is created anew.
+func (r *QuestionRepository) dropStaleTextIndex() error {
+	ctx := context.Background()
+	cursor, err := r.collection.Indexes().List(ctx)
+	if err != nil {
+		return err
+	}
+	var indexes []bson.M
+	if err := cursor.All(ctx, &indexes); err != nil {
+		return err
+	}
+	for _, index := range indexes {
+		if index["name"] != "question_text" {
+			continue
+		}
+		weights, _ := index["weights"].(bson.M)
+		stale := len(weights) != len(searchFields)
+		for _, field := range searchFields {
+			if fmt.Sprint(weights[field.Key]) != fmt.Sprint(field.Value) {
+				stale = true
+			}
+		}
+		if stale {
+			_, err := r.collection.Indexes().DropOne(ctx, "question_text")
+			return err
+		}
+	}
+	return nil
+}
+
+// CategorySetCount is the number of questions in exactly one set of categories
+type CategorySetCount struct {
+	Categories []string `bson:"_id"`
+	Count      int64    `bson:"count"`
+}
+
+// CountCategorySets counts the live questions, and the trashed ones with includeTrashed, of every set of categories
+// in use, from which the questions of any category, with or without its topics, can be counted without counting a question twice
+func (r *QuestionRepository) CountCategorySets(includeTrashed bool) ([]CategorySetCount, error) {
+	ctx := context.Background()
+	filter := bson.M{}
+	if !includeTrashed {
+		filter = live(filter)
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$group", Value: bson.M{"_id": "$categories", "count": bson.M{"$sum": 1}}}},
+	}
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	counts := []CategorySetCount{}
+	if err := cursor.All(ctx, &counts); err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// MigrateCategories gives the questions stored before they had several categories their single category as
+// their categories, and returns every category in use so the taxonomy can include them. It is safe to call on every startup.
+func (r *QuestionRepository) MigrateCategories() ([]string, error) {
+	ctx := context.Background()
+	filter := bson.M{"categories": bson.M{"$in": bson.A{nil, bson.A{}}}, "category": bson.M{"$nin": bson.A{nil, ""}}}
+	update := mongo.Pipeline{{{Key: "$set", Value: bson.M{"categories": bson.A{"$category"}}}}}
+	if _, err := r.collection.UpdateMany(ctx, filter, update); err != nil {
+		return nil, err
+	}
+	inUse, err := r.collection.Distinct(ctx, "categories", bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	categories := []string{}
+	for _, category := range inUse {
+		if name, ok := category.(string); ok && name != "" {
+			categories = append(categories, name)
+		}
+	}
+	return categories, nil
+}
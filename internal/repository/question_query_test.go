@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/model"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestPageToken(t *testing.T) {
+	token, err := decodePageToken(encodePageToken(pageToken{Offset: 40}))
+	if err != nil || token.Offset != 40 {
+		t.Errorf("expected the token to round trip, got %+v %v", token, err)
+	}
+	if token, err := decodePageToken(""); err != nil || token.Offset != 0 {
+		t.Errorf("expected no token to start at the first page, got %+v %v", token, err)
+	}
+	for _, encoded := range []string{"not base64!", encodePageToken(pageToken{Offset: -1}), "bm90IGpzb24"} {
+		if _, err := decodePageToken(encoded); err == nil {
+			t.Errorf("%q: expected an invalid page token", encoded)
+		}
+	}
+}
+
+func TestQuestionFilter(t *testing.T) {
+	params := model.QuestionQueryParams{
+		Categories:   []string{"Array", ""},
+		Difficulties: []string{"Easy"},
+		Tags:         []string{"dp"},
+		Companies:    []string{"Acme"},
+	}
+	expected := bson.M{
+		"categories": bson.M{"$in": []string{"Array"}},
+		"difficulty": bson.M{"$in": []string{"Easy"}},
+		"tags":       bson.M{"$in": []string{"dp"}},
+		"companies":  bson.M{"$in": []string{"Acme"}},
+		"deleted_at": bson.M{"$exists": false},
+	}
+	if filter := questionFilter(params, searchQuery{}); !reflect.DeepEqual(filter, expected) {
+		t.Errorf("expected %v, got %v", expected, filter)
+	}
+
+	expected = bson.M{"$text": bson.M{"$search": `two "binary search"`}, "difficulty": bson.M{"$in": []string{"Easy"}}, "deleted_at": bson.M{"$exists": false}}
+	if filter := questionFilter(model.QuestionQueryParams{Difficulties: []string{"Easy"}}, parseSearchQuery(`two "binary search"`)); !reflect.DeepEqual(filter, expected) {
+		t.Errorf("expected %v, got %v", expected, filter)
+	}
+
+	params = model.QuestionQueryParams{Tags: []string{"dp", "greedy"}, TagMatch: "all"}
+	if filter := questionFilter(params, searchQuery{}); !reflect.DeepEqual(filter["tags"], bson.M{"$all": []string{"dp", "greedy"}}) {
+		t.Errorf("expected questions with all the tags, got %v", filter)
+	}
+
+	params = model.QuestionQueryParams{Categories: []string{""}, Trashed: true}
+	expected = bson.M{"deleted_at": bson.M{"$exists": true}}
+	if filter := questionFilter(params, searchQuery{}); !reflect.DeepEqual(filter, expected) {
+		t.Errorf("expected empty values not to filter the trash, got %v", filter)
+	}
+}
+
+func TestQuestionSort(t *testing.T) {
+	text := parseSearchQuery("two sum")
+	cases := []struct {
+		params model.QuestionQueryParams
+		search searchQuery
+		sort   bson.D
+	}{
+		{model.QuestionQueryParams{}, searchQuery{}, bson.D{{Key: "title", Value: 1}, {Key: "_id", Value: 1}}},
+		{model.QuestionQueryParams{SortBy: "difficulty", SortOrder: "DESC"}, searchQuery{}, bson.D{{Key: "difficulty_rank", Value: -1}, {Key: "_id", Value: -1}}},
+		{model.QuestionQueryParams{SortBy: "stats"}, searchQuery{}, bson.D{{Key: "stats.attempts", Value: 1}, {Key: "_id", Value: 1}}},
+		{model.QuestionQueryParams{SortBy: "median_runtime", SortOrder: "desc"}, searchQuery{}, bson.D{{Key: "stats.median_runtime_ms", Value: -1}, {Key: "_id", Value: -1}}},
+		{model.QuestionQueryParams{SortBy: "unknown"}, searchQuery{}, bson.D{{Key: "title", Value: 1}, {Key: "_id", Value: 1}}},
+		{model.QuestionQueryParams{}, text, bson.D{{Key: "score", Value: bson.M{"$meta": "textScore"}}, {Key: "_id", Value: 1}}},
+		{model.QuestionQueryParams{SortBy: "title"}, text, bson.D{{Key: "title", Value: 1}, {Key: "_id", Value: 1}}},
+		// Relevance needs the text index, prefixes alone sort by title
+		{model.QuestionQueryParams{SortBy: "relevance"}, parseSearchQuery("sort*"), bson.D{{Key: "title", Value: 1}, {Key: "_id", Value: 1}}},
+	}
+	for _, c := range cases {
+		if sort := questionSort(c.params, c.search); !reflect.DeepEqual(sort, c.sort) {
+			t.Errorf("%+v: expected %v, got %v", c.params, c.sort, sort)
+		}
+	}
+}
+
+func TestQuestionProjection(t *testing.T) {
+	expected := bson.M{"_id": 1, "title": 1, "stats": 1}
+	if projection := questionProjection([]string{"id", "title", "stats"}, searchQuery{}); !reflect.DeepEqual(projection, expected) {
+		t.Errorf("expected %v, got %v", expected, projection)
+	}
+	// Searches also read the searched fields, to highlight them
+	projection := questionProjection([]string{"id"}, parseSearchQuery("sum"))
+	for _, field := range searchFields {
+		if projection[field.Key] != 1 {
+			t.Errorf("expected a search to project %s, got %v", field.Key, projection)
+		}
+	}
+}
@@ -2,29 +2,48 @@ package repository
 
 import (
 	"context"
+	"reflect"
+	"strings"
+	"time"
+
 	"github.com/TehilaTheStudent/SkillCode-backend/internal/model"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 // Define the interface for basic CRUD operations
 type QuestionRepositoryInterface interface {
 	CreateQuestion(question model.Question) (*model.Question, error) // Return the ID as a string
 	GetQuestionByID(id primitive.ObjectID) (*model.Question, error)
-	GetAllQuestions() ([]model.Question, error)
-	UpdateQuestion(id primitive.ObjectID, question model.Question) (bool, error) // Return success status
-	DeleteQuestion(id primitive.ObjectID) (bool, error)                          // Return success status
+	GetQuestionByTitle(title string) (*model.Question, error)                                  // Nil without error when no live question has the title
+	ExportQuestions(visit func(model.Question) error) error                                    // Visits every live question by title, stops at the first error
+	ListQuestions(params model.QuestionQueryParams) (*model.QuestionPage, error)               // Filtered, sorted and paginated in Mongo
+	UpdateQuestion(id primitive.ObjectID, question model.Question, revision int) (bool, error) // Only while the stored question is at revision, 412 otherwise
+	DeleteQuestion(id primitive.ObjectID) (bool, error)                                        // Moves the question to the trash
+	RestoreQuestion(id primitive.ObjectID) (*model.Question, error)                            // Moves the question out of the trash
+	PurgeTrash(deletedBefore time.Time) ([]primitive.ObjectID, error)                          // Permanently deletes the questions trashed before deletedBefore
+	CountCategorySets(includeTrashed bool) ([]CategorySetCount, error)                         // Of the live questions, and the trashed ones with includeTrashed
+	RecordSubmission(id primitive.ObjectID, submission model.GradedSubmission) error              // Counts a graded submission in the stats of the question
+}
+
+// live restricts filter to the questions that are not in the trash
+func live(filter bson.M) bson.M {
+	filter["deleted_at"] = bson.M{"$exists": false}
+	return filter
 }
 
 type QuestionRepository struct {
 	collection *mongo.Collection
+	solvers    *mongo.Collection // Who solved which question, for the unique solvers of its stats
 }
 
 // NewQuestionRepository creates a new QuestionRepository with the provided MongoDB database.
 func NewQuestionRepository(db *mongo.Database) *QuestionRepository {
 	return &QuestionRepository{
 		collection: db.Collection("questions"),
+		solvers:    db.Collection("question_solvers"),
 	}
 }
 
@@ -34,7 +53,7 @@ func NewQuestionRepository(db *mongo.Database) *QuestionRepository {
 func (r *QuestionRepository) CreateQuestion(question model.Question) (*model.Question, error) {
 	// Check if a question with the same title already exists
 	var existingQuestion model.Question
-	err := r.collection.FindOne(context.Background(), bson.M{"title": question.Title}).Decode(&existingQuestion)
+	err := r.collection.FindOne(context.Background(), live(bson.M{"title": question.Title})).Decode(&existingQuestion)
 	if err == nil {
 		return nil, model.NewCustomError(400, "Question with the same title already exists")
 	} else if err != mongo.ErrNoDocuments {
@@ -57,7 +76,7 @@ func (r *QuestionRepository) CreateQuestion(question model.Question) (*model.Que
 // GetQuestionByID retrieves a question from the database by its ID.
 func (r *QuestionRepository) GetQuestionByID(id primitive.ObjectID) (*model.Question, error) {
 	var question model.Question
-	err := r.collection.FindOne(context.Background(), bson.M{"_id": id}).Decode(&question)
+	err := r.collection.FindOne(context.Background(), live(bson.M{"_id": id})).Decode(&question)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			// Return the custom "not found" error
@@ -69,58 +88,120 @@ func (r *QuestionRepository) GetQuestionByID(id primitive.ObjectID) (*model.Ques
 	return &question, err
 }
 
-// GetAllQuestions retrieves all questions from the database.
-func (r *QuestionRepository) GetAllQuestions() ([]model.Question, error) {
-	var questions []model.Question
-	cursor, err := r.collection.Find(context.Background(), bson.M{})
-	if err != nil {
+// GetQuestionByTitle retrieves the live question with the title, nil when there is none.
+func (r *QuestionRepository) GetQuestionByTitle(title string) (*model.Question, error) {
+	var question model.Question
+	err := r.collection.FindOne(context.Background(), live(bson.M{"title": title})).Decode(&question)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	} else if err != nil {
 		return nil, err
 	}
-	defer cursor.Close(context.Background())
+	return &question, nil
+}
 
-	for cursor.Next(context.Background()) {
+// ExportQuestions reads every live question sorted by title, one at a time, so the bank is never held in memory whole.
+func (r *QuestionRepository) ExportQuestions(visit func(model.Question) error) error {
+	ctx := context.Background()
+	cursor, err := r.collection.Find(ctx, live(bson.M{}), options.Find().SetSort(bson.D{{Key: "title", Value: 1}, {Key: "_id", Value: 1}}))
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+	for cursor.Next(ctx) {
 		var question model.Question
 		if err := cursor.Decode(&question); err != nil {
-			return nil, err
+			return err
+		}
+		if err := visit(question); err != nil {
+			return err
 		}
-		questions = append(questions, question)
 	}
-	return questions, nil
+	return cursor.Err()
 }
 
-// UpdateQuestion updates an existing question in the database by its ID.
-func (r *QuestionRepository) UpdateQuestion(id primitive.ObjectID, question model.Question) (bool, error) {
+// UpdateQuestion updates an existing question in the database by its ID, if it is still at revision.
+// The check and the update are one operation, so of two concurrent updates of the same revision one fails.
+func (r *QuestionRepository) UpdateQuestion(id primitive.ObjectID, question model.Question, revision int) (bool, error) {
 	// Check if another question with the same title already exists
 	var existingQuestion model.Question
-	err := r.collection.FindOne(context.Background(), bson.M{"title": question.Title, "_id": bson.M{"$ne": id}}).Decode(&existingQuestion)
+	err := r.collection.FindOne(context.Background(), live(bson.M{"title": question.Title, "_id": bson.M{"$ne": id}})).Decode(&existingQuestion)
 	if err == nil {
 		return false, model.NewCustomError(400, "Another question with the same title already exists")
 	} else if err != mongo.ErrNoDocuments {
 		return false, err
 	}
 
-	updateResult, err := r.collection.UpdateOne(context.Background(), bson.M{"_id": id}, bson.M{"$set": question})
+	filter := live(bson.M{"_id": id, "revision": revision})
+	if revision == 0 {
+		// Questions created before revisions have no revision field
+		filter["revision"] = bson.M{"$in": bson.A{0, nil}}
+	}
+	update, err := questionUpdate(question)
+	if err != nil {
+		return false, err
+	}
+	updateResult, err := r.collection.UpdateOne(context.Background(), filter, update)
 	if err != nil {
 		return false, model.ErrInternal
 	}
 
-	// Check if no document was matched
+	// Check if no document was matched, because it does not exist or was changed since revision
 	if updateResult.MatchedCount == 0 {
+		count, err := r.collection.CountDocuments(context.Background(), live(bson.M{"_id": id}))
+		if err != nil {
+			return false, err
+		}
+		if count > 0 {
+			return false, model.NewCustomError(412, "Question was changed by someone else, reload it and retry")
+		}
 		return false, model.NewCustomError(404, "Question not found with ID: "+id.Hex())
 	}
 
 	return true, nil
 }
 
-// DeleteQuestion deletes a question from the database by its ID.
+// Top-level fields an update leaves alone, the stats are left to the submissions and the trash to DeleteQuestion
+var untouchedFields = map[string]bool{"_id": true, "stats": true, "deleted_at": true}
+
+// questionUpdate sets the fields of question and unsets the ones it leaves out, such as a removed checker
+func questionUpdate(question model.Question) (bson.M, error) {
+	document, err := bson.Marshal(question)
+	if err != nil {
+		return nil, err
+	}
+	var fields bson.M
+	if err := bson.Unmarshal(document, &fields); err != nil {
+		return nil, err
+	}
+	unset := bson.M{}
+	questionType := reflect.TypeOf(question)
+	for i := 0; i < questionType.NumField(); i++ {
+		key, _, _ := strings.Cut(questionType.Field(i).Tag.Get("bson"), ",")
+		if _, ok := fields[key]; !ok && key != "" && key != "-" {
+			unset[key] = ""
+		}
+	}
+	for key := range untouchedFields {
+		delete(fields, key)
+		delete(unset, key)
+	}
+	update := bson.M{"$set": fields}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+	return update, nil
+}
+
+// DeleteQuestion moves a question to the trash by its ID, it is left out of every read until restored or purged.
 func (r *QuestionRepository) DeleteQuestion(id primitive.ObjectID) (bool, error) {
-	deleteResult, err := r.collection.DeleteOne(context.Background(), bson.M{"_id": id})
+	updateResult, err := r.collection.UpdateOne(context.Background(), live(bson.M{"_id": id}), bson.M{"$set": bson.M{"deleted_at": time.Now().UTC()}})
 	if err != nil {
 		return false, model.ErrInternal
 	}
 
 	// Check if no document was deleted
-	if deleteResult.DeletedCount == 0 {
+	if updateResult.MatchedCount == 0 {
 		return false, model.NewCustomError(404, "Question not found with ID: "+id.Hex())
 	}
 
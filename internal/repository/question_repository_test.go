@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/model"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// applyUpdate applies the $set and $unset of update to the stored document, as Mongo would
+func applyUpdate(t *testing.T, stored model.Question, update bson.M) model.Question {
+	data, err := bson.Marshal(stored)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var document bson.M
+	if err := bson.Unmarshal(data, &document); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for key, value := range update["$set"].(bson.M) {
+		document[key] = value
+	}
+	if unset, ok := update["$unset"].(bson.M); ok {
+		for key := range unset {
+			delete(document, key)
+		}
+	}
+	data, err = bson.Marshal(document)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var updated model.Question
+	if err := bson.Unmarshal(data, &updated); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return updated
+}
+
+func TestQuestionUpdateRemovesOmittedFields(t *testing.T) {
+	deletedAt := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	stored := model.Question{
+		Title:     "Topological order",
+		Category:  "Graph",
+		Tags:      []string{"dag"},
+		Checker:   &model.Checker{Language: model.Python, Code: "def check(inputs, expected, actual): return True, ''"},
+		Stats:     model.QuestionStats{Attempts: 7},
+		Revision:  3,
+		DeletedAt: &deletedAt,
+	}
+	patched, err := stored.ApplyMergePatch([]byte(`{"checker": null, "tags": null}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	patched.Stats, patched.DeletedAt = model.QuestionStats{}, nil
+	update, err := questionUpdate(*patched)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	updated := applyUpdate(t, stored, update)
+	if updated.Checker != nil || updated.Tags != nil {
+		t.Errorf("expected the checker and tags to be removed, got %+v and %v", updated.Checker, updated.Tags)
+	}
+	if updated.Title != stored.Title || updated.Revision != stored.Revision {
+		t.Errorf("expected the other fields to be kept, got %+v", updated)
+	}
+	if updated.Stats.Attempts != 7 || updated.DeletedAt == nil || !updated.DeletedAt.Equal(deletedAt) {
+		t.Errorf("expected the stats and deleted_at to be left alone, got %+v and %v", updated.Stats, updated.DeletedAt)
+	}
+}
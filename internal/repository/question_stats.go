@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// RecordSubmission counts a graded submission in the stats of a live question, in a single update that also
+// derives the acceptance rates and the median runtime, so concurrent submissions never leave them stale.
+func (r *QuestionRepository) RecordSubmission(id primitive.ObjectID, submission model.GradedSubmission) error {
+	ctx := context.Background()
+	firstSolve := false
+	if submission.Accepted && submission.Solver != "" {
+		// The first accepted submission of a solver is the only one inserted
+		_, err := r.solvers.InsertOne(ctx, bson.M{"question_id": id, "solver": submission.Solver, "solved_at": time.Now().UTC()})
+		if err == nil {
+			firstSolve = true
+		} else if !mongo.IsDuplicateKeyError(err) {
+			return err
+		}
+	}
+	result, err := r.collection.UpdateOne(ctx, live(bson.M{"_id": id}), submissionUpdate(submission, firstSolve))
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return model.NewCustomError(404, "Question not found with ID: "+id.Hex())
+	}
+	return nil
+}
+
+// submissionUpdate is the update pipeline counting submission in the stats, and the solver when firstSolve.
+// Stats that are not a document, such as the bare number questions had before, start over.
+func submissionUpdate(submission model.GradedSubmission, firstSolve bool) mongo.Pipeline {
+	accepted, solvers := 0, 0
+	if submission.Accepted {
+		accepted = 1
+	}
+	if firstSolve {
+		solvers = 1
+	}
+	language := "stats.languages." + submission.Language
+	counters := bson.M{
+		"stats.attempts":       increment("stats.attempts", 1),
+		"stats.accepted":       increment("stats.accepted", accepted),
+		"stats.unique_solvers": increment("stats.unique_solvers", solvers),
+		language + ".attempts": increment(language+".attempts", 1),
+		language + ".accepted": increment(language+".accepted", accepted),
+	}
+	if submission.Accepted && submission.RuntimeMs != nil {
+		bucket := "stats.runtime_histogram." + strconv.FormatInt(model.RuntimeBucket(*submission.RuntimeMs), 10)
+		counters[bucket] = increment(bucket, 1)
+	}
+	return mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{"stats": bson.M{"$cond": bson.A{bson.M{"$eq": bson.A{bson.M{"$type": "$stats"}, "object"}}, "$stats", bson.M{}}}}}},
+		{{Key: "$set", Value: counters}},
+		{{Key: "$set", Value: bson.M{
+			"stats.acceptance_rate":       bson.M{"$divide": bson.A{"$stats.accepted", "$stats.attempts"}},
+			language + ".acceptance_rate": bson.M{"$divide": bson.A{"$" + language + ".accepted", "$" + language + ".attempts"}},
+			"stats.median_runtime_ms":     bson.M{"$ifNull": bson.A{histogramMedian("$stats.runtime_histogram"), "$$REMOVE"}},
+		}}},
+	}
+}
+
+// increment adds by to the counter at path, which starts at 0
+func increment(path string, by int) bson.M {
+	return bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$" + path, 0}}, by}}
+}
+
+// histogramMedian is the expression of the bucket holding the median of the runtime histogram at field,
+// null when it is empty
+func histogramMedian(field string) bson.M {
+	buckets := bson.M{"$filter": bson.M{
+		"input": bson.M{"$map": bson.M{
+			"input": bson.M{"$objectToArray": bson.M{"$ifNull": bson.A{field, bson.M{}}}},
+			"in": bson.M{
+				"bucket": bson.M{"$convert": bson.M{"input": "$$this.k", "to": "long", "onError": nil}},
+				"count":  "$$this.v",
+			},
+		}},
+		"cond": bson.M{"$and": bson.A{bson.M{"$ne": bson.A{"$$this.bucket", nil}}, bson.M{"$gt": bson.A{"$$this.count", 0}}}},
+	}}
+	return bson.M{"$let": bson.M{
+		"vars": bson.M{"buckets": bson.M{"$sortArray": bson.M{"input": buckets, "sortBy": bson.M{"bucket": 1}}}},
+		"in": bson.M{"$let": bson.M{
+			"vars": bson.M{"total": bson.M{"$sum": "$$buckets.count"}},
+			"in": bson.M{"$getField": bson.M{"field": "median", "input": bson.M{"$reduce": bson.M{
+				"input":        "$$buckets",
+				"initialValue": bson.M{"cumulative": 0, "median": nil},
+				"in": bson.M{
+					"cumulative": bson.M{"$add": bson.A{"$$value.cumulative", "$$this.count"}},
+					"median": bson.M{"$cond": bson.A{
+						bson.M{"$and": bson.A{
+							bson.M{"$eq": bson.A{"$$value.median", nil}},
+							bson.M{"$gte": bson.A{bson.M{"$multiply": bson.A{bson.M{"$add": bson.A{"$$value.cumulative", "$$this.count"}}, 2}}, "$$total"}},
+						}},
+						"$$this.bucket",
+						"$$value.median",
+					}},
+				},
+			}}}},
+		}},
+	}}
+}
+
+// MigrateStats replaces the bare submission counters of the questions stored before they had stats with empty stats,
+// which the submissions can increment. It is safe to call on every startup.
+func (r *QuestionRepository) MigrateStats() error {
+	filter := bson.M{"stats": bson.M{"$not": bson.M{"$type": "object"}}}
+	_, err := r.collection.UpdateMany(context.Background(), filter, bson.M{"$set": bson.M{"stats": model.QuestionStats{}}})
+	return err
+}
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/model"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestSubmissionUpdate(t *testing.T) {
+	runtime := int64(1234)
+	pipeline := submissionUpdate(model.GradedSubmission{Language: "python", Accepted: true, RuntimeMs: &runtime}, true)
+	if len(pipeline) != 3 {
+		t.Fatalf("expected the stats to be reset, counted and derived, got %v", pipeline)
+	}
+	counters := pipeline[1][0].Value.(bson.M)
+	for path, by := range map[string]int{
+		"stats.attempts":                  1,
+		"stats.accepted":                  1,
+		"stats.unique_solvers":            1,
+		"stats.languages.python.attempts": 1,
+		"stats.languages.python.accepted": 1,
+		"stats.runtime_histogram.1200":    1,
+	} {
+		counter, ok := counters[path].(bson.M)
+		if !ok || counter["$add"].(bson.A)[1] != by {
+			t.Errorf("%s: expected an increment by %d, got %v", path, by, counters[path])
+		}
+	}
+	derived := pipeline[2][0].Value.(bson.M)
+	for _, path := range []string{"stats.acceptance_rate", "stats.languages.python.acceptance_rate", "stats.median_runtime_ms"} {
+		if _, ok := derived[path]; !ok {
+			t.Errorf("expected %s to be derived, got %v", path, derived)
+		}
+	}
+
+	// A failed anonymous submission counts an attempt only, and no runtime
+	counters = submissionUpdate(model.GradedSubmission{Language: "java", RuntimeMs: &runtime}, false)[1][0].Value.(bson.M)
+	if len(counters) != 5 || counters["stats.accepted"].(bson.M)["$add"].(bson.A)[1] != 0 || counters["stats.unique_solvers"].(bson.M)["$add"].(bson.A)[1] != 0 {
+		t.Errorf("expected an attempt only, got %v", counters)
+	}
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// RestoreQuestion moves a question out of the trash by its ID and returns it. It fails when a live question
+// has taken its title meanwhile.
+func (r *QuestionRepository) RestoreQuestion(id primitive.ObjectID) (*model.Question, error) {
+	ctx := context.Background()
+	var trashed model.Question
+	err := r.collection.FindOne(ctx, bson.M{"_id": id, "deleted_at": bson.M{"$exists": true}}).Decode(&trashed)
+	if err == mongo.ErrNoDocuments {
+		return nil, model.NewCustomError(404, "Question not found in the trash for ID: "+id.Hex())
+	} else if err != nil {
+		return nil, err
+	}
+
+	err = r.collection.FindOne(ctx, live(bson.M{"title": trashed.Title})).Err()
+	if err == nil {
+		return nil, model.NewCustomError(400, "Another question with the same title already exists, rename it before restoring this one")
+	} else if err != mongo.ErrNoDocuments {
+		return nil, err
+	}
+
+	var restored model.Question
+	err = r.collection.FindOneAndUpdate(ctx,
+		bson.M{"_id": id, "deleted_at": bson.M{"$exists": true}},
+		bson.M{"$unset": bson.M{"deleted_at": ""}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&restored)
+	if err == mongo.ErrNoDocuments {
+		// Restored or purged concurrently
+		return nil, model.NewCustomError(404, "Question not found in the trash for ID: "+id.Hex())
+	} else if err != nil {
+		return nil, err
+	}
+	return &restored, nil
+}
+
+// PurgeTrash permanently deletes the questions moved to the trash before deletedBefore and returns their IDs
+func (r *QuestionRepository) PurgeTrash(deletedBefore time.Time) ([]primitive.ObjectID, error) {
+	ctx := context.Background()
+	filter := bson.M{"deleted_at": bson.M{"$lt": deletedBefore}}
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+	var expired []model.Question
+	if err := cursor.All(ctx, &expired); err != nil {
+		return nil, err
+	}
+	ids := make([]primitive.ObjectID, 0, len(expired))
+	for _, question := range expired {
+		ids = append(ids, question.ID)
+	}
+	if len(ids) == 0 {
+		return ids, nil
+	}
+	// Questions restored since they were found are kept
+	if _, err := r.collection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": ids}, "deleted_at": bson.M{"$lt": deletedBefore}}); err != nil {
+		return nil, err
+	}
+	kept, err := r.collection.Distinct(ctx, "_id", bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+	purged := ids[:0]
+	for _, id := range ids {
+		if !slices.Contains(kept, interface{}(id)) {
+			purged = append(purged, id)
+		}
+	}
+	if len(purged) > 0 {
+		if _, err := r.solvers.DeleteMany(ctx, bson.M{"question_id": bson.M{"$in": purged}}); err != nil {
+			return nil, err
+		}
+	}
+	return purged, nil
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// RevisionRepositoryInterface stores the revisions of questions. Revisions are never modified.
+type RevisionRepositoryInterface interface {
+	CreateRevision(revision model.QuestionRevision) (*model.QuestionRevision, error) // Fails with 412 when the revision number is taken
+	ListRevisions(questionID primitive.ObjectID) ([]model.QuestionRevision, error)   // Newest first, without snapshots
+	GetRevision(questionID primitive.ObjectID, revision int) (*model.QuestionRevision, error)
+	DeleteRevision(id primitive.ObjectID) error                     // Only undoes a revision whose question update failed
+	DeleteQuestionRevisions(questionIDs []primitive.ObjectID) error // Of purged questions
+}
+
+type RevisionRepository struct {
+	collection *mongo.Collection
+}
+
+// NewRevisionRepository creates a new RevisionRepository with the provided MongoDB database.
+func NewRevisionRepository(db *mongo.Database) *RevisionRepository {
+	return &RevisionRepository{
+		collection: db.Collection("question_revisions"),
+	}
+}
+
+// CreateRevision inserts a revision and returns it with its ID
+func (r *RevisionRepository) CreateRevision(revision model.QuestionRevision) (*model.QuestionRevision, error) {
+	result, err := r.collection.InsertOne(context.Background(), revision)
+	if mongo.IsDuplicateKeyError(err) {
+		return nil, model.NewCustomError(412, "Question was changed by someone else, reload it and retry")
+	} else if err != nil {
+		return nil, err
+	}
+	revision.ID = result.InsertedID.(primitive.ObjectID)
+	return &revision, nil
+}
+
+// ListRevisions returns the revisions of a question, newest first. Snapshots are left out, they are fetched one at a time.
+func (r *RevisionRepository) ListRevisions(questionID primitive.ObjectID) ([]model.QuestionRevision, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "revision", Value: -1}}).
+		SetProjection(bson.M{"snapshot": 0})
+	cursor, err := r.collection.Find(context.Background(), bson.M{"question_id": questionID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	revisions := []model.QuestionRevision{}
+	if err := cursor.All(context.Background(), &revisions); err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+// GetRevision retrieves one revision of a question, with its snapshot
+func (r *RevisionRepository) GetRevision(questionID primitive.ObjectID, revision int) (*model.QuestionRevision, error) {
+	var result model.QuestionRevision
+	err := r.collection.FindOne(context.Background(), bson.M{"question_id": questionID, "revision": revision}).Decode(&result)
+	if err == mongo.ErrNoDocuments {
+		return nil, model.NewCustomError(404, "Revision not found for question ID: "+questionID.Hex())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DeleteRevision deletes a revision by its ID
+func (r *RevisionRepository) DeleteRevision(id primitive.ObjectID) error {
+	_, err := r.collection.DeleteOne(context.Background(), bson.M{"_id": id})
+	return err
+}
+
+// DeleteQuestionRevisions deletes every revision of the questions
+func (r *RevisionRepository) DeleteQuestionRevisions(questionIDs []primitive.ObjectID) error {
+	_, err := r.collection.DeleteMany(context.Background(), bson.M{"question_id": bson.M{"$in": questionIDs}})
+	return err
+}
+
+// EnsureIndexes creates the indexes of the revisions, it is safe to call on every startup.
+// The unique index keeps two concurrent updates from both creating the same revision.
+func (r *RevisionRepository) EnsureIndexes() error {
+	_, err := r.collection.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys:    bson.D{{Key: "question_id", Value: 1}, {Key: "revision", Value: -1}},
+		Options: options.Index().SetUnique(true),
+	})
+	return err
+}
@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/model"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Fields covered by the text index, with their weight in the relevance score
+var searchFields = bson.D{
+	{Key: "title", Value: 10},
+	{Key: "tags", Value: 5},
+	{Key: "categories", Value: 3},
+	{Key: "companies", Value: 3},
+	{Key: "description", Value: 1},
+}
+
+// Characters shown around the first match of a highlight snippet
+const snippetContext = 40
+
+// searchQuery is a search string split into words, "quoted phrases" and prefix* queries
+type searchQuery struct {
+	Terms    []string
+	Phrases  []string
+	Prefixes []string
+}
+
+var searchTokenRegex = regexp.MustCompile(`"([^"]*)"|(\S+)`)
+
+func parseSearchQuery(search string) searchQuery {
+	var query searchQuery
+	for _, match := range searchTokenRegex.FindAllStringSubmatch(search, -1) {
+		if match[2] == "" {
+			// A quoted phrase, an unclosed quote starts a word instead
+			if phrase := strings.Join(strings.Fields(match[1]), " "); phrase != "" {
+				query.Phrases = append(query.Phrases, phrase)
+			}
+			continue
+		}
+		word := strings.Trim(match[2], `"`)
+		if prefix, isPrefix := strings.CutSuffix(word, "*"); isPrefix {
+			if prefix = strings.TrimRight(prefix, "*"); prefix != "" {
+				query.Prefixes = append(query.Prefixes, prefix)
+			}
+		} else if word != "" {
+			query.Terms = append(query.Terms, word)
+		}
+	}
+	return query
+}
+
+func (q searchQuery) isEmpty() bool {
+	return len(q.Terms) == 0 && len(q.Phrases) == 0 && len(q.Prefixes) == 0
+}
+
+// usesTextIndex reports whether the query is matched and ranked by the text index. Mongo text
+// search has no prefix queries, so prefix-only queries match with regular expressions and are not ranked.
+func (q searchQuery) usesTextIndex() bool {
+	return len(q.Terms) > 0 || len(q.Phrases) > 0
+}
+
+// filter translates the query into Mongo conditions: words match any of them, phrases must all
+// appear (both through the text index), and every prefix must start a word of a searched field
+func (q searchQuery) filter() bson.M {
+	var conditions bson.A
+	if q.usesTextIndex() {
+		search := strings.Join(q.Terms, " ")
+		for _, phrase := range q.Phrases {
+			search += fmt.Sprintf(` "%s"`, phrase)
+		}
+		conditions = append(conditions, bson.M{"$text": bson.M{"$search": strings.TrimSpace(search)}})
+	}
+	for _, prefix := range q.Prefixes {
+		pattern := `\b` + regexp.QuoteMeta(prefix)
+		var fields bson.A
+		for _, field := range searchFields {
+			fields = append(fields, bson.M{field.Key: bson.M{"$regex": pattern, "$options": "i"}})
+		}
+		conditions = append(conditions, bson.M{"$or": fields})
+	}
+	if len(conditions) == 1 {
+		return conditions[0].(bson.M)
+	}
+	return bson.M{"$and": conditions}
+}
+
+// highlightRegex matches every word, phrase and prefix of the query, words also with their endings
+// (the text index stems them, "sorted" matches "sort")
+func (q searchQuery) highlightRegex() *regexp.Regexp {
+	var patterns []string
+	for _, phrase := range q.Phrases {
+		patterns = append(patterns, strings.Join(strings.Split(regexp.QuoteMeta(phrase), " "), `\s+`))
+	}
+	for _, word := range append(append([]string{}, q.Terms...), q.Prefixes...) {
+		patterns = append(patterns, `\b`+regexp.QuoteMeta(word)+`\w*`)
+	}
+	if len(patterns) == 0 {
+		return nil
+	}
+	return regexp.MustCompile(`(?i)(` + strings.Join(patterns, "|") + `)`)
+}
+
+// highlight returns a snippet of every searched field of question that matches, matches wrapped in <em></em>
+func (q searchQuery) highlight(question model.Question) []model.SearchHighlight {
+	matcher := q.highlightRegex()
+	if matcher == nil {
+		return nil
+	}
+	fields := []struct {
+		name   string
+		values []string
+	}{
+		{"title", []string{question.Title}},
+		{"tags", question.Tags},
+		{"categories", question.Categories},
+		{"companies", question.Companies},
+		{"description", []string{question.Description}},
+	}
+	var highlights []model.SearchHighlight
+	for _, field := range fields {
+		for _, value := range field.values {
+			if snippet := highlightSnippet(value, matcher); snippet != "" {
+				highlights = append(highlights, model.SearchHighlight{Field: field.name, Snippet: snippet})
+			}
+		}
+	}
+	return highlights
+}
+
+// highlightSnippet cuts text around its first match, with every match in the snippet wrapped in <em></em>.
+// The text is HTML-escaped, so the snippet can be rendered as HTML. It is empty when nothing matches.
+func highlightSnippet(text string, matcher *regexp.Regexp) string {
+	first := matcher.FindStringIndex(text)
+	if first == nil {
+		return ""
+	}
+	start, end := first[0]-snippetContext, first[1]+snippetContext
+	prefix, suffix := "…", "…"
+	if start <= 0 {
+		start, prefix = 0, ""
+	}
+	if end >= len(text) {
+		end, suffix = len(text), ""
+	}
+	// Never cut a multi-byte character
+	for start > 0 && !utf8.RuneStart(text[start]) {
+		start--
+	}
+	for end < len(text) && !utf8.RuneStart(text[end]) {
+		end++
+	}
+	window := text[start:end]
+	var snippet strings.Builder
+	last := 0
+	for _, match := range matcher.FindAllStringIndex(window, -1) {
+		snippet.WriteString(html.EscapeString(window[last:match[0]]))
+		snippet.WriteString("<em>" + html.EscapeString(window[match[0]:match[1]]) + "</em>")
+		last = match[1]
+	}
+	snippet.WriteString(html.EscapeString(window[last:]))
+	return prefix + strings.Join(strings.Fields(snippet.String()), " ") + suffix
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/model"
+)
+
+func TestParseSearchQuery(t *testing.T) {
+	cases := []struct {
+		search string
+		query  searchQuery
+	}{
+		{"two sum", searchQuery{Terms: []string{"two", "sum"}}},
+		{`"binary  search" tree`, searchQuery{Terms: []string{"tree"}, Phrases: []string{"binary search"}}},
+		{"sort* graph", searchQuery{Terms: []string{"graph"}, Prefixes: []string{"sort"}}},
+		{`"" ** "unclosed`, searchQuery{Terms: []string{"unclosed"}}},
+		{"   ", searchQuery{}},
+	}
+	for _, c := range cases {
+		if query := parseSearchQuery(c.search); !reflect.DeepEqual(query, c.query) {
+			t.Errorf("%q: expected %+v, got %+v", c.search, c.query, query)
+		}
+	}
+
+	if parseSearchQuery("sort*").usesTextIndex() {
+		t.Errorf("expected a prefix-only query to match without the text index")
+	}
+	if !parseSearchQuery(`"two sum"`).usesTextIndex() {
+		t.Errorf("expected a phrase query to use the text index")
+	}
+}
+
+func TestHighlightSnippet(t *testing.T) {
+	matcher := parseSearchQuery("sort").highlightRegex()
+	cases := []struct {
+		text    string
+		snippet string
+	}{
+		{"Sorted arrays", "<em>Sorted</em> arrays"},
+		{"no match here", ""},
+		{`<script>alert("sort")</script>`, "&lt;script&gt;alert(&#34;<em>sort</em>&#34;)&lt;/script&gt;"},
+		{"<b>sort</b> & sort", "&lt;b&gt;<em>sort</em>&lt;/b&gt; &amp; <em>sort</em>"},
+		{strings.Repeat("x", 60) + " sort " + strings.Repeat("y", 60), "…" + strings.Repeat("x", 39) + " <em>sort</em> " + strings.Repeat("y", 39) + "…"},
+	}
+	for _, c := range cases {
+		if snippet := highlightSnippet(c.text, matcher); snippet != c.snippet {
+			t.Errorf("%q: expected %q, got %q", c.text, c.snippet, snippet)
+		}
+	}
+}
+
+func TestHighlightFields(t *testing.T) {
+	question := model.Question{
+		Title:       "Paths",
+		Tags:        []string{"bfs"},
+		Categories:  []string{"Graph", "Shortest Path"},
+		Companies:   []string{"Pathfinder Inc"},
+		Description: "Count the paths",
+	}
+	var fields []string
+	for _, highlight := range parseSearchQuery("path*").highlight(question) {
+		fields = append(fields, highlight.Field)
+	}
+	if expected := []string{"title", "categories", "companies", "description"}; !reflect.DeepEqual(fields, expected) {
+		t.Errorf("expected highlights of %v, got %v", expected, fields)
+	}
+}
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/model"
+)
+
+// ListCategories returns the taxonomy as a tree, with the number of live questions in each category
+func (s *QuestionService) ListCategories() ([]model.CategoryNode, error) {
+	categories, err := s.CategoryRepo.ListCategories()
+	if err != nil {
+		return nil, err
+	}
+	sets, err := s.Repo.CountCategorySets(false)
+	if err != nil {
+		return nil, err
+	}
+
+	counts, totals := map[string]int64{}, map[string]int64{}
+	for _, category := range categories {
+		descendants := model.CategoryDescendants(categories, category.Name)
+		for _, set := range sets {
+			if slices.Contains(set.Categories, category.Name) {
+				counts[category.Name] += set.Count
+			}
+			if slices.ContainsFunc(set.Categories, func(name string) bool { return slices.Contains(descendants, name) }) {
+				totals[category.Name] += set.Count
+			}
+		}
+	}
+	return model.CategoryTree(categories, counts, totals), nil
+}
+
+// CreateCategory adds a category to the taxonomy, as a topic of its parent when it has one.
+// Names are unique ignoring case, the parent may be named in any case.
+func (s *QuestionService) CreateCategory(category model.Category) (*model.Category, error) {
+	category.Name = strings.Join(strings.Fields(category.Name), " ")
+	category.Parent = strings.TrimSpace(category.Parent)
+	if category.Name == "" {
+		return nil, model.NewCustomError(400, "category name is required")
+	}
+	categories, err := s.CategoryRepo.ListCategories()
+	if err != nil {
+		return nil, err
+	}
+	if existing, found := model.FindCategory(categories, category.Name); found {
+		return nil, model.NewCustomError(400, "Category already exists: "+existing.Name)
+	}
+	if category.Parent != "" {
+		parent, found := model.FindCategory(categories, category.Parent)
+		if !found {
+			return nil, model.NewCustomError(400, "parent category not found: "+category.Parent)
+		}
+		category.Parent = parent.Name
+	}
+	return s.CategoryRepo.CreateCategory(category)
+}
+
+// DeleteCategory removes a category, named in any case, from the taxonomy. Categories with topics or questions
+// cannot be removed, including the questions in the trash, which would be restored with a category the taxonomy does not know.
+func (s *QuestionService) DeleteCategory(name string) error {
+	categories, err := s.CategoryRepo.ListCategories()
+	if err != nil {
+		return err
+	}
+	category, found := model.FindCategory(categories, name)
+	if !found {
+		return model.NewCustomError(404, "Category not found: "+name)
+	}
+	if slices.ContainsFunc(categories, func(c model.Category) bool { return c.Parent == category.Name }) {
+		return model.NewCustomError(400, "category has topics, delete them first: "+category.Name)
+	}
+	sets, err := s.Repo.CountCategorySets(true)
+	if err != nil {
+		return err
+	}
+	for _, set := range sets {
+		if slices.ContainsFunc(set.Categories, func(used string) bool { return strings.EqualFold(used, category.Name) }) {
+			return model.NewCustomError(400, "category is used by questions, in the trash or not: "+category.Name)
+		}
+	}
+	return s.CategoryRepo.DeleteCategory(category.Name)
+}
+
+// expandCategories resolves category filters to the taxonomy names, ignoring case, with all their topics.
+// Names the taxonomy does not know are kept as they are.
+func (s *QuestionService) expandCategories(names []string) ([]string, error) {
+	categories, err := s.CategoryRepo.ListCategories()
+	if err != nil {
+		return nil, err
+	}
+	expanded := []string{}
+	for _, name := range names {
+		resolved := strings.TrimSpace(name)
+		if category, found := model.FindCategory(categories, resolved); found {
+			resolved = category.Name
+		}
+		for _, descendant := range model.CategoryDescendants(categories, resolved) {
+			if !slices.Contains(expanded, descendant) {
+				expanded = append(expanded, descendant)
+			}
+		}
+	}
+	return expanded, nil
+}
+
+// validateQuestion validates question as ValidateQuestion does, and its categories against the taxonomy,
+// reporting all problems together
+func (s *QuestionService) validateQuestion(question *model.Question) error {
+	var issues []model.ValidationIssue
+	if err := ValidateQuestion(question); err != nil {
+		var validationErr *model.ValidationError
+		if !errors.As(err, &validationErr) {
+			return err
+		}
+		issues = validationErr.Issues
+	}
+	categoryIssues, err := s.validateCategories(question)
+	if err != nil {
+		return err
+	}
+	return model.NewValidationError(append(issues, categoryIssues...))
+}
+
+// validateCategories checks that the question has at least one category and that all of them are in the taxonomy,
+// spelling them as the taxonomy does
+func (s *QuestionService) validateCategories(question *model.Question) ([]model.ValidationIssue, error) {
+	if len(question.Categories) == 0 {
+		return []model.ValidationIssue{{Location: "categories", Message: "at least one category is required"}}, nil
+	}
+	categories, err := s.CategoryRepo.ListCategories()
+	if err != nil {
+		return nil, err
+	}
+	issues := []model.ValidationIssue{}
+	for i, name := range question.Categories {
+		category, found := model.FindCategory(categories, name)
+		if !found {
+			issues = append(issues, model.ValidationIssue{
+				Location: fmt.Sprintf("categories[%d]", i),
+				Value:    name,
+				Message:  "unknown category, see GET /categories",
+			})
+			continue
+		}
+		question.Categories[i] = category.Name
+	}
+	question.Category = question.Categories[0]
+	return issues, nil
+}
@@ -0,0 +1,123 @@
+package service_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/model"
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/repository"
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/service"
+)
+
+// newTaxonomyService returns a service whose taxonomy has the topics Shortest Path and BFS of Graph
+func newTaxonomyService(questions *stubQuestionRepository) (*service.QuestionService, *stubCategoryRepository) {
+	taxonomy := newStubTaxonomy()
+	taxonomy.categories = append(taxonomy.categories, model.Category{Name: "Shortest Path", Parent: "Graph"}, model.Category{Name: "BFS", Parent: "Graph"})
+	return service.NewQuestionService(questions, &stubRevisionRepository{}, taxonomy, nil), taxonomy
+}
+
+func TestCategoriesAreSpelledAsTheTaxonomy(t *testing.T) {
+	questions := &stubQuestionRepository{}
+	questionService, _ := newTaxonomyService(questions)
+
+	question := validQuestion("Paths")
+	question.Category, question.Categories = "", []string{" shortest path", "GRAPH", "Graph"}
+	if _, err := questionService.CreateQuestion(question); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	created := questions.created[0]
+	if created.Category != "Shortest Path" || !reflect.DeepEqual(created.Categories, []string{"Shortest Path", "Graph"}) {
+		t.Errorf("expected the categories of the taxonomy, got %q %v", created.Category, created.Categories)
+	}
+
+	question.Categories = []string{"array", "Networks"}
+	var validationErr *model.ValidationError
+	if _, err := questionService.CreateQuestion(question); !errors.As(err, &validationErr) || validationErr.Issues[0].Location != "categories[1]" {
+		t.Errorf("expected the unknown category to be a validation issue, got %v", err)
+	}
+}
+
+func TestCategoryFiltersIgnoreCase(t *testing.T) {
+	questions := &stubQuestionRepository{}
+	questionService, _ := newTaxonomyService(questions)
+
+	if _, err := questionService.GetAllQuestions(model.QuestionQueryParams{Categories: []string{"graph", "Unknown"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := []string{"Graph", "Shortest Path", "BFS", "Unknown"}; !reflect.DeepEqual(questions.listed.Categories, expected) {
+		t.Errorf("expected %v, got %v", expected, questions.listed.Categories)
+	}
+}
+
+func TestCreateCategory(t *testing.T) {
+	questionService, taxonomy := newTaxonomyService(&stubQuestionRepository{})
+
+	created, err := questionService.CreateCategory(model.Category{Name: " Dijkstra ", Parent: "shortest PATH"})
+	if err != nil || created.Name != "Dijkstra" || created.Parent != "Shortest Path" {
+		t.Fatalf("expected a topic of Shortest Path, got %+v %v", created, err)
+	}
+	for _, category := range []model.Category{{Name: "graph"}, {Name: "Flows", Parent: "Networks"}, {Name: " "}} {
+		if _, err := questionService.CreateCategory(category); statusOf(err) != 400 {
+			t.Errorf("%+v: expected a 400, got %v", category, err)
+		}
+	}
+	if len(taxonomy.categories) != len(model.PredefinedCategories)+3 {
+		t.Errorf("expected a single category to be added, got %+v", taxonomy.categories)
+	}
+}
+
+func TestDeleteCategory(t *testing.T) {
+	questions := &stubQuestionRepository{categorySets: map[bool][]repository.CategorySetCount{
+		true: {{Categories: []string{"Matrix"}, Count: 1}},
+	}}
+	questionService, taxonomy := newTaxonomyService(questions)
+
+	if err := questionService.DeleteCategory("graph"); statusOf(err) != 400 {
+		t.Errorf("expected a category with topics to be kept, got %v", err)
+	}
+	if err := questionService.DeleteCategory("matrix"); statusOf(err) != 400 {
+		t.Errorf("expected a category of a trashed question to be kept, got %v", err)
+	}
+	if err := questionService.DeleteCategory("Networks"); statusOf(err) != 404 {
+		t.Errorf("expected an unknown category to be a 404, got %v", err)
+	}
+	if err := questionService.DeleteCategory("array"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, found := model.FindCategory(taxonomy.categories, "Array"); found {
+		t.Errorf("expected Array to be deleted")
+	}
+}
+
+func TestListCategories(t *testing.T) {
+	questions := &stubQuestionRepository{categorySets: map[bool][]repository.CategorySetCount{
+		false: {{Categories: []string{"Shortest Path", "BFS"}, Count: 1}, {Categories: []string{"Graph"}, Count: 1}},
+	}}
+	questionService, _ := newTaxonomyService(questions)
+
+	tree, err := questionService.ListCategories()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, node := range tree {
+		// The question in both topics counts once in the total of Graph
+		if node.Name == "Graph" && (node.Count != 1 || node.Total != 2 || len(node.Children) != 2 || node.Children[0].Count != 1) {
+			t.Errorf("expected Graph to count 1 question of its own and 2 in total, got %+v", node)
+		}
+	}
+}
+
+func TestPatchedCategoryIsKept(t *testing.T) {
+	stored := validQuestion("Add")
+	stored.Revision, stored.Categories = 1, []string{"Array", "Graph"}
+	questionService, _ := newTaxonomyService(&stubQuestionRepository{question: &stored})
+
+	patched, err := questionService.PatchQuestion(stored.ID.Hex(), []byte(`{"category": "tree"}`), 1, "ada")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if patched.Category != "Tree" || !reflect.DeepEqual(patched.Categories, []string{"Tree", "Graph"}) {
+		t.Errorf("expected the patched category to replace the first, got %q %v", patched.Category, patched.Categories)
+	}
+}
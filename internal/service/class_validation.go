@@ -0,0 +1,44 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/coding"
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/model"
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/parser_validator"
+)
+
+// validateClassQuestion checks the class configuration and every example and test case, as operation sequences.
+// Outputs are matched exactly, so comparators, checkers and reference solutions are not supported.
+func validateClassQuestion(question *model.Question) error {
+	issues := parser_validator.ValidateClassConfig(question.ClassConfig)
+	if err := coding.ValidateCharacters(question); err != nil {
+		issues = append(issues, model.ValidationIssue{Location: "class_config", Message: err.Error()})
+	}
+	if question.ClassConfig != nil {
+		issues = append(issues, coding.ValidateParameterJavaTypes(question.ClassConfig.ConstructorParameters(), "class_config.constructor")...)
+		for i, method := range question.ClassConfig.Methods {
+			issues = append(issues, coding.ValidateJavaTypes(method, fmt.Sprintf("class_config.methods[%d]", i))...)
+		}
+	}
+	if question.Comparator.GetType() != model.ExactComparator {
+		issues = append(issues, model.ValidationIssue{Location: "comparator", Message: "class questions only support the exact comparator"})
+	}
+	if question.Checker != nil {
+		issues = append(issues, model.ValidationIssue{Location: "checker", Message: "class questions do not support checkers"})
+	}
+	if question.ReferenceSolution != nil {
+		issues = append(issues, model.ValidationIssue{Location: "reference_solution", Message: "class questions do not support reference solutions"})
+	}
+	if len(issues) > 0 {
+		return model.NewValidationError(issues)
+	}
+
+	for i, example := range question.Examples {
+		issues = append(issues, parser_validator.ValidateOperationSequence(example, question.ClassConfig, fmt.Sprintf("examples[%d]", i))...)
+	}
+	for i, testCase := range question.TestCases {
+		issues = append(issues, parser_validator.ValidateOperationSequence(testCase, question.ClassConfig, fmt.Sprintf("test_cases[%d]", i))...)
+	}
+	return model.NewValidationError(issues)
+}
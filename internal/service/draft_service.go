@@ -0,0 +1,65 @@
+package service
+
+import (
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/coding"
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/model"
+)
+
+// DraftQuestion reads a problem copied from another site into a question: its signature into the function
+// configuration and its examples into examples and test cases. The draft is not stored, it is returned with
+// what was guessed and what must be fixed before it can be created.
+func (s *QuestionService) DraftQuestion(request model.DraftRequest) (*model.QuestionDraft, error) {
+	if strings.TrimSpace(request.Signature) == "" {
+		return nil, model.NewCustomError(400, "signature is required")
+	}
+	parsed, err := coding.ParseSignature(request.Signature)
+	if err != nil {
+		return nil, model.NewCustomError(400, "invalid signature: "+err.Error())
+	}
+
+	examplesText := request.Examples
+	if examplesText == "" {
+		examplesText = request.Description
+	}
+	examples, warnings := coding.ParseExamples(examplesText, *parsed.FunctionConfig.Parameters)
+	languages := []string{}
+	for _, language := range model.PredefinedSupportedLanguages {
+		languages = append(languages, string(language))
+	}
+	question := model.Question{
+		Title:          strings.TrimSpace(request.Title),
+		Description:    coding.ProblemStatement(request.Description),
+		Difficulty:     request.Difficulty,
+		Category:       request.Category,
+		Categories:     request.Categories,
+		Tags:           request.Tags,
+		Companies:      request.Companies,
+		Examples:       examples,
+		TestCases:      slices.Clone(examples),
+		FunctionConfig: parsed.FunctionConfig,
+		Languages:      languages,
+	}
+
+	draft := &model.QuestionDraft{
+		SignatureSource: string(parsed.Source),
+		Warnings:        append(parsed.Warnings, warnings...),
+		Issues:          []model.ValidationIssue{},
+	}
+	if len(examples) > 0 {
+		draft.Warnings = append(draft.Warnings, "the test cases are the examples, add more before publishing")
+	}
+	if err := s.validateQuestion(&question); err != nil {
+		var validationErr *model.ValidationError
+		if errors.As(err, &validationErr) {
+			draft.Issues = validationErr.Issues
+		} else {
+			draft.Issues = append(draft.Issues, model.ValidationIssue{Message: err.Error()})
+		}
+	}
+	draft.Question = question
+	return draft, nil
+}
@@ -0,0 +1,230 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"sigs.k8s.io/yaml"
+)
+
+const maxImportedQuestions = 1000
+
+// ImportQuestions creates or updates every question of data, matching existing questions by title.
+// Invalid questions are reported and skipped, the valid ones are stored. In a dry run nothing is stored.
+func (s *QuestionService) ImportQuestions(data []byte, format model.TransferFormat, dryRun bool, author string) (*model.ImportResult, error) {
+	items, err := splitImportedQuestions(data, format)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, model.NewCustomError(400, "no questions to import")
+	}
+	if len(items) > maxImportedQuestions {
+		return nil, model.NewCustomError(400, fmt.Sprintf("at most %d questions can be imported at once, got %d", maxImportedQuestions, len(items)))
+	}
+
+	result := &model.ImportResult{DryRun: dryRun, Items: []model.ImportItemResult{}}
+	titles := map[string]int{}
+	for i, item := range items {
+		itemResult := s.importQuestion(i, item, titles, dryRun, author)
+		switch itemResult.Action {
+		case model.ImportCreated:
+			result.Created++
+		case model.ImportUpdated:
+			result.Updated++
+		case model.ImportUnchanged:
+			result.Unchanged++
+		default:
+			result.Invalid++
+		}
+		result.Items = append(result.Items, itemResult)
+	}
+	return result, nil
+}
+
+// importQuestion creates or updates one imported question. titles holds the index of every title imported so far.
+func (s *QuestionService) importQuestion(index int, data json.RawMessage, titles map[string]int, dryRun bool, author string) model.ImportItemResult {
+	result := model.ImportItemResult{Index: index, Action: model.ImportInvalid}
+	var question model.Question
+	if err := json.Unmarshal(data, &question); err != nil {
+		result.Error = fmt.Sprintf("invalid question: %v", err)
+		return result
+	}
+	result.Title = question.Title
+	if first, imported := titles[question.Title]; imported {
+		result.Error = fmt.Sprintf("duplicate title, already imported as item %d", first)
+		return result
+	}
+	titles[question.Title] = index
+
+	// The stored question owns its identity and bookkeeping, whatever the document says
+	question.ID, question.Revision, question.Stats, question.DeletedAt = primitive.NilObjectID, 0, model.QuestionStats{}, nil
+	existing, err := s.Repo.GetQuestionByTitle(question.Title)
+	if err != nil {
+		return invalidImport(result, err)
+	}
+	if existing != nil {
+		// As with PUT, a question imported without its reference solution, checker code or judges keeps them
+		question.KeepSource(existing)
+	}
+	if err := s.validateQuestion(&question); err != nil {
+		return invalidImport(result, err)
+	}
+
+	if existing == nil {
+		result.Action = model.ImportCreated
+		if !dryRun {
+			created, err := s.CreateQuestion(question)
+			if err != nil {
+				return invalidImport(result, err)
+			}
+			result.ID = created.ID.Hex()
+		}
+		return result
+	}
+
+	result.ID = existing.ID.Hex()
+	changes, err := model.DiffQuestions(existing, &question)
+	if err != nil {
+		return invalidImport(result, err)
+	}
+	if len(changes) == 0 {
+		result.Action = model.ImportUnchanged
+		return result
+	}
+	result.Action = model.ImportUpdated
+	for _, change := range changes {
+		result.ChangedFields = append(result.ChangedFields, change.Path)
+	}
+	if !dryRun {
+		if _, err := s.commitRevision(existing, question, author, "imported"); err != nil {
+			return invalidImport(result, err)
+		}
+	}
+	return result
+}
+
+// invalidImport reports why a question was not imported, with every validation issue
+func invalidImport(result model.ImportItemResult, err error) model.ImportItemResult {
+	result.Action = model.ImportInvalid
+	result.ChangedFields = nil
+	var validationErr *model.ValidationError
+	if errors.As(err, &validationErr) {
+		result.Issues = validationErr.Issues
+	} else {
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// splitImportedQuestions splits an imported document into the JSON of each of its questions,
+// which are decoded one by one so a malformed question does not fail the others
+func splitImportedQuestions(data []byte, format model.TransferFormat) ([]json.RawMessage, error) {
+	switch format {
+	case model.NDJSONFormat:
+		var items []json.RawMessage
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		scanner.Buffer(nil, len(data)+1)
+		for scanner.Scan() {
+			if line := bytes.TrimSpace(scanner.Bytes()); len(line) > 0 {
+				items = append(items, json.RawMessage(bytes.Clone(line)))
+			}
+		}
+		return items, scanner.Err()
+	case model.YAMLFormat:
+		converted, err := yaml.YAMLToJSON(data)
+		if err != nil {
+			return nil, model.NewCustomError(400, fmt.Sprintf("invalid YAML: %v", err))
+		}
+		return splitImportedQuestions(converted, model.JSONFormat)
+	case model.JSONFormat:
+		trimmed := bytes.TrimSpace(data)
+		if bytes.HasPrefix(trimmed, []byte("[")) {
+			var items []json.RawMessage
+			if err := json.Unmarshal(trimmed, &items); err != nil {
+				return nil, model.NewCustomError(400, fmt.Sprintf("invalid JSON array: %v", err))
+			}
+			return items, nil
+		}
+		// A single question, or several one after another
+		var items []json.RawMessage
+		decoder := json.NewDecoder(bytes.NewReader(trimmed))
+		for decoder.More() {
+			var item json.RawMessage
+			if err := decoder.Decode(&item); err != nil {
+				return nil, model.NewCustomError(400, fmt.Sprintf("invalid JSON: %v", err))
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	}
+	return nil, model.NewCustomError(400, fmt.Sprintf("unknown format: %s, must be one of %v", format, model.TransferFormats))
+}
+
+// ExportQuestions writes every live question to w in format, sorted by title, as it reads them.
+// The output can be imported again as is.
+func (s *QuestionService) ExportQuestions(format model.TransferFormat, w io.Writer) error {
+	count := 0
+	err := s.Repo.ExportQuestions(func(question model.Question) error {
+		encoded, err := encodeExportedQuestion(question, format, count == 0)
+		if err != nil {
+			return err
+		}
+		count++
+		_, err = w.Write(encoded)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	switch {
+	case format == model.JSONFormat && count == 0:
+		_, err = io.WriteString(w, "[]\n")
+	case format == model.JSONFormat:
+		_, err = io.WriteString(w, "\n]\n")
+	case format == model.YAMLFormat && count == 0:
+		_, err = io.WriteString(w, "[]\n")
+	}
+	return err
+}
+
+// encodeExportedQuestion encodes one question as an element of the exported document
+func encodeExportedQuestion(question model.Question, format model.TransferFormat, first bool) ([]byte, error) {
+	switch format {
+	case model.NDJSONFormat:
+		encoded, err := json.Marshal(question)
+		return append(encoded, '\n'), err
+	case model.YAMLFormat:
+		encoded, err := yaml.Marshal(question)
+		if err != nil {
+			return nil, err
+		}
+		// An item of a YAML sequence
+		lines := strings.Split(strings.TrimSuffix(string(encoded), "\n"), "\n")
+		for i := range lines {
+			if i == 0 {
+				lines[i] = "- " + lines[i]
+			} else {
+				lines[i] = "  " + lines[i]
+			}
+		}
+		return []byte(strings.Join(lines, "\n") + "\n"), nil
+	default:
+		encoded, err := json.MarshalIndent(question, "  ", "  ")
+		if err != nil {
+			return nil, err
+		}
+		separator := ",\n  "
+		if first {
+			separator = "[\n  "
+		}
+		return append([]byte(separator), encoded...), nil
+	}
+}
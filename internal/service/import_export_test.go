@@ -0,0 +1,107 @@
+package service_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/model"
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/service"
+)
+
+func TestImportFormats(t *testing.T) {
+	questionService := service.NewQuestionService(&stubQuestionRepository{}, &stubRevisionRepository{}, newStubTaxonomy(), nil)
+	cases := []struct {
+		format model.TransferFormat
+		data   string
+		titles []string
+	}{
+		{model.JSONFormat, `[{"title": "A"}, {"title": "B"}]`, []string{"A", "B"}},
+		{model.JSONFormat, `{"title": "A"}`, []string{"A"}},
+		{model.JSONFormat, "{\"title\": \"A\"}\n{\"title\": \"B\"}", []string{"A", "B"}},
+		{model.NDJSONFormat, "{\"title\": \"A\"}\n\n{\"title\": \"B\"\n", []string{"A", ""}},
+		{model.YAMLFormat, "- title: A\n- title: B\n", []string{"A", "B"}},
+		{model.YAMLFormat, "title: A\n", []string{"A"}},
+	}
+	for _, c := range cases {
+		result, err := questionService.ImportQuestions([]byte(c.data), c.format, true, "")
+		if err != nil {
+			t.Errorf("%s %q: unexpected error: %v", c.format, c.data, err)
+			continue
+		}
+		var titles []string
+		for _, item := range result.Items {
+			titles = append(titles, item.Title)
+		}
+		if len(titles) != len(c.titles) || titles[0] != c.titles[0] {
+			t.Errorf("%s %q: expected %v, got %v", c.format, c.data, c.titles, titles)
+		}
+	}
+
+	for _, c := range []struct {
+		format model.TransferFormat
+		data   string
+	}{{model.JSONFormat, `[{"title": "A"},`}, {model.YAMLFormat, "- title: [A"}, {"xml", "<questions/>"}, {model.JSONFormat, "[]"}} {
+		if _, err := questionService.ImportQuestions([]byte(c.data), c.format, true, ""); statusOf(err) != 400 {
+			t.Errorf("%s %q: expected a 400, got %v", c.format, c.data, err)
+		}
+	}
+}
+
+func TestImportQuestions(t *testing.T) {
+	stored := validQuestion("Existing")
+	stored.Revision = 1
+	stored.ReferenceSolution = &model.Submission{Language: model.Python, Code: "def add(a, b): return a + b"}
+	questions := &stubQuestionRepository{question: &stored}
+	questionService := service.NewQuestionService(questions, &stubRevisionRepository{}, newStubTaxonomy(), nil)
+
+	unchanged, created, invalid := validQuestion("Existing"), validQuestion("New"), validQuestion("Invalid")
+	invalid.FunctionConfig.Parameters = nil
+	var data bytes.Buffer
+	for _, question := range []model.Question{unchanged, created, invalid} {
+		encoded, _ := json.Marshal(question)
+		data.Write(append(encoded, '\n'))
+	}
+	data.WriteString("{\"title\": \"New\"}\n")
+
+	result, err := questionService.ImportQuestions(data.Bytes(), model.NDJSONFormat, true, "ada")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Unchanged != 1 || result.Created != 1 || result.Invalid != 2 {
+		t.Errorf("expected 1 unchanged, 1 created and 2 invalid, got %+v", result)
+	}
+	if len(result.Items[2].Issues) == 0 {
+		t.Errorf("expected the validation issues of the invalid question, got %+v", result.Items[2])
+	}
+	if result.Items[3].Error == "" {
+		t.Errorf("expected the repeated title to be reported, got %+v", result.Items[3])
+	}
+	if len(questions.created) != 0 {
+		t.Errorf("expected a dry run to store nothing, got %+v", questions.created)
+	}
+
+	updated := validQuestion("Existing")
+	updated.Description = "Add two integers"
+	encoded, _ := json.Marshal([]model.Question{updated, created})
+	result, err = questionService.ImportQuestions(encoded, model.JSONFormat, false, "ada")
+	if err != nil || result.Updated != 1 || result.Created != 1 || len(questions.created) != 1 {
+		t.Fatalf("expected 1 updated and 1 created, got %+v %v", result, err)
+	}
+	if fields := result.Items[0].ChangedFields; len(fields) != 1 || fields[0] != "description" {
+		t.Errorf("expected the description to be the changed field, got %v", fields)
+	}
+	if questions.question.ReferenceSolution == nil || questions.question.Revision != 2 {
+		t.Errorf("expected revision 2 to keep the reference solution, got %+v", questions.question)
+	}
+
+	// An export imports again unchanged
+	var exported bytes.Buffer
+	if err := questionService.ExportQuestions(model.YAMLFormat, &exported); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result, err = questionService.ImportQuestions(exported.Bytes(), model.YAMLFormat, false, "ada")
+	if err != nil || result.Unchanged != 1 || len(result.Items) != 1 {
+		t.Errorf("expected the export to import unchanged, got %+v %v", result, err)
+	}
+}
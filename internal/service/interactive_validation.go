@@ -0,0 +1,65 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/model"
+)
+
+// validateInteractiveQuestion checks the judge of every language and the judge's input of every example and test case.
+// The judge gives the verdict, so comparators, checkers and reference solutions are not supported.
+func validateInteractiveQuestion(question *model.Question) error {
+	interactiveConfig := question.InteractiveConfig
+	if interactiveConfig == nil {
+		return model.NewValidationError([]model.ValidationIssue{{Location: "interactive_config", Message: "interactive configuration cannot be null for an interactive question"}})
+	}
+	languages, issues := programLanguages(question, model.InteractiveLanguages)
+	for language := range interactiveConfig.Judge {
+		if !slices.Contains(model.InteractiveLanguages, language) {
+			issues = append(issues, model.ValidationIssue{Location: "interactive_config.judge", Value: string(language), Message: fmt.Sprintf("unsupported judge language, must be one of %v", model.InteractiveLanguages)})
+		}
+	}
+	for _, language := range languages {
+		if strings.TrimSpace(interactiveConfig.Judge[language]) == "" {
+			issues = append(issues, model.ValidationIssue{Location: "interactive_config.judge", Message: fmt.Sprintf("a judge is required for %s", language)})
+		}
+	}
+	if interactiveConfig.QueryLimit < 0 {
+		issues = append(issues, model.ValidationIssue{Location: "interactive_config.query_limit", Value: strconv.Itoa(interactiveConfig.QueryLimit), Message: "query limit cannot be negative"})
+	}
+	if interactiveConfig.TimeLimit < 0 || interactiveConfig.TimeLimit > model.MaxInteractiveTimeLimit {
+		issues = append(issues, model.ValidationIssue{Location: "interactive_config.time_limit", Message: fmt.Sprintf("time limit must be between 0 and %v seconds", model.MaxInteractiveTimeLimit)})
+	}
+	if question.Comparator != nil {
+		issues = append(issues, model.ValidationIssue{Location: "comparator", Message: "interactive questions are judged by their judge, comparators are not supported"})
+	}
+	if question.Checker != nil {
+		issues = append(issues, model.ValidationIssue{Location: "checker", Message: "interactive questions are judged by their judge, checkers are not supported"})
+	}
+	if question.ReferenceSolution != nil {
+		issues = append(issues, model.ValidationIssue{Location: "reference_solution", Message: "interactive questions do not support reference solutions"})
+	}
+
+	for i, example := range question.Examples {
+		issues = append(issues, validateJudgeInput(example, fmt.Sprintf("examples[%d]", i))...)
+	}
+	for i, testCase := range question.TestCases {
+		issues = append(issues, validateJudgeInput(testCase, fmt.Sprintf("test_cases[%d]", i))...)
+	}
+	return model.NewValidationError(issues)
+}
+
+// validateJudgeInput checks that an interactive test case holds one parameter, the judge's input as JSON
+func validateJudgeInput(inputOutput model.InputOutput, location string) []model.ValidationIssue {
+	if len(inputOutput.Parameters) != 1 {
+		return []model.ValidationIssue{{Location: location + ".parameters", Message: fmt.Sprintf("an interactive test case has 1 parameter, the judge's input, got %d", len(inputOutput.Parameters))}}
+	}
+	if !json.Valid([]byte(inputOutput.Parameters[0])) {
+		return []model.ValidationIssue{{Location: location + ".parameters[0]", Value: inputOutput.Parameters[0], Message: "the judge's input must be valid JSON"}}
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package service
+
+import (
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/model"
+)
+
+// PatchQuestion applies a JSON Merge Patch to an existing question, recording the change as a new revision by author.
+// It fails with 412 unless the question is at expectedRevision.
+func (s *QuestionService) PatchQuestion(id string, patch []byte, expectedRevision int, author string) (*model.Question, error) {
+	objID, err := handleInvalidID(id)
+	if err != nil {
+		return nil, err
+	}
+	current, err := s.Repo.GetQuestionByID(objID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkRevision(current, expectedRevision); err != nil {
+		return nil, err
+	}
+	question, err := current.ApplyMergePatch(patch)
+	if err != nil {
+		return nil, err
+	}
+	question.KeepCategoryChange(current)
+	if err := s.validateQuestion(question); err != nil {
+		return nil, err
+	}
+	return s.commitRevision(current, *question, author, "")
+}
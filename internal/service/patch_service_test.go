@@ -0,0 +1,27 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/model"
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/service"
+)
+
+func TestPatchQuestion(t *testing.T) {
+	stored := validQuestion("Add")
+	stored.Revision = 1
+	stored.Tags = []string{"math"}
+	questions := &stubQuestionRepository{question: &stored}
+	questionService := service.NewQuestionService(questions, &stubRevisionRepository{}, newStubTaxonomy(), nil)
+
+	patched, err := questionService.PatchQuestion(stored.ID.Hex(), []byte(`{"title": "Sum", "tags": null}`), model.AnyRevision, "grace")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if patched.Title != "Sum" || patched.Tags != nil || patched.Revision != 2 || patched.Description != stored.Description {
+		t.Errorf("expected the title replaced and the tags removed at revision 2, got %+v", patched)
+	}
+	if _, err := questionService.PatchQuestion(stored.ID.Hex(), []byte(`{"function_config": null}`), model.AnyRevision, "grace"); statusOf(err) != 422 {
+		t.Errorf("expected a patch leaving an invalid question to be a 422, got %v", err)
+	}
+}
@@ -3,8 +3,10 @@ package service
 import (
 	"encoding/json"
 	"fmt"
-	"sort"
+	"io"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/TehilaTheStudent/SkillCode-backend/internal/coding"
 	"github.com/TehilaTheStudent/SkillCode-backend/internal/config"
@@ -19,33 +21,54 @@ import (
 type QuestionServiceInterface interface {
 	CreateQuestion(question model.Question) (*model.Question, error)
 	GetQuestionByID(id string) (*model.Question, error)
-	GetAllQuestions(params model.QuestionQueryParams) ([]model.Question, error)
-	UpdateQuestion(id string, question model.Question) (*model.Question, error)
+	GetAllQuestions(params model.QuestionQueryParams) (*model.QuestionPage, error)
+	UpdateQuestion(id string, question model.Question, expectedRevision int, author string) (*model.Question, error)
+	PatchQuestion(id string, patch []byte, expectedRevision int, author string) (*model.Question, error)
 	DeleteQuestion(id string) error
+	ListTrash(params model.QuestionQueryParams) (*model.QuestionPage, error)
+	RestoreQuestion(id string) (*model.Question, error)
+	ImportQuestions(data []byte, format model.TransferFormat, dryRun bool, author string) (*model.ImportResult, error)
+	ExportQuestions(format model.TransferFormat, w io.Writer) error
+	DraftQuestion(request model.DraftRequest) (*model.QuestionDraft, error)
 	// TestQuestion(id string, solution model.Submission) (*model.Feedback, error)
 	TestUniqueQuestion(questionID string, submission model.Submission, requestID string) (*model.Feedback, error)
+	RecordSubmission(questionID string, submission model.Submission, feedback *model.Feedback, solver string) error
+	GenerateTestCases(questionID string, request model.TestCaseGenerationRequest, requestID string) (*model.TestCaseGenerationResult, error)
+	ListRevisions(id string) ([]model.QuestionRevision, error)
+	GetRevision(id string, revision int) (*model.QuestionRevision, error)
+	DiffRevisions(id string, from, to int) (*model.RevisionDiff, error)
+	RollbackQuestion(id string, revision int, expectedRevision int, author string) (*model.Question, error)
+	ListCategories() ([]model.CategoryNode, error)
+	CreateCategory(category model.Category) (*model.Category, error)
+	DeleteCategory(name string) error
 }
 
 type QuestionService struct {
 	Repo         repository.QuestionRepositoryInterface
+	RevisionRepo repository.RevisionRepositoryInterface
+	CategoryRepo repository.CategoryRepositoryInterface
 	SharedTester *tester.SharedTester
 }
 
 // NewQuestionService creates a new QuestionService with a QuestionRepository instance.
-func NewQuestionService(repo repository.QuestionRepositoryInterface, sharedTester *tester.SharedTester) *QuestionService {
-	return &QuestionService{Repo: repo, SharedTester: sharedTester}
+func NewQuestionService(repo repository.QuestionRepositoryInterface, revisionRepo repository.RevisionRepositoryInterface, categoryRepo repository.CategoryRepositoryInterface, sharedTester *tester.SharedTester) *QuestionService {
+	return &QuestionService{Repo: repo, RevisionRepo: revisionRepo, CategoryRepo: categoryRepo, SharedTester: sharedTester}
 }
 
-// CreateQuestion creates a new question in the repository.
+// CreateQuestion creates a new question in the repository, as its first revision.
 func (s *QuestionService) CreateQuestion(question model.Question) (*model.Question, error) {
-	err:=ValidateQuestion(&question)
+	err:=s.validateQuestion(&question)
 	if err!=nil{
 		return nil, err
     }
+	question.Revision = 1
 	result, err:= s.Repo.CreateQuestion(question)
 	if err != nil {
 		return nil, err
 	}
+	if err := s.createBaselineRevision(result, "created"); err != nil {
+		return nil, err
+	}
 	return result, nil
 }
 
@@ -66,126 +89,63 @@ func (s *QuestionService) GetQuestionByID(id string) (*model.Question, error) {
 	return s.Repo.GetQuestionByID(objID)
 }
 
-func (s *QuestionService) GetAllQuestions(params model.QuestionQueryParams) ([]model.Question, error) {
-	// Fetch all questions from the repository
-	questions, err := s.Repo.GetAllQuestions()
-	if err != nil {
-		return []model.Question{}, err
+// GetAllQuestions returns one page of the questions matching params, filtered, sorted and paginated by the repository.
+func (s *QuestionService) GetAllQuestions(params model.QuestionQueryParams) (*model.QuestionPage, error) {
+	if params.SortBy != "" && !slices.Contains(model.QuestionSortKeys, params.SortBy) {
+		return nil, model.NewCustomError(400, fmt.Sprintf("invalid sort_by: %s, must be one of %v", params.SortBy, model.QuestionSortKeys))
 	}
-
-	// Apply filtering
-	if params.Search != "" {
-		questions = filterBySearchQuery(questions, params.Search)
-	}
-
-	if len(params.Categories) > 0 {
-		questions = filterByCategories(questions, params.Categories)
+	if order := strings.ToLower(params.SortOrder); order != "" && order != "asc" && order != "desc" {
+		return nil, model.NewCustomError(400, fmt.Sprintf("invalid order: %s, must be asc or desc", params.SortOrder))
 	}
-
-	if len(params.Difficulties) > 0 {
-		questions = filterByDifficulties(questions, params.Difficulties)
-	}
-
-	// Apply sorting
-	questions = sortQuestions(questions, params.SortBy, params.SortOrder)
-
-	// Ensure no nil slices are returned
-	if questions == nil {
-		questions = []model.Question{}
-	}
-	return questions, nil
-}
-
-// Filter by search query
-func filterBySearchQuery(questions []model.Question, query string) []model.Question {
-	var filtered []model.Question
-	query = strings.ToLower(query)
-	for _, question := range questions {
-		if strings.Contains(strings.ToLower(question.Title), query) {
-			filtered = append(filtered, question)
-		}
+	if match := strings.ToLower(params.TagMatch); match != "" && match != "any" && match != "all" {
+		return nil, model.NewCustomError(400, fmt.Sprintf("invalid tag_match: %s, must be any or all", params.TagMatch))
 	}
-	return filtered
-}
-
-// Filter by categories
-func filterByCategories(questions []model.Question, categories []string) []model.Question {
-	categorySet := make(map[string]struct{}, len(categories))
-	for _, category := range categories {
-		if category != "" {
-			categorySet[category] = struct{}{}
+	params.TagMatch = strings.ToLower(params.TagMatch)
+	if len(params.Categories) > 0 {
+		categories, err := s.expandCategories(params.Categories)
+		if err != nil {
+			return nil, err
 		}
+		params.Categories = categories
 	}
-
-	var filtered []model.Question
-	for _, question := range questions {
-		if _, exists := categorySet[question.Category]; exists {
-			filtered = append(filtered, question)
-		}
+	if params.Limit < 0 || params.Limit > model.MaxPageSize {
+		return nil, model.NewCustomError(400, fmt.Sprintf("limit must be between 1 and %d", model.MaxPageSize))
 	}
-	return filtered
-}
-
-// Filter by difficulties
-func filterByDifficulties(questions []model.Question, difficulties []string) []model.Question {
-	difficultySet := make(map[string]struct{}, len(difficulties))
-	for _, difficulty := range difficulties {
-		if difficulty != "" {
-			difficultySet[difficulty] = struct{}{}
-		}
+	if len(params.Fields) == 0 {
+		params.Fields = model.QuestionSummaryFields
 	}
-
-	var filtered []model.Question
-	for _, question := range questions {
-		if _, exists := difficultySet[question.Difficulty]; exists {
-			filtered = append(filtered, question)
+	for _, field := range params.Fields {
+		if !slices.Contains(model.QuestionListFields, field) {
+			return nil, model.NewCustomError(400, fmt.Sprintf("invalid field: %s, must be one of %v", field, model.QuestionListFields))
 		}
 	}
-	return filtered
-}
-
-// Sort questions
-func sortQuestions(questions []model.Question, sortBy, sortOrder string) []model.Question {
-	sort.SliceStable(questions, func(i, j int) bool {
-		var less bool
-		switch sortBy {
-		case "stats":
-			less = questions[i].Stats < questions[j].Stats
-		case "difficulty":
-			order := map[string]int{"easy": 1, "medium": 2, "hard": 3}
-			less = order[strings.ToLower(questions[i].Difficulty)] < order[strings.ToLower(questions[j].Difficulty)]
-		case "category":
-			less = strings.ToLower(questions[i].Category) < strings.ToLower(questions[j].Category)
-		default: // Default to sorting by title
-			less = strings.ToLower(questions[i].Title) < strings.ToLower(questions[j].Title)
-		}
-		if strings.ToLower(sortOrder) == "desc" {
-			return !less
-		}
-		return less
-	})
-	return questions
+	return s.Repo.ListQuestions(params)
 }
 
-// UpdateQuestion updates an existing question in the repository.
-func (s *QuestionService) UpdateQuestion(id string, question model.Question) (*model.Question, error) {
-	err:=ValidateQuestion(&question)
-	if err!=nil{
-		return nil, err
-    }
+// UpdateQuestion replaces the content of an existing question, recording the change as a new revision by author.
+// It fails with 412 unless the question is at expectedRevision. A question sent without its reference solution,
+// checker code or judges keeps the stored ones.
+func (s *QuestionService) UpdateQuestion(id string, question model.Question, expectedRevision int, author string) (*model.Question, error) {
 	objID, err := handleInvalidID(id)
 	if err != nil {
 		return nil, err
 	}
-	_, err = s.Repo.UpdateQuestion(objID, question)
+	current, err := s.Repo.GetQuestionByID(objID)
 	if err != nil {
 		return nil, err
 	}
-	question.ID = objID
-	return &question, nil
+	if err := checkRevision(current, expectedRevision); err != nil {
+		return nil, err
+	}
+	question.KeepSource(current)
+	question.KeepCategoryChange(current)
+	if err := s.validateQuestion(&question); err != nil {
+		return nil, err
+	}
+	return s.commitRevision(current, question, author, "")
 }
 
-// DeleteQuestion deletes a question by its ID from the repository.
+// DeleteQuestion moves a question to the trash by its ID, it can be restored until the trash is purged.
 func (s *QuestionService) DeleteQuestion(id string) error {
 	objID, err := handleInvalidID(id)
 	if err != nil {
@@ -207,26 +167,117 @@ func (s *QuestionService) TestUniqueQuestion(questionID string, submission model
 		return nil, model.NewCustomError(404, "Question not found with ID: "+questionID)
 	}
 
-	// Step 3: Create UniqueTester and execute
-	uniqueTester := tester.NewUniqueTester(
-		s.SharedTester,
-		fmt.Sprintf("job-%s", requestID),
-		config.GlobalLanguageConfigs[submission.Language].ImageName,
-		tester.GetRuntime(submission.Language),
-		model.GetFileExtension(submission.Language),
-		requestID, submission.Language,
-	)
-	script, err := tester.CreateTestRunnerScript(submission.Language, *question, submission.Code)
+	if question.IsInteractive() && (question.InteractiveConfig == nil || question.InteractiveConfig.Judge[submission.Language] == "") {
+		return nil, model.NewCustomError(400, fmt.Sprintf("this interactive question cannot be solved in %s", submission.Language))
+	}
+	if question.IsStdio() && !slices.Contains(model.StdioLanguages, submission.Language) {
+		return nil, model.NewCustomError(400, fmt.Sprintf("stdio questions cannot be solved in %s", submission.Language))
+	}
+
+	// Step 2: Custom inputs replace the question's test cases
+	if len(submission.CustomTestCases) > 0 {
+		testCases, err := s.prepareCustomTestCases(*question, submission.CustomTestCases, requestID)
+		if err != nil {
+			return nil, err
+		}
+		question.TestCases = testCases
+	}
+
+	feedback, err := s.runSubmission(*question, submission, requestID)
 	if err != nil {
 		return nil, err
 	}
-	var rawLogs string
-	if config.GlobalConfigAPI.ModeEnv == "production" {
+	if err := s.applyChecker(*question, feedback, requestID); err != nil {
+		return nil, err
+	}
+	feedback.QuestionRevision = question.Revision
+	return feedback, nil
+}
 
-		rawLogs, err = uniqueTester.ExecuteUniqueTestProducton(script)
-	} else {
-		rawLogs, err = uniqueTester.ExecuteUniqueTestDevelopment(script)
+// RecordSubmission counts a submission graded by TestUniqueQuestion in the stats of its question, for solver when
+// not empty. Runs on custom test cases and submissions that could not be graded are not counted.
+func (s *QuestionService) RecordSubmission(questionID string, submission model.Submission, feedback *model.Feedback, solver string) error {
+	if feedback == nil || len(submission.CustomTestCases) > 0 {
+		return nil
 	}
+	if feedback.Error != nil && *feedback.Error == model.InternalServerError {
+		return nil
+	}
+	objID, err := handleInvalidID(questionID)
+	if err != nil {
+		return err
+	}
+	return s.Repo.RecordSubmission(objID, model.GradedSubmission{
+		Language:  string(submission.Language),
+		Accepted:  feedback.Status == "success",
+		RuntimeMs: feedback.RuntimeMs,
+		Solver:    strings.TrimSpace(solver),
+	})
+}
+
+// prepareCustomTestCases validates user-provided test cases against the parameter types and constraints.
+// Missing expected outputs are computed with the reference solution when the question has one.
+func (s *QuestionService) prepareCustomTestCases(question model.Question, customTestCases []model.InputOutput, requestID string) ([]model.InputOutput, error) {
+	if question.IsClassDesign() || question.IsInteractive() || question.IsStdio() {
+		var issues []model.ValidationIssue
+		for i, testCase := range customTestCases {
+			location := fmt.Sprintf("custom_test_cases[%d]", i)
+			switch {
+			case question.IsInteractive():
+				issues = append(issues, validateJudgeInput(testCase, location)...)
+			case question.IsStdio():
+				issues = append(issues, validateStdin(testCase, location)...)
+			default:
+				issues = append(issues, parser_validator.ValidateOperationSequence(testCase, question.ClassConfig, location)...)
+			}
+		}
+		return customTestCases, model.NewValidationError(issues)
+	}
+	if question.FunctionConfig.Parameters == nil || question.FunctionConfig.OutputType() == nil {
+		return nil, model.NewCustomError(400, "question function configuration is incomplete")
+	}
+	var issues []model.ValidationIssue
+	var missing []int
+	for i, testCase := range customTestCases {
+		location := fmt.Sprintf("custom_test_cases[%d]", i)
+		if testCase.ExpectedOutput != "" {
+			issues = append(issues, validateInputOutput(testCase, question.FunctionConfig, location)...)
+			continue
+		}
+		missing = append(missing, i)
+		issues = append(issues, validateParameters(testCase, *question.FunctionConfig.Parameters, location)...)
+		if question.ReferenceSolution == nil {
+			issues = append(issues, model.ValidationIssue{Location: location + ".expected_output", Message: "expected output is required, the question has no reference solution"})
+		}
+	}
+	if err := model.NewValidationError(issues); err != nil {
+		return nil, err
+	}
+
+	testCases := append([]model.InputOutput(nil), customTestCases...)
+	if len(missing) > 0 {
+		computed := make([]model.InputOutput, len(missing))
+		for i, index := range missing {
+			computed[i] = model.InputOutput{Parameters: testCases[index].Parameters}
+		}
+		if err := s.computeExpectedOutputs(question, computed, requestID); err != nil {
+			return nil, err
+		}
+		for i, index := range missing {
+			testCases[index].ExpectedOutput = computed[i].ExpectedOutput
+		}
+	}
+	return testCases, nil
+}
+
+// runSubmission runs submission against the test cases of question and returns the parsed feedback
+func (s *QuestionService) runSubmission(question model.Question, submission model.Submission, requestID string) (*model.Feedback, error) {
+	// Step 3: Create the test runner and execute
+	script, err := tester.CreateTestRunnerScript(submission.Language, question, submission.Code)
+	if err != nil {
+		return nil, err
+	}
+	rawLogs, err := s.executeScript(submission.Language, script, requestID)
 	if err != nil {
 		return nil, err
 	}
@@ -247,48 +298,387 @@ func (s *QuestionService) TestUniqueQuestion(questionID string, submission model
 	return &feedback, nil
 }
 
-func ValidateQuestion(question *model.Question) error {
-	if question == nil {
-		return fmt.Errorf("question cannot be null")
+// executeScript runs script in language, as a Job in production and locally otherwise, and returns its output
+func (s *QuestionService) executeScript(language model.PredefinedSupportedLanguage, script string, requestID string) (string, error) {
+	uniqueTester := tester.NewUniqueTester(
+		s.SharedTester,
+		fmt.Sprintf("job-%s", requestID),
+		config.GlobalLanguageConfigs[language].ImageName,
+		tester.GetRuntime(language),
+		model.GetFileExtension(language),
+		requestID, language,
+	)
+	if config.GlobalConfigAPI.ModeEnv == "production" {
+		return uniqueTester.ExecuteUniqueTestProducton(script)
 	}
-	if question.FunctionConfig.Parameters == nil {
-		return fmt.Errorf("function configuration parameters cannot be null")//yet...
+	return uniqueTester.ExecuteUniqueTestDevelopment(script)
+}
+
+// applyChecker grades the outputs in feedback with the checker of question, replacing the
+// comparator's verdict of every result that holds a valid output with the checker's one
+func (s *QuestionService) applyChecker(question model.Question, feedback *model.Feedback, requestID string) error {
+	if question.Checker == nil || (feedback.Error != nil && *feedback.Error == model.CompilationError) {
+		return nil
 	}
-	if question.FunctionConfig.ReturnType == nil {
-		return fmt.Errorf("function configuration return type cannot be null")//yet...
+	if len(feedback.Results) != len(question.TestCases) {
+		return model.NewCustomError(500, fmt.Sprintf("got %d results for %d test cases", len(feedback.Results), len(question.TestCases)))
 	}
-	err:= coding.ValidateCharacters(question)
-	if err!=nil{
-        return err
-    }
-	// Validate function configuration - example/test against functionConfig
-	for _, example := range question.Examples {
-		if len(example.Parameters) != len(*question.FunctionConfig.Parameters) {
-			return fmt.Errorf("example parameters count mismatch")
+
+	var cases []model.CheckerCase
+	var checked []int
+	for i, result := range feedback.Results {
+		var actualOutput string
+		if err := json.Unmarshal(result.ActualOutput, &actualOutput); err != nil {
+			actualOutput = string(result.ActualOutput)
 		}
-		for i, param := range *question.FunctionConfig.Parameters {
-			if err := parser_validator.ValidateAbstractType(example.Parameters[i], &param.ParamType); err != nil {
-				return fmt.Errorf("example %d, parameter '%s': %v", i, param.Name, err)
+		value, err := parser_validator.Parse(actualOutput, question.FunctionConfig.OutputType())
+		if err != nil {
+			// The user's code crashed or returned something else, the checker has nothing to grade
+			message := fmt.Sprintf("output is not a valid %s", question.FunctionConfig.OutputType().ToPrint())
+			feedback.Results[i].Status = "fail"
+			feedback.Results[i].Message = &message
+			continue
+		}
+		checkerCase, err := newCheckerCase(question, question.TestCases[i], value.Format())
+		if err != nil {
+			return model.NewCustomError(500, fmt.Sprintf("test case %d: %v", i, err))
+		}
+		cases = append(cases, checkerCase)
+		checked = append(checked, i)
+	}
+
+	if len(cases) > 0 {
+		script, err := tester.CreateCheckerScript(question, cases)
+		if err != nil {
+			return err
+		}
+		rawLogs, err := s.executeScript(question.Checker.Language, script, requestID+"-checker")
+		if err != nil {
+			return err
+		}
+		var checkerFeedback model.CheckerFeedback
+		if err := json.Unmarshal([]byte(rawLogs), &checkerFeedback); err != nil {
+			return model.NewCustomError(500, fmt.Sprintf("failed to parse checker logs: %v", err))
+		}
+		if checkerFeedback.Error != nil {
+			return model.NewCustomError(500, "checker failed: "+*checkerFeedback.Error)
+		}
+		if len(checkerFeedback.Verdicts) != len(cases) {
+			return model.NewCustomError(500, fmt.Sprintf("checker returned %d verdicts for %d cases", len(checkerFeedback.Verdicts), len(cases)))
+		}
+		for i, verdict := range checkerFeedback.Verdicts {
+			message := verdict.Message
+			feedback.Results[checked[i]].Status = verdict.Status
+			feedback.Results[checked[i]].Message = &message
+		}
+	}
+
+	// The overall status follows the checker's verdicts
+	feedback.Status = "success"
+	feedback.Error = nil
+	feedback.Details = nil
+	for _, result := range feedback.Results {
+		if result.Status != "pass" {
+			failTests := model.FailTestsError
+			feedback.Status = "fail"
+			feedback.Error = &failTests
+			break
+		}
+	}
+	return nil
+}
+
+// newCheckerCase formats testCase and actualOutput the way the test runners receive their test cases
+func newCheckerCase(question model.Question, testCase model.InputOutput, actualOutput string) (model.CheckerCase, error) {
+	checkerCase := model.CheckerCase{Parameters: make([]string, len(testCase.Parameters)), ActualOutput: actualOutput}
+	for i, param := range *question.FunctionConfig.Parameters {
+		if i >= len(testCase.Parameters) {
+			return checkerCase, fmt.Errorf("parameters count mismatch")
+		}
+		value, err := parser_validator.Parse(testCase.Parameters[i], &param.ParamType)
+		if err != nil {
+			return checkerCase, fmt.Errorf("parameter '%s': %v", param.Name, err)
+		}
+		checkerCase.Parameters[i] = value.Format()
+	}
+	value, err := parser_validator.Parse(testCase.ExpectedOutput, question.FunctionConfig.OutputType())
+	if err != nil {
+		return checkerCase, fmt.Errorf("expected output: %v", err)
+	}
+	checkerCase.ExpectedOutput = value.Format()
+	return checkerCase, nil
+}
+
+// maxGeneratedTestCases caps the number of test cases generated per request
+const maxGeneratedTestCases = 500
+
+// GenerateTestCases generates random test cases for a question. Expected outputs are computed
+// by running the question's reference solution; without one they are left empty for the author to fill in.
+func (s *QuestionService) GenerateTestCases(questionID string, request model.TestCaseGenerationRequest, requestID string) (*model.TestCaseGenerationResult, error) {
+	if request.Count < 1 || request.Count > maxGeneratedTestCases {
+		return nil, model.NewCustomError(400, fmt.Sprintf("count must be between 1 and %d", maxGeneratedTestCases))
+	}
+	objID, err := handleInvalidID(questionID)
+	if err != nil {
+		return nil, err
+	}
+	question, err := s.Repo.GetQuestionByID(objID)
+	if err != nil {
+		return nil, model.NewCustomError(404, "Question not found with ID: "+questionID)
+	}
+	if question.IsClassDesign() || question.IsInteractive() || question.IsStdio() {
+		return nil, model.NewCustomError(400, fmt.Sprintf("test cases cannot be generated for %s questions", question.Kind))
+	}
+	if question.FunctionConfig.Parameters == nil || question.FunctionConfig.OutputType() == nil {
+		return nil, model.NewCustomError(400, "question function configuration is incomplete")
+	}
+	if request.Append && question.ReferenceSolution == nil {
+		return nil, model.NewCustomError(400, "a reference solution is required to append generated test cases")
+	}
+	parameters := *question.FunctionConfig.Parameters
+	for name := range request.Parameters {
+		if !hasParameter(parameters, name) {
+			return nil, model.NewCustomError(400, "unknown parameter: "+name)
+		}
+	}
+
+	seed := time.Now().UnixNano()
+	if request.Seed != nil {
+		seed = *request.Seed
+	}
+	generator := parser_validator.NewRandomGenerator(seed)
+	testCases := make([]model.InputOutput, request.Count)
+	for i := range testCases {
+		testCases[i].Parameters = make([]string, len(parameters))
+		for j, param := range parameters {
+			input, err := generateParameterValue(generator, param, request.Parameters)
+			if err != nil {
+				return nil, err
 			}
+			testCases[i].Parameters[j] = input
+		}
+	}
+
+	result := &model.TestCaseGenerationResult{Seed: seed, TestCases: testCases}
+	if question.ReferenceSolution == nil {
+		return result, nil
+	}
+	if err := s.computeExpectedOutputs(*question, testCases, requestID); err != nil {
+		return nil, err
+	}
+	if request.Append {
+		updated := *question
+		updated.TestCases = append(slices.Clone(question.TestCases), testCases...)
+		message := fmt.Sprintf("appended %d generated test cases (seed %d)", len(testCases), seed)
+		if _, err := s.commitRevision(question, updated, model.AnonymousAuthor, message); err != nil {
+			return nil, err
+		}
+		result.Appended = true
+	}
+	return result, nil
+}
+
+// computeExpectedOutputs fills in the expected outputs of testCases by running the reference solution of question
+func (s *QuestionService) computeExpectedOutputs(question model.Question, testCases []model.InputOutput, requestID string) error {
+	// The reference run only needs actual outputs, any valid value will do as the expected one
+	placeholder := parser_validator.GenerateValidString(question.FunctionConfig.OutputType())
+	question.TestCases = make([]model.InputOutput, len(testCases))
+	for i, testCase := range testCases {
+		question.TestCases[i] = model.InputOutput{Parameters: testCase.Parameters, ExpectedOutput: placeholder}
+	}
+
+	feedback, err := s.runSubmission(question, *question.ReferenceSolution, requestID)
+	if err != nil {
+		return err
+	}
+	if feedback.Error != nil && *feedback.Error == model.CompilationError {
+		return model.NewCustomError(500, "reference solution failed to compile: "+stringOrEmpty(feedback.Details))
+	}
+	if len(feedback.Results) != len(testCases) {
+		return model.NewCustomError(500, fmt.Sprintf("reference solution returned %d results for %d test cases", len(feedback.Results), len(testCases)))
+	}
+	for i, result := range feedback.Results {
+		var actualOutput string
+		if err := json.Unmarshal(result.ActualOutput, &actualOutput); err != nil {
+			actualOutput = string(result.ActualOutput)
+		}
+		value, err := parser_validator.Parse(actualOutput, question.FunctionConfig.OutputType())
+		if err != nil {
+			return model.NewCustomError(500, fmt.Sprintf("reference solution output for test case %d is invalid: %v", i, err))
+		}
+		testCases[i].ExpectedOutput = value.Format()
+	}
+	return nil
+}
+
+// maxConstraintTries bounds the attempts to generate a value that satisfies the parameter constraints
+const maxConstraintTries = 100
+
+// generateParameterValue generates a value for param. Options default to the parameter constraints,
+// and values breaking the constraints (e.g. a tree that is too high) are generated again.
+func generateParameterValue(generator *parser_validator.RandomGenerator, param model.Parameter, requestOptions map[string]model.GeneratorOptions) (string, error) {
+	options, ok := requestOptions[param.Name]
+	if !ok {
+		options = param.Constraints.GeneratorOptions()
+	}
+	for try := 0; try < maxConstraintTries; try++ {
+		value := generator.Generate(&param.ParamType, options)
+		if err := generator.Err(); err != nil {
+			return "", model.NewValidationError([]model.ValidationIssue{{Location: "parameters." + param.Name, Message: err.Error()}})
 		}
-		if err := parser_validator.ValidateAbstractType(example.ExpectedOutput, question.FunctionConfig.ReturnType); err != nil {
-			return fmt.Errorf("example expected output: %v", err)
+		if len(parser_validator.CheckConstraints(value, param.Constraints, param.Name)) == 0 {
+			return value.Format(), nil
 		}
 	}
+	return "", model.NewCustomError(400, fmt.Sprintf("could not generate a value for parameter '%s' within its constraints: %s", param.Name, param.Constraints))
+}
 
-	for _, testCase := range question.TestCases {
-		if len(testCase.Parameters) != len(*question.FunctionConfig.Parameters) {
-			return fmt.Errorf("test case parameters count mismatch")
+func hasParameter(parameters []model.Parameter, name string) bool {
+	for _, param := range parameters {
+		if param.Name == name {
+			return true
 		}
+	}
+	return false
+}
+
+func stringOrEmpty(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+// ValidateQuestion checks the function configuration and every example and test case against it.
+// All problems are reported together as a *model.ValidationError.
+func ValidateQuestion(question *model.Question) error {
+	if question == nil {
+		return fmt.Errorf("question cannot be null")
+	}
+	question.NormalizeTaxonomy()
+	switch question.Kind {
+	case "", model.FunctionQuestion:
+	case model.ClassQuestion:
+		return validateClassQuestion(question)
+	case model.InteractiveQuestion:
+		return validateInteractiveQuestion(question)
+	case model.StdioQuestion:
+		return validateStdioQuestion(question)
+	default:
+		return model.NewValidationError([]model.ValidationIssue{{Location: "kind", Value: string(question.Kind), Message: fmt.Sprintf("unknown question kind, must be one of %v", model.QuestionKinds)}})
+	}
+	var issues []model.ValidationIssue
+	if question.FunctionConfig.Parameters == nil {
+		issues = append(issues, model.ValidationIssue{Location: "function_config.parameters", Message: "function configuration parameters cannot be null"}) //yet...
+	}
+	issues = append(issues, validateMutatedParameter(question.FunctionConfig)...)
+	issues = append(issues, coding.ValidateJavaTypes(question.FunctionConfig, "function_config")...)
+	if err := coding.ValidateCharacters(question); err != nil {
+		issues = append(issues, model.ValidationIssue{Location: "function_config", Message: err.Error()})
+	}
+	if question.FunctionConfig.Parameters != nil {
 		for i, param := range *question.FunctionConfig.Parameters {
-			if err := parser_validator.ValidateAbstractType(testCase.Parameters[i], &param.ParamType); err != nil {
-				return fmt.Errorf("test case %d, parameter '%s': %v", i, param.Name, err)
+			if err := param.Constraints.Validate(); err != nil {
+				issues = append(issues, model.ValidationIssue{Location: fmt.Sprintf("function_config.parameters[%d].constraints", i), Message: err.Error()})
 			}
 		}
-		if err := parser_validator.ValidateAbstractType(testCase.ExpectedOutput, question.FunctionConfig.ReturnType); err != nil {
-			return fmt.Errorf("test case expected output: %v", err)
+	}
+	if err := parser_validator.ValidateComparator(question.Comparator, question.FunctionConfig.OutputType(), question.Languages); err != nil {
+		issues = append(issues, model.ValidationIssue{Location: "comparator", Message: err.Error()})
+	}
+	if question.Checker != nil {
+		if !slices.Contains(model.CheckerLanguages, question.Checker.Language) {
+			issues = append(issues, model.ValidationIssue{Location: "checker.language", Value: string(question.Checker.Language), Message: fmt.Sprintf("unsupported checker language, must be one of %v", model.CheckerLanguages)})
+		}
+		if strings.TrimSpace(question.Checker.Code) == "" {
+			issues = append(issues, model.ValidationIssue{Location: "checker.code", Message: "checker code cannot be empty"})
 		}
 	}
+	if question.ReferenceSolution != nil && model.GetFileExtension(question.ReferenceSolution.Language) == "" {
+		issues = append(issues, model.ValidationIssue{Location: "reference_solution.language", Message: fmt.Sprintf("unsupported language: %s", question.ReferenceSolution.Language)})
+	}
+	if len(issues) > 0 {
+		return model.NewValidationError(issues)
+	}
 
+	// Validate function configuration - example/test against functionConfig
+	for i, example := range question.Examples {
+		issues = append(issues, validateInputOutput(example, question.FunctionConfig, fmt.Sprintf("examples[%d]", i))...)
+	}
+	for i, testCase := range question.TestCases {
+		issues = append(issues, validateInputOutput(testCase, question.FunctionConfig, fmt.Sprintf("test_cases[%d]", i))...)
+	}
+
+	return model.NewValidationError(issues)
+}
+
+// programLanguages returns the languages question can be solved in, all of supported when it lists none,
+// with an issue for every listed language outside supported
+func programLanguages(question *model.Question, supported []model.PredefinedSupportedLanguage) ([]model.PredefinedSupportedLanguage, []model.ValidationIssue) {
+	if len(question.Languages) == 0 {
+		return supported, nil
+	}
+	var languages []model.PredefinedSupportedLanguage
+	var issues []model.ValidationIssue
+	for i, language := range question.Languages {
+		if !slices.Contains(supported, model.PredefinedSupportedLanguage(language)) {
+			issues = append(issues, model.ValidationIssue{Location: fmt.Sprintf("languages[%d]", i), Value: language, Message: fmt.Sprintf("%s questions can only be solved in %v", question.Kind, supported)})
+			continue
+		}
+		languages = append(languages, model.PredefinedSupportedLanguage(language))
+	}
+	return languages, issues
+}
+
+// validateMutatedParameter checks that a function either returns its output or, when void,
+// names a composite parameter it modifies in place
+func validateMutatedParameter(functionConfig model.FunctionConfig) []model.ValidationIssue {
+	if !functionConfig.IsVoid() {
+		if functionConfig.MutatedParameter != "" {
+			return []model.ValidationIssue{{Location: "function_config.mutated_parameter", Value: functionConfig.MutatedParameter, Message: "mutated parameter is only used by functions without a return type"}}
+		}
+		return nil
+	}
+	if functionConfig.MutatedParameter == "" {
+		return []model.ValidationIssue{{Location: "function_config.return_type", Message: "function configuration needs a return type, or a mutated parameter when it returns nothing"}}
+	}
+	if functionConfig.Parameters == nil {
+		return nil
+	}
+	index := functionConfig.MutatedParameterIndex()
+	if index < 0 {
+		return []model.ValidationIssue{{Location: "function_config.mutated_parameter", Value: functionConfig.MutatedParameter, Message: "no parameter with this name"}}
+	}
+	paramType := (*functionConfig.Parameters)[index].ParamType
+	if slices.Contains(model.AtomicTypes, model.AtomicType(paramType.Type)) {
+		return []model.ValidationIssue{{Location: "function_config.mutated_parameter", Expected: "Array, Matrix, ListNode, TreeNode or Graph", Value: paramType.ToPrint(), Message: "atomic parameters cannot be modified in place"}}
+	}
 	return nil
 }
+
+// validateInputOutput validates one example or test case, located at location (e.g. "test_cases[3]")
+func validateInputOutput(inputOutput model.InputOutput, functionConfig model.FunctionConfig, location string) []model.ValidationIssue {
+	issues := validateParameters(inputOutput, *functionConfig.Parameters, location)
+	issues = append(issues, parser_validator.CollectValidationIssues(inputOutput.ExpectedOutput, functionConfig.OutputType(), location+".expected_output")...)
+	return issues
+}
+
+// validateParameters validates the parameters of an example or test case against their types and constraints
+func validateParameters(inputOutput model.InputOutput, parameters []model.Parameter, location string) []model.ValidationIssue {
+	var issues []model.ValidationIssue
+	if len(inputOutput.Parameters) != len(parameters) {
+		issues = append(issues, model.ValidationIssue{
+			Location: location + ".parameters",
+			Message:  fmt.Sprintf("parameters count mismatch: expected %d, got %d", len(parameters), len(inputOutput.Parameters)),
+		})
+	} else {
+		for i, param := range parameters {
+			paramLocation := fmt.Sprintf("%s.parameters[%d]", location, i)
+			for _, issue := range parser_validator.ValidateParameterValue(inputOutput.Parameters[i], param, paramLocation) {
+				issue.Message = fmt.Sprintf("parameter '%s': %s", param.Name, issue.Message)
+				issues = append(issues, issue)
+			}
+		}
+	}
+	return issues
+}
@@ -0,0 +1,158 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/model"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// commitRevision replaces current with updated, recording the change as the next revision of the question.
+// The revision is stored first, so that a question never has content no revision describes; it is removed
+// again when the update fails. An update that changes nothing returns current without a new revision.
+// Both the revision and the update fail with 412 when someone else changed the question since current was read.
+func (s *QuestionService) commitRevision(current *model.Question, updated model.Question, author, message string) (*model.Question, error) {
+	diff, err := model.DiffQuestions(current, &updated)
+	if err != nil {
+		return nil, err
+	}
+	if len(diff) == 0 {
+		return current, nil
+	}
+	storedRevision := current.Revision
+	if current.Revision == 0 {
+		// Questions created before revisions get their content as revision 1 before their first change
+		baseline := *current
+		baseline.Revision = 1
+		if err := s.createBaselineRevision(&baseline, "baseline"); err != nil {
+			return nil, err
+		}
+		current = &baseline
+	}
+
+	updated.ID = current.ID
+	updated.Revision = current.Revision + 1
+	updated.Stats = current.Stats // Stats belong to the submissions, not to the content
+	revision, err := s.RevisionRepo.CreateRevision(model.QuestionRevision{
+		QuestionID: current.ID,
+		Revision:   updated.Revision,
+		Author:     authorOrAnonymous(author),
+		CreatedAt:  time.Now().UTC(),
+		Message:    message,
+		Diff:       diff,
+		Snapshot:   &updated,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.Repo.UpdateQuestion(current.ID, updated, storedRevision); err != nil {
+		if deleteErr := s.RevisionRepo.DeleteRevision(revision.ID); deleteErr != nil {
+			return nil, fmt.Errorf("%w (and the revision could not be removed: %v)", err, deleteErr)
+		}
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// createBaselineRevision records question, at its current revision, with no diff
+func (s *QuestionService) createBaselineRevision(question *model.Question, message string) error {
+	_, err := s.RevisionRepo.CreateRevision(model.QuestionRevision{
+		QuestionID: question.ID,
+		Revision:   question.Revision,
+		Author:     model.AnonymousAuthor,
+		CreatedAt:  time.Now().UTC(),
+		Message:    message,
+		Diff:       []model.FieldChange{},
+		Snapshot:   question,
+	})
+	return err
+}
+
+// checkRevision fails with 412 unless question is at expectedRevision
+func checkRevision(question *model.Question, expectedRevision int) error {
+	if expectedRevision != model.AnyRevision && expectedRevision != question.Revision {
+		return model.NewCustomError(412, fmt.Sprintf("Question is at revision %d, not %d, reload it and retry", question.Revision, expectedRevision))
+	}
+	return nil
+}
+
+func authorOrAnonymous(author string) string {
+	if author == "" {
+		return model.AnonymousAuthor
+	}
+	return author
+}
+
+// ListRevisions returns the revisions of a question, newest first, without their snapshots
+func (s *QuestionService) ListRevisions(id string) ([]model.QuestionRevision, error) {
+	objID, err := s.liveQuestionID(id)
+	if err != nil {
+		return nil, err
+	}
+	return s.RevisionRepo.ListRevisions(objID)
+}
+
+// GetRevision returns one revision of a question, with the content of the question at that revision
+func (s *QuestionService) GetRevision(id string, revision int) (*model.QuestionRevision, error) {
+	objID, err := s.liveQuestionID(id)
+	if err != nil {
+		return nil, err
+	}
+	return s.RevisionRepo.GetRevision(objID, revision)
+}
+
+// DiffRevisions lists the changes of a question from revision from to revision to, either may be the older one
+func (s *QuestionService) DiffRevisions(id string, from, to int) (*model.RevisionDiff, error) {
+	objID, err := s.liveQuestionID(id)
+	if err != nil {
+		return nil, err
+	}
+	fromRevision, err := s.RevisionRepo.GetRevision(objID, from)
+	if err != nil {
+		return nil, err
+	}
+	toRevision, err := s.RevisionRepo.GetRevision(objID, to)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := model.DiffQuestions(fromRevision.Snapshot, toRevision.Snapshot)
+	if err != nil {
+		return nil, err
+	}
+	return &model.RevisionDiff{QuestionID: objID, From: from, To: to, Changes: changes}, nil
+}
+
+// liveQuestionID parses the ID of a question that is not in the trash. The history of a trashed question
+// is left out like the question itself, until it is restored.
+func (s *QuestionService) liveQuestionID(id string) (primitive.ObjectID, error) {
+	objID, err := handleInvalidID(id)
+	if err != nil {
+		return primitive.NilObjectID, err
+	}
+	if _, err := s.Repo.GetQuestionByID(objID); err != nil {
+		return primitive.NilObjectID, err
+	}
+	return objID, nil
+}
+
+// RollbackQuestion restores the content of a question at revision. The rollback is itself a new revision,
+// so the revisions after the restored one stay in the history. It fails with 412 unless the question is at expectedRevision.
+func (s *QuestionService) RollbackQuestion(id string, revision int, expectedRevision int, author string) (*model.Question, error) {
+	target, err := s.GetRevision(id, revision)
+	if err != nil {
+		return nil, err
+	}
+	current, err := s.Repo.GetQuestionByID(target.QuestionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkRevision(current, expectedRevision); err != nil {
+		return nil, err
+	}
+	// The validation may have become stricter since the revision was made
+	if err := s.validateQuestion(target.Snapshot); err != nil {
+		return nil, err
+	}
+	return s.commitRevision(current, *target.Snapshot, author, fmt.Sprintf("rollback to revision %d", revision))
+}
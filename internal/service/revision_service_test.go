@@ -0,0 +1,98 @@
+package service_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/model"
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/service"
+)
+
+func TestUpdateQuestionRecordsRevisions(t *testing.T) {
+	stored := validQuestion("Add")
+	stored.Revision = 1
+	stored.Stats = model.QuestionStats{Attempts: 7}
+	stored.ReferenceSolution = &model.Submission{Language: model.Python, Code: "def add(a, b): return a + b"}
+	questions := &stubQuestionRepository{question: &stored}
+	revisions := &stubRevisionRepository{}
+	questionService := service.NewQuestionService(questions, revisions, newStubTaxonomy(), nil)
+
+	edited := validQuestion("Add two numbers")
+	edited.Stats = model.QuestionStats{}
+	updated, err := questionService.UpdateQuestion(stored.ID.Hex(), edited, 1, "ada")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated.Revision != 2 || updated.Stats.Attempts != 7 || updated.ReferenceSolution == nil || questions.question.Title != "Add two numbers" {
+		t.Errorf("expected revision 2 with the stored stats and reference solution, got %+v", updated)
+	}
+	if len(revisions.revisions) != 1 || revisions.revisions[0].Author != "ada" || revisions.revisions[0].Diff[0].Path != "title" {
+		t.Fatalf("expected a revision by ada changing the title, got %+v", revisions.revisions)
+	}
+
+	// Sending the same content again changes nothing
+	if unchanged, err := questionService.UpdateQuestion(stored.ID.Hex(), edited, 2, ""); err != nil || unchanged.Revision != 2 || len(revisions.revisions) != 1 {
+		t.Errorf("expected no new revision, got %+v %v", unchanged, err)
+	}
+}
+
+func TestUpdateQuestionRemovesTheRevisionOfAFailedUpdate(t *testing.T) {
+	stored := validQuestion("Add")
+	stored.Revision = 1
+	updateErr := errors.New("connection lost")
+	revisions := &stubRevisionRepository{}
+	questionService := service.NewQuestionService(&stubQuestionRepository{question: &stored, updateErr: updateErr}, revisions, newStubTaxonomy(), nil)
+
+	if _, err := questionService.UpdateQuestion(stored.ID.Hex(), validQuestion("Add two numbers"), model.AnyRevision, "ada"); !errors.Is(err, updateErr) {
+		t.Errorf("expected the update error, got %v", err)
+	}
+	if len(revisions.deleted) != 1 || revisions.deleted[0] != revisions.revisions[0].ID {
+		t.Errorf("expected the revision to be removed, got %v", revisions.deleted)
+	}
+}
+
+func TestRollbackQuestion(t *testing.T) {
+	stored := validQuestion("Add")
+	questions := &stubQuestionRepository{question: &stored}
+	revisions := &stubRevisionRepository{}
+	questionService := service.NewQuestionService(questions, revisions, newStubTaxonomy(), nil)
+
+	// A question stored before revisions gets its content as revision 1 first
+	if _, err := questionService.UpdateQuestion(stored.ID.Hex(), validQuestion("Add two numbers"), model.AnyRevision, "ada"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(revisions.revisions) != 2 || revisions.revisions[0].Message != "baseline" {
+		t.Fatalf("expected a baseline and an update, got %+v", revisions.revisions)
+	}
+
+	rolledBack, err := questionService.RollbackQuestion(stored.ID.Hex(), 1, 2, "grace")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rolledBack.Title != "Add" || rolledBack.Revision != 3 || revisions.revisions[2].Message != "rollback to revision 1" {
+		t.Errorf("expected the title of revision 1 at revision 3, got %q at %d", rolledBack.Title, rolledBack.Revision)
+	}
+	if _, err := questionService.RollbackQuestion(stored.ID.Hex(), 9, model.AnyRevision, "grace"); statusOf(err) != 404 {
+		t.Errorf("expected a missing revision to be a 404, got %v", err)
+	}
+}
+
+func TestStaleUpdatesFail(t *testing.T) {
+	stored := validQuestion("Add")
+	stored.Revision = 1
+	questions := &stubQuestionRepository{question: &stored}
+	questionService := service.NewQuestionService(questions, &stubRevisionRepository{}, newStubTaxonomy(), nil)
+
+	if _, err := questionService.UpdateQuestion(stored.ID.Hex(), validQuestion("Add two numbers"), 1, "ada"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := questionService.UpdateQuestion(stored.ID.Hex(), validQuestion("Sum"), 1, "grace"); statusOf(err) != 412 {
+		t.Errorf("expected an update of a stale revision to be a 412, got %v", err)
+	}
+	if _, err := questionService.PatchQuestion(stored.ID.Hex(), []byte(`{"title": "Sum"}`), 1, "grace"); statusOf(err) != 412 {
+		t.Errorf("expected a patch of a stale revision to be a 412, got %v", err)
+	}
+	if _, err := questionService.RollbackQuestion(stored.ID.Hex(), 2, 1, "grace"); statusOf(err) != 412 {
+		t.Errorf("expected a rollback of a stale revision to be a 412, got %v", err)
+	}
+}
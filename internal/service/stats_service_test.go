@@ -0,0 +1,55 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/model"
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/service"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestRecordSubmission(t *testing.T) {
+	questions := &stubQuestionRepository{}
+	questionService := service.NewQuestionService(questions, &stubRevisionRepository{}, newStubTaxonomy(), nil)
+	id := primitive.NewObjectID().Hex()
+	submission := model.Submission{Language: model.Python, Code: "def add(a, b): return a + b"}
+	runtime := int64(12)
+	for _, feedback := range []*model.Feedback{{Status: "success", RuntimeMs: &runtime}, {Status: "fail"}} {
+		if err := questionService.RecordSubmission(id, submission, feedback, " ada "); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if len(questions.graded) != 2 {
+		t.Fatalf("expected 2 graded submissions, got %d", len(questions.graded))
+	}
+	first, second := questions.graded[0], questions.graded[1]
+	if !first.Accepted || first.RuntimeMs == nil || *first.RuntimeMs != 12 || first.Solver != "ada" || first.Language != string(model.Python) {
+		t.Errorf("expected an accepted submission by ada with the runtime measured by the evaluator, got %+v", first)
+	}
+	if second.Accepted || second.RuntimeMs != nil {
+		t.Errorf("expected a failed submission without a runtime, got %+v", second)
+	}
+}
+
+func TestRecordSubmissionSkipsUngradedRuns(t *testing.T) {
+	questions := &stubQuestionRepository{}
+	questionService := service.NewQuestionService(questions, &stubRevisionRepository{}, newStubTaxonomy(), nil)
+	internalError := model.InternalServerError
+	custom := model.Submission{Language: model.Python, CustomTestCases: []model.InputOutput{{Parameters: []string{"1", "1"}}}}
+	runs := []struct {
+		submission model.Submission
+		feedback   *model.Feedback
+	}{
+		{custom, &model.Feedback{Status: "success"}},
+		{model.Submission{Language: model.Python}, &model.Feedback{Status: "fail", Error: &internalError}},
+		{model.Submission{Language: model.Python}, nil},
+	}
+	for _, run := range runs {
+		if err := questionService.RecordSubmission(primitive.NewObjectID().Hex(), run.submission, run.feedback, ""); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if len(questions.graded) != 0 {
+		t.Errorf("expected no graded submissions, got %+v", questions.graded)
+	}
+}
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/model"
+)
+
+// validateStdioQuestion checks the output matching settings and the stdin of every example and test case.
+// Outputs are matched as text, so comparators, checkers and reference solutions are not supported.
+func validateStdioQuestion(question *model.Question) error {
+	_, issues := programLanguages(question, model.StdioLanguages)
+	if stdioConfig := question.StdioConfig; stdioConfig != nil {
+		if !slices.Contains(model.WhitespaceModes, stdioConfig.GetWhitespace()) {
+			issues = append(issues, model.ValidationIssue{Location: "stdio_config.whitespace", Value: string(stdioConfig.Whitespace), Message: fmt.Sprintf("unknown whitespace mode, must be one of %v", model.WhitespaceModes)})
+		}
+		if stdioConfig.TimeLimit < 0 || stdioConfig.TimeLimit > model.MaxStdioTimeLimit {
+			issues = append(issues, model.ValidationIssue{Location: "stdio_config.time_limit", Message: fmt.Sprintf("time limit must be between 0 and %v seconds", model.MaxStdioTimeLimit)})
+		}
+	}
+	if question.Comparator != nil {
+		issues = append(issues, model.ValidationIssue{Location: "comparator", Message: "stdio questions match outputs as text, comparators are not supported"})
+	}
+	if question.Checker != nil {
+		issues = append(issues, model.ValidationIssue{Location: "checker", Message: "stdio questions match outputs as text, checkers are not supported"})
+	}
+	if question.ReferenceSolution != nil {
+		issues = append(issues, model.ValidationIssue{Location: "reference_solution", Message: "stdio questions do not support reference solutions"})
+	}
+
+	for i, example := range question.Examples {
+		issues = append(issues, validateStdin(example, fmt.Sprintf("examples[%d]", i))...)
+	}
+	for i, testCase := range question.TestCases {
+		issues = append(issues, validateStdin(testCase, fmt.Sprintf("test_cases[%d]", i))...)
+	}
+	return model.NewValidationError(issues)
+}
+
+// validateStdin checks that a stdio test case holds one parameter, the stdin text
+func validateStdin(inputOutput model.InputOutput, location string) []model.ValidationIssue {
+	if len(inputOutput.Parameters) != 1 {
+		return []model.ValidationIssue{{Location: location + ".parameters", Message: fmt.Sprintf("a stdio test case has 1 parameter, the stdin text, got %d", len(inputOutput.Parameters))}}
+	}
+	return nil
+}
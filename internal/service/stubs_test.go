@@ -0,0 +1,186 @@
+package service_test
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/model"
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// stubQuestionRepository holds one stored question, the methods a test does not set up panic
+type stubQuestionRepository struct {
+	repository.QuestionRepositoryInterface
+	question  *model.Question
+	updateErr error // Returned by UpdateQuestion instead of storing the question
+	listed    model.QuestionQueryParams
+	purged    []primitive.ObjectID // Returned by PurgeTrash
+	created   []model.Question
+	// Returned by CountCategorySets, by whether the trashed questions are included
+	categorySets map[bool][]repository.CategorySetCount
+	graded       []model.GradedSubmission
+}
+
+func (r *stubQuestionRepository) GetQuestionByID(id primitive.ObjectID) (*model.Question, error) {
+	if r.question == nil || r.question.ID != id || r.question.DeletedAt != nil {
+		return nil, model.NewCustomError(404, "Question not found with ID: "+id.Hex())
+	}
+	stored := *r.question
+	return &stored, nil
+}
+
+func (r *stubQuestionRepository) GetQuestionByTitle(title string) (*model.Question, error) {
+	if r.question == nil || r.question.Title != title {
+		return nil, nil
+	}
+	stored := *r.question
+	return &stored, nil
+}
+
+func (r *stubQuestionRepository) CreateQuestion(question model.Question) (*model.Question, error) {
+	question.ID = primitive.NewObjectID()
+	r.created = append(r.created, question)
+	return &question, nil
+}
+
+func (r *stubQuestionRepository) ExportQuestions(visit func(model.Question) error) error {
+	if r.question == nil {
+		return nil
+	}
+	return visit(*r.question)
+}
+
+func (r *stubQuestionRepository) ListQuestions(params model.QuestionQueryParams) (*model.QuestionPage, error) {
+	r.listed = params
+	return &model.QuestionPage{}, nil
+}
+
+func (r *stubQuestionRepository) RestoreQuestion(id primitive.ObjectID) (*model.Question, error) {
+	if r.question == nil || r.question.ID != id || r.question.DeletedAt == nil {
+		return nil, model.NewCustomError(404, "Question not found in the trash for ID: "+id.Hex())
+	}
+	r.question.DeletedAt = nil
+	restored := *r.question
+	return &restored, nil
+}
+
+func (r *stubQuestionRepository) PurgeTrash(deletedBefore time.Time) ([]primitive.ObjectID, error) {
+	return r.purged, nil
+}
+
+func (r *stubQuestionRepository) CountCategorySets(includeTrashed bool) ([]repository.CategorySetCount, error) {
+	return r.categorySets[includeTrashed], nil
+}
+
+func (r *stubQuestionRepository) RecordSubmission(id primitive.ObjectID, submission model.GradedSubmission) error {
+	r.graded = append(r.graded, submission)
+	return nil
+}
+
+func (r *stubQuestionRepository) UpdateQuestion(id primitive.ObjectID, question model.Question, revision int) (bool, error) {
+	if r.updateErr != nil {
+		return false, r.updateErr
+	}
+	if r.question.Revision != revision {
+		return false, model.NewCustomError(412, "Question was changed by someone else, reload it and retry")
+	}
+	r.question = &question
+	return true, nil
+}
+
+// stubRevisionRepository keeps the revisions in memory, in creation order
+type stubRevisionRepository struct {
+	repository.RevisionRepositoryInterface
+	revisions []model.QuestionRevision
+	deleted   []primitive.ObjectID
+	purged    []primitive.ObjectID // The questions of DeleteQuestionRevisions
+}
+
+func (r *stubRevisionRepository) CreateRevision(revision model.QuestionRevision) (*model.QuestionRevision, error) {
+	revision.ID = primitive.NewObjectID()
+	r.revisions = append(r.revisions, revision)
+	return &revision, nil
+}
+
+func (r *stubRevisionRepository) GetRevision(questionID primitive.ObjectID, number int) (*model.QuestionRevision, error) {
+	for _, revision := range r.revisions {
+		if revision.QuestionID == questionID && revision.Revision == number {
+			return &revision, nil
+		}
+	}
+	return nil, model.NewCustomError(404, "Revision not found for question ID: "+questionID.Hex())
+}
+
+func (r *stubRevisionRepository) DeleteRevision(id primitive.ObjectID) error {
+	r.deleted = append(r.deleted, id)
+	return nil
+}
+
+func (r *stubRevisionRepository) DeleteQuestionRevisions(questionIDs []primitive.ObjectID) error {
+	r.purged = append(r.purged, questionIDs...)
+	return nil
+}
+
+// stubCategoryRepository keeps the taxonomy in memory
+type stubCategoryRepository struct {
+	categories []model.Category
+}
+
+// newStubTaxonomy returns a taxonomy of the predefined categories
+func newStubTaxonomy() *stubCategoryRepository {
+	taxonomy := &stubCategoryRepository{}
+	for _, category := range model.PredefinedCategories {
+		taxonomy.categories = append(taxonomy.categories, model.Category{Name: string(category)})
+	}
+	return taxonomy
+}
+
+func (r *stubCategoryRepository) ListCategories() ([]model.Category, error) {
+	return slices.Clone(r.categories), nil
+}
+
+func (r *stubCategoryRepository) CreateCategory(category model.Category) (*model.Category, error) {
+	r.categories = append(r.categories, category)
+	return &category, nil
+}
+
+func (r *stubCategoryRepository) DeleteCategory(name string) error {
+	r.categories = slices.DeleteFunc(r.categories, func(category model.Category) bool { return category.Name == name })
+	return nil
+}
+
+// validQuestion returns a stored question that passes validation, titled title
+func validQuestion(title string) model.Question {
+	integer := model.AbstractType{Type: string(model.Integer)}
+	return model.Question{
+		ID:          primitive.NewObjectID(),
+		Title:       title,
+		Description: "Add two numbers",
+		Difficulty:  "Easy",
+		Category:    string(model.ArrayCategory),
+		Categories:  []string{string(model.ArrayCategory)},
+		Examples:    []model.InputOutput{{Parameters: []string{"1", "2"}, ExpectedOutput: "3"}},
+		TestCases:   []model.InputOutput{{Parameters: []string{"1", "2"}, ExpectedOutput: "3"}},
+		FunctionConfig: model.FunctionConfig{
+			Name:       "add",
+			Parameters: &[]model.Parameter{{Name: "a", ParamType: integer}, {Name: "b", ParamType: integer}},
+			ReturnType: &integer,
+		},
+		Languages: []string{string(model.Python)},
+	}
+}
+
+// statusOf returns the HTTP status of a *model.CustomError or *model.ValidationError, 0 for other errors
+func statusOf(err error) int {
+	var customErr *model.CustomError
+	var validationErr *model.ValidationError
+	switch {
+	case errors.As(err, &customErr):
+		return customErr.Code
+	case errors.As(err, &validationErr):
+		return 422
+	}
+	return 0
+}
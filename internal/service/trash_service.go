@@ -0,0 +1,42 @@
+package service
+
+import (
+	"slices"
+	"time"
+
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/model"
+)
+
+// ListTrash returns one page of the questions in the trash, most recently deleted first unless params sort otherwise
+func (s *QuestionService) ListTrash(params model.QuestionQueryParams) (*model.QuestionPage, error) {
+	params.Trashed = true
+	if params.SortBy == "" && params.Search == "" {
+		params.SortBy, params.SortOrder = "deleted_at", "desc"
+	}
+	if len(params.Fields) == 0 {
+		params.Fields = append(slices.Clone(model.QuestionSummaryFields), "deleted_at")
+	}
+	return s.GetAllQuestions(params)
+}
+
+// RestoreQuestion moves a question out of the trash by its ID
+func (s *QuestionService) RestoreQuestion(id string) (*model.Question, error) {
+	objID, err := handleInvalidID(id)
+	if err != nil {
+		return nil, err
+	}
+	return s.Repo.RestoreQuestion(objID)
+}
+
+// PurgeTrash permanently deletes the questions that have been in the trash for longer than retention,
+// with their revisions, and returns how many were deleted
+func (s *QuestionService) PurgeTrash(retention time.Duration) (int, error) {
+	purged, err := s.Repo.PurgeTrash(time.Now().UTC().Add(-retention))
+	if err != nil || len(purged) == 0 {
+		return 0, err
+	}
+	if err := s.RevisionRepo.DeleteQuestionRevisions(purged); err != nil {
+		return 0, err
+	}
+	return len(purged), nil
+}
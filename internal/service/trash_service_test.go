@@ -0,0 +1,63 @@
+package service_test
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/model"
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/service"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestListTrash(t *testing.T) {
+	questions := &stubQuestionRepository{}
+	questionService := service.NewQuestionService(questions, &stubRevisionRepository{}, newStubTaxonomy(), nil)
+
+	if _, err := questionService.ListTrash(model.QuestionQueryParams{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	listed := questions.listed
+	if !listed.Trashed || listed.SortBy != "deleted_at" || listed.SortOrder != "desc" || !slices.Contains(listed.Fields, "deleted_at") {
+		t.Errorf("expected the trash, most recently deleted first, got %+v", listed)
+	}
+
+	if _, err := questionService.ListTrash(model.QuestionQueryParams{Search: "sum"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if questions.listed.SortBy != "" {
+		t.Errorf("expected searches of the trash to sort by relevance, got %q", questions.listed.SortBy)
+	}
+}
+
+func TestRestoreQuestion(t *testing.T) {
+	stored := validQuestion("Add")
+	deletedAt := time.Now()
+	stored.DeletedAt = &deletedAt
+	questionService := service.NewQuestionService(&stubQuestionRepository{question: &stored}, &stubRevisionRepository{}, newStubTaxonomy(), nil)
+
+	if _, err := questionService.ListRevisions(stored.ID.Hex()); statusOf(err) != 404 {
+		t.Errorf("expected the revisions of a trashed question to be a 404, got %v", err)
+	}
+	restored, err := questionService.RestoreQuestion(stored.ID.Hex())
+	if err != nil || restored.DeletedAt != nil {
+		t.Fatalf("expected the question to be restored, got %+v %v", restored, err)
+	}
+	if _, err := questionService.RestoreQuestion(stored.ID.Hex()); statusOf(err) != 404 {
+		t.Errorf("expected restoring a live question to be a 404, got %v", err)
+	}
+}
+
+func TestPurgeTrash(t *testing.T) {
+	purged := []primitive.ObjectID{primitive.NewObjectID(), primitive.NewObjectID()}
+	revisions := &stubRevisionRepository{}
+	questionService := service.NewQuestionService(&stubQuestionRepository{purged: purged}, revisions, newStubTaxonomy(), nil)
+
+	count, err := questionService.PurgeTrash(30 * 24 * time.Hour)
+	if err != nil || count != 2 {
+		t.Fatalf("expected 2 questions to be purged, got %d %v", count, err)
+	}
+	if !slices.Equal(revisions.purged, purged) {
+		t.Errorf("expected the revisions of the purged questions to be deleted, got %v", revisions.purged)
+	}
+}
@@ -6,11 +6,13 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"text/template"
 
 	"github.com/TehilaTheStudent/SkillCode-backend/internal/coding"
 	"github.com/TehilaTheStudent/SkillCode-backend/internal/config"
 	"github.com/TehilaTheStudent/SkillCode-backend/internal/model"
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/parser_validator"
 	"github.com/TehilaTheStudent/SkillCode-backend/internal/utils"
 )
 
@@ -52,11 +54,24 @@ func (t *UniqueTester) ExecuteUniqueTestDevelopment(scriptContent string) (strin
 
 // CreateTestRunner generates a test runner script using templates for the specified language
 func CreateTestRunnerScript(language model.PredefinedSupportedLanguage,  question model.Question, userCode string) (string, error) {
+	if question.IsClassDesign() {
+		return createClassRunnerScript(language, question, userCode)
+	}
+	if question.IsInteractive() {
+		return createInteractiveRunnerScript(language, question, userCode)
+	}
+	if question.IsStdio() {
+		return createStdioRunnerScript(language, question, userCode)
+	}
 	// Map language to its template file path
 	templatePath := filepath.Join(config.GlobalLanguageConfigs[language].AssetsDir, "main.tmpl")
 
 	// Load test cases as JSON
-	testCasesJSON, err := json.Marshal(question.TestCases)
+	testCases, err := canonicalTestCases(question)
+	if err != nil {
+		return "", err
+	}
+	testCasesJSON, err := json.Marshal(testCases)
 	if err != nil {
 		return "", fmt.Errorf("failed to marshal test cases: %v", err)
 	}
@@ -74,19 +89,191 @@ func CreateTestRunnerScript(language model.PredefinedSupportedLanguage,  questio
 	}
 	configJSON, err := json.MarshalIndent(question.FunctionConfig, "", "  ")
 	if err != nil {
-		return "",fmt.Errorf("Error marshaling FunctionConfig:", err)
+		return "",fmt.Errorf("Error marshaling FunctionConfig: %v", err)
+	}
+	comparator := question.Comparator
+	if comparator == nil {
+		comparator = &model.Comparator{Type: model.ExactComparator}
+	}
+	comparatorJSON, err := json.Marshal(comparator)
+	if err != nil {
+		return "", fmt.Errorf("error marshaling Comparator: %v", err)
 	}
 	data := map[string]string{
 		"UserCode":     userCode,
 		"TestCases":    string(testCasesJSON),
 		"FunctionName": functionName,
 		"FunctionConfig":string(configJSON),
+		"Comparator":   string(comparatorJSON),
 	}
 
 	// Generate the test runner
 	return generateFromTemplate(templatePath, data)
 }
 
+// createClassRunnerScript generates a test runner for a class-design question, using the class template of language.
+// Operation sequences are passed as stored, with the name each method has in language.
+func createClassRunnerScript(language model.PredefinedSupportedLanguage, question model.Question, userCode string) (string, error) {
+	if question.ClassConfig == nil {
+		return "", fmt.Errorf("class question has no class configuration")
+	}
+	templatePath := filepath.Join(config.GlobalLanguageConfigs[language].AssetsDir, "class.tmpl")
+
+	testCasesJSON, err := json.Marshal(question.TestCases)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal test cases: %v", err)
+	}
+	configJSON, err := json.MarshalIndent(question.ClassConfig, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("error marshaling ClassConfig: %v", err)
+	}
+	methodNames := make(map[string]string, len(question.ClassConfig.Methods))
+	for _, method := range question.ClassConfig.Methods {
+		switch language {
+		case model.Python:
+			methodNames[method.Name] = coding.ToPythonStyle(method.Name)
+		case model.JavaScript:
+			methodNames[method.Name] = coding.ToJSStyle(method.Name)
+		case model.Java:
+			methodNames[method.Name] = coding.ToJavaStyle(method.Name)
+		default:
+			return "", fmt.Errorf("unsupported language: %v", language)
+		}
+	}
+	methodNamesJSON, err := json.Marshal(methodNames)
+	if err != nil {
+		return "", fmt.Errorf("error marshaling method names: %v", err)
+	}
+	data := map[string]string{
+		"UserCode":    userCode,
+		"TestCases":   string(testCasesJSON),
+		"ClassConfig": string(configJSON),
+		"MethodNames": string(methodNamesJSON),
+	}
+	return generateFromTemplate(templatePath, data)
+}
+
+// createInteractiveRunnerScript generates a runner that connects the user's program with the judge of language.
+// Both programs are embedded as JSON string literals, which Python and JavaScript read as is.
+func createInteractiveRunnerScript(language model.PredefinedSupportedLanguage, question model.Question, userCode string) (string, error) {
+	if question.InteractiveConfig == nil || question.InteractiveConfig.Judge[language] == "" {
+		return "", fmt.Errorf("interactive question has no judge for %s", language)
+	}
+	templatePath := filepath.Join(config.GlobalLanguageConfigs[language].AssetsDir, "interactive.tmpl")
+
+	testCasesJSON, err := json.Marshal(question.TestCases)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal test cases: %v", err)
+	}
+	userCodeJSON, err := json.Marshal(userCode)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal user code: %v", err)
+	}
+	judgeCodeJSON, err := json.Marshal(question.InteractiveConfig.Judge[language])
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal judge code: %v", err)
+	}
+	data := map[string]string{
+		"UserCode":   string(userCodeJSON),
+		"JudgeCode":  string(judgeCodeJSON),
+		"TestCases":  string(testCasesJSON),
+		"QueryLimit": strconv.Itoa(question.InteractiveConfig.QueryLimit),
+		"TimeLimit":  strconv.FormatFloat(question.InteractiveConfig.GetTimeLimit(), 'f', -1, 64),
+	}
+	return generateFromTemplate(templatePath, data)
+}
+
+// createStdioRunnerScript generates a runner that feeds the stdin of every test case to the user's program.
+// The runner receives the configuration with its defaults filled in.
+func createStdioRunnerScript(language model.PredefinedSupportedLanguage, question model.Question, userCode string) (string, error) {
+	templatePath := filepath.Join(config.GlobalLanguageConfigs[language].AssetsDir, "stdio.tmpl")
+
+	testCasesJSON, err := json.Marshal(question.TestCases)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal test cases: %v", err)
+	}
+	userCodeJSON, err := json.Marshal(userCode)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal user code: %v", err)
+	}
+	stdioConfig := model.StdioConfig{
+		Whitespace:          question.StdioConfig.GetWhitespace(),
+		KeepCarriageReturns: question.StdioConfig != nil && question.StdioConfig.KeepCarriageReturns,
+		TimeLimit:           question.StdioConfig.GetTimeLimit(),
+	}
+	configJSON, err := json.Marshal(stdioConfig)
+	if err != nil {
+		return "", fmt.Errorf("error marshaling StdioConfig: %v", err)
+	}
+	data := map[string]string{
+		"UserCode":    string(userCodeJSON),
+		"TestCases":   string(testCasesJSON),
+		"StdioConfig": string(configJSON),
+	}
+	return generateFromTemplate(templatePath, data)
+}
+
+// CreateCheckerScript generates a script that runs the question's checker over cases,
+// using the checker template of the checker's language
+func CreateCheckerScript(question model.Question, cases []model.CheckerCase) (string, error) {
+	if question.Checker == nil {
+		return "", fmt.Errorf("question has no checker")
+	}
+	templatePath := filepath.Join(config.GlobalLanguageConfigs[question.Checker.Language].AssetsDir, "checker.tmpl")
+
+	casesJSON, err := json.Marshal(cases)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal checker cases: %v", err)
+	}
+	// The code is embedded as a JSON string literal, which both Python and JavaScript read as is
+	codeJSON, err := json.Marshal(question.Checker.Code)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal checker code: %v", err)
+	}
+	configJSON, err := json.MarshalIndent(question.FunctionConfig, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("error marshaling FunctionConfig: %v", err)
+	}
+	data := map[string]string{
+		"CheckerCode":    string(codeJSON),
+		"Cases":          string(casesJSON),
+		"FunctionConfig": string(configJSON),
+	}
+	return generateFromTemplate(templatePath, data)
+}
+
+// canonicalTestCases parses every test case against the function config once and
+// rewrites it in canonical form, so the executors all receive the same JSON
+func canonicalTestCases(question model.Question) ([]model.InputOutput, error) {
+	parameters := []model.Parameter{}
+	if question.FunctionConfig.Parameters != nil {
+		parameters = *question.FunctionConfig.Parameters
+	}
+	testCases := make([]model.InputOutput, len(question.TestCases))
+	for i, testCase := range question.TestCases {
+		if len(testCase.Parameters) != len(parameters) {
+			return nil, fmt.Errorf("test case %d: parameters count mismatch", i)
+		}
+		testCases[i].Parameters = make([]string, len(testCase.Parameters))
+		for j, param := range parameters {
+			value, err := parser_validator.Parse(testCase.Parameters[j], &param.ParamType)
+			if err != nil {
+				return nil, fmt.Errorf("test case %d, parameter '%s': %v", i, param.Name, err)
+			}
+			testCases[i].Parameters[j] = value.Format()
+		}
+		testCases[i].ExpectedOutput = testCase.ExpectedOutput
+		if outputType := question.FunctionConfig.OutputType(); outputType != nil {
+			value, err := parser_validator.Parse(testCase.ExpectedOutput, outputType)
+			if err != nil {
+				return nil, fmt.Errorf("test case %d, expected output: %v", i, err)
+			}
+			testCases[i].ExpectedOutput = value.Format()
+		}
+	}
+	return testCases, nil
+}
+
 // generateFromTemplate processes the template with given data and returns the generated content as a string
 func generateFromTemplate(templatePath string, data map[string]string) (string, error) {
 	// Parse the template
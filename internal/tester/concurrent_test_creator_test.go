@@ -0,0 +1,328 @@
+package tester_test
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/config"
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/model"
+	"github.com/TehilaTheStudent/SkillCode-backend/internal/tester"
+)
+
+var interpreters = map[model.PredefinedSupportedLanguage]string{model.Python: "python3", model.JavaScript: "node"}
+
+// missingPackage matches the errors of runners whose evaluator packages (jsonschema, ajv) are not installed
+var missingPackage = regexp.MustCompile(`No module named|Cannot find module`)
+
+// useTemplateAssets points the language configs at the template assets of the repository
+func useTemplateAssets(t *testing.T) {
+	t.Helper()
+	config.GlobalLanguageConfigs = map[model.PredefinedSupportedLanguage]*config.LanguageConfig{}
+	for language := range interpreters {
+		config.GlobalLanguageConfigs[language] = &config.LanguageConfig{
+			AssetsDir: filepath.Join("..", "..", "template-assets", strings.ToLower(string(language))),
+		}
+	}
+}
+
+// runScript runs script the way development mode does, next to the assets of language
+func runScript(t *testing.T, language model.PredefinedSupportedLanguage, script string) string {
+	t.Helper()
+	if _, err := exec.LookPath(interpreters[language]); err != nil {
+		t.Skipf("%s is not installed", interpreters[language])
+	}
+	requestID := "test-" + strings.ToLower(strings.ReplaceAll(t.Name(), "/", "-"))
+	uniqueTester := tester.NewUniqueTester(nil, "", "", tester.GetRuntime(language), model.GetFileExtension(language), requestID, language)
+	t.Cleanup(func() {
+		os.Remove(filepath.Join(config.GlobalLanguageConfigs[language].AssetsDir, requestID+"."+model.GetFileExtension(language)))
+	})
+	output, err := uniqueTester.ExecuteUniqueTestDevelopment(script)
+	if err != nil && missingPackage.MatchString(err.Error()) {
+		t.Skipf("the evaluator packages are not installed: %v", err)
+	}
+	if err != nil {
+		t.Fatalf("failed to run the script: %v", err)
+	}
+	return output
+}
+
+func TestCheckerScript(t *testing.T) {
+	useTemplateAssets(t)
+	integer := model.AbstractType{Type: string(model.Integer)}
+	functionConfig := model.FunctionConfig{
+		Name:       "topologicalOrder",
+		Parameters: &[]model.Parameter{{Name: "n", ParamType: integer}, {Name: "edges", ParamType: model.AbstractType{Type: string(model.Matrix), TypeChildren: &integer}}},
+		ReturnType: &model.AbstractType{Type: string(model.Array), TypeChildren: &integer},
+	}
+	cases := []model.CheckerCase{
+		{Parameters: []string{"3", "[[0, 1], [0, 2]]"}, ExpectedOutput: "[0, 1, 2]", ActualOutput: "[0, 2, 1]"},
+		{Parameters: []string{"3", "[[0, 1], [0, 2]]"}, ExpectedOutput: "[0, 1, 2]", ActualOutput: "[1, 0, 2]"},
+	}
+	checkers := map[model.PredefinedSupportedLanguage]string{
+		model.Python: `
+def check(inputs, expected, actual):
+    n, edges = inputs
+    position = {node: i for i, node in enumerate(actual)}
+    for u, v in edges:
+        if position[u] > position[v]:
+            return False, f"{u} must come before {v}"
+    return True, "valid order"
+`,
+		model.JavaScript: `
+function check(inputs, expected, actual) {
+    const [n, edges] = inputs;
+    for (const [u, v] of edges) {
+        if (actual.indexOf(u) > actual.indexOf(v)) {
+            return [false, u + " must come before " + v];
+        }
+    }
+    return [true, "valid order"];
+}
+`,
+	}
+	tests := []struct {
+		name     string
+		code     string // Replaces the checker of the language when set
+		verdicts []model.CheckerVerdict
+		failed   bool // The checker itself fails
+	}{
+		{name: "verdicts", verdicts: []model.CheckerVerdict{{Status: "pass", Message: "valid order"}, {Status: "fail", Message: "0 must come before 1"}}},
+		{name: "broken checker", code: "check(", failed: true},
+	}
+	for language, checkerCode := range checkers {
+		for _, test := range tests {
+			t.Run(string(language)+"/"+test.name, func(t *testing.T) {
+				code := checkerCode
+				if test.code != "" {
+					code = test.code
+				}
+				question := model.Question{FunctionConfig: functionConfig, Checker: &model.Checker{Language: language, Code: code}}
+				script, err := tester.CreateCheckerScript(question, cases)
+				if err != nil {
+					t.Fatalf("failed to create the checker script: %v", err)
+				}
+
+				var feedback model.CheckerFeedback
+				output := runScript(t, language, script)
+				if err := json.Unmarshal([]byte(output), &feedback); err != nil {
+					t.Fatalf("failed to parse the checker output %q: %v", output, err)
+				}
+				if test.failed {
+					if feedback.Error == nil {
+						t.Errorf("expected the checker to fail, got %+v", feedback)
+					}
+					return
+				}
+				if feedback.Error != nil || len(feedback.Verdicts) != len(test.verdicts) {
+					t.Fatalf("expected %d verdicts, got %+v", len(test.verdicts), feedback)
+				}
+				for i, verdict := range feedback.Verdicts {
+					if verdict != test.verdicts[i] {
+						t.Errorf("case %d: expected %+v, got %+v", i, test.verdicts[i], verdict)
+					}
+				}
+			})
+		}
+	}
+
+	if _, err := tester.CreateCheckerScript(model.Question{FunctionConfig: functionConfig}, cases); err == nil {
+		t.Errorf("expected a question without a checker to be rejected")
+	}
+}
+
+func TestInteractiveRunnerScript(t *testing.T) {
+	useTemplateAssets(t)
+	judges := map[model.PredefinedSupportedLanguage]string{
+		model.Python: `
+def judge(secret, interactor):
+    while True:
+        guess = int(interactor.read())
+        if guess == secret:
+            interactor.write("correct")
+            return True, "found the number"
+        interactor.write("higher" if guess < secret else "lower")
+`,
+		model.JavaScript: `
+async function judge(secret, interactor) {
+    while (true) {
+        const guess = Number(await interactor.read());
+        if (guess === secret) {
+            interactor.write("correct");
+            return [true, "found the number"];
+        }
+        interactor.write(guess < secret ? "higher" : "lower");
+    }
+}
+`,
+	}
+	programs := map[model.PredefinedSupportedLanguage]map[string]string{
+		model.Python: {
+			"binary search": `
+low, high = 1, 100
+while True:
+    guess = (low + high) // 2
+    print(guess, flush=True)
+    answer = input()
+    if answer == "correct":
+        break
+    if answer == "higher":
+        low = guess + 1
+    else:
+        high = guess - 1
+`,
+			"linear search": `
+guess = 1
+while True:
+    print(guess, flush=True)
+    if input() == "correct":
+        break
+    guess += 1
+`,
+		},
+		model.JavaScript: {
+			"binary search": `
+const readline = require('readline');
+const lines = readline.createInterface({ input: process.stdin });
+let low = 1, high = 100, guess = 50;
+console.log(guess);
+lines.on('line', (answer) => {
+    if (answer === "correct") {
+        lines.close();
+        return;
+    }
+    if (answer === "higher") {
+        low = guess + 1;
+    } else {
+        high = guess - 1;
+    }
+    guess = Math.floor((low + high) / 2);
+    console.log(guess);
+});
+`,
+			"linear search": `
+const readline = require('readline');
+const lines = readline.createInterface({ input: process.stdin });
+let guess = 1;
+console.log(guess);
+lines.on('line', (answer) => {
+    if (answer === "correct") {
+        lines.close();
+        return;
+    }
+    guess++;
+    console.log(guess);
+});
+`,
+		},
+	}
+	tests := []struct {
+		program  string
+		statuses []string // Status of each test case
+		message  string   // Part of the message of the last test case
+	}{
+		{"binary search", []string{"pass", "pass", "pass"}, "found the number"},
+		{"linear search", []string{"pass", "pass", "fail"}, "query limit"},
+	}
+	for language, judge := range judges {
+		for _, test := range tests {
+			t.Run(string(language)+"/"+test.program, func(t *testing.T) {
+				question := model.Question{
+					Kind:              model.InteractiveQuestion,
+					InteractiveConfig: &model.InteractiveConfig{Judge: map[model.PredefinedSupportedLanguage]string{language: judge}, QueryLimit: 7},
+					TestCases:         []model.InputOutput{{Parameters: []string{"1"}}, {Parameters: []string{"7"}}, {Parameters: []string{"100"}}},
+				}
+				script, err := tester.CreateTestRunnerScript(language, question, programs[language][test.program])
+				if err != nil {
+					t.Fatalf("failed to create the runner script: %v", err)
+				}
+
+				var feedback model.Feedback
+				output := runScript(t, language, script)
+				if err := json.Unmarshal([]byte(output), &feedback); err != nil {
+					t.Fatalf("failed to parse the runner output %q: %v", output, err)
+				}
+				if len(feedback.Results) != len(test.statuses) {
+					t.Fatalf("expected %d results, got %+v", len(test.statuses), feedback)
+				}
+				for i, result := range feedback.Results {
+					if result.Status != test.statuses[i] {
+						t.Errorf("test case %d: expected %s, got %s", i, test.statuses[i], result.Status)
+					}
+				}
+				if last := feedback.Results[len(feedback.Results)-1]; last.Message == nil || !strings.Contains(*last.Message, test.message) {
+					t.Errorf("expected the last message to mention %q, got %v", test.message, last.Message)
+				}
+			})
+		}
+	}
+
+	if _, err := tester.CreateTestRunnerScript(model.Python, model.Question{Kind: model.InteractiveQuestion, InteractiveConfig: &model.InteractiveConfig{}}, "print(1)"); err == nil {
+		t.Errorf("expected a question without a judge to be rejected")
+	}
+}
+
+func TestStdioRunnerScript(t *testing.T) {
+	useTemplateAssets(t)
+	echoPrograms := map[model.PredefinedSupportedLanguage]string{
+		model.Python:     "import sys\nsys.stdout.write(sys.stdin.read())\n",
+		model.JavaScript: "process.stdout.write(require('fs').readFileSync(0, 'utf8'));\n",
+	}
+	// The programs echo their stdin, so each test case compares its stdin with its expected output
+	tests := []struct {
+		name     string
+		config   *model.StdioConfig
+		stdin    []string
+		expected []string
+		statuses []string
+	}{
+		{"trailing by default", nil,
+			[]string{"1 2  \r\n3\n\n", "1   2\n3", "1 2\n3"},
+			[]string{"1 2\n3", "1 2\n3", "1 2\n3\n\n\n"},
+			[]string{"pass", "fail", "pass"}},
+		{"exact", &model.StdioConfig{Whitespace: model.ExactWhitespace},
+			[]string{"1 2  \r\n3\n\n", "1 2\r\n3\n"},
+			[]string{"1 2\n3", "1 2\n3\n"},
+			[]string{"fail", "pass"}},
+		{"tokens", &model.StdioConfig{Whitespace: model.TokenWhitespace},
+			[]string{"1   2\n\n3\t", "1 2 3"},
+			[]string{"1 2 3", "1 23"},
+			[]string{"pass", "fail"}},
+		{"carriage returns kept", &model.StdioConfig{KeepCarriageReturns: true},
+			[]string{"1 2\r\n3", "1 2\r\n3"},
+			[]string{"1 2\n3", "1 2\r\n3"},
+			[]string{"fail", "pass"}},
+	}
+	for language, program := range echoPrograms {
+		for _, test := range tests {
+			t.Run(string(language)+"/"+test.name, func(t *testing.T) {
+				question := model.Question{Kind: model.StdioQuestion, StdioConfig: test.config}
+				for i, stdin := range test.stdin {
+					question.TestCases = append(question.TestCases, model.InputOutput{Parameters: []string{stdin}, ExpectedOutput: test.expected[i]})
+				}
+				script, err := tester.CreateTestRunnerScript(language, question, program)
+				if err != nil {
+					t.Fatalf("failed to create the runner script: %v", err)
+				}
+
+				var feedback model.Feedback
+				output := runScript(t, language, script)
+				if err := json.Unmarshal([]byte(output), &feedback); err != nil {
+					t.Fatalf("failed to parse the runner output %q: %v", output, err)
+				}
+				if len(feedback.Results) != len(test.statuses) {
+					t.Fatalf("expected %d results, got %+v", len(test.statuses), feedback)
+				}
+				for i, result := range feedback.Results {
+					if result.Status != test.statuses[i] {
+						t.Errorf("test case %d: %q against %q: expected %s, got %s", i, test.stdin[i], test.expected[i], test.statuses[i], result.Status)
+					}
+				}
+			})
+		}
+	}
+}
@@ -11,15 +11,47 @@ public class AbstractType {
    public String type;
     public AbstractType typeChildren;
 
+    // Graph only
+    public boolean directed = true; // Graphs are directed unless the type says otherwise
+    public AbstractType weightType; // null means unweighted
+    public String format; // "edge_list" (default) or "adjacency_list"
+
+    // The Java type the value is declared as in the user's signature, e.g. "int[]" or "long"; null for the boxed default
+    public String javaType;
+
     // Default constructor for Jackson
     public AbstractType() {}
 
+    public AbstractType(String type, AbstractType typeChildren) {
+        this(type, typeChildren, true, null, null, null);
+    }
+
+    public AbstractType(String type, AbstractType typeChildren, Boolean directed, AbstractType weightType, String format) {
+        this(type, typeChildren, directed, weightType, format, null);
+    }
+
     // Constructor with parameters
     @JsonCreator
     public AbstractType(@JsonProperty("type") String type,
-                        @JsonProperty("type_children") AbstractType typeChildren) {
+                        @JsonProperty("type_children") AbstractType typeChildren,
+                        @JsonProperty("directed") Boolean directed,
+                        @JsonProperty("weight_type") AbstractType weightType,
+                        @JsonProperty("format") String format,
+                        @JsonProperty("java_type") String javaType) {
         this.type = type;
         this.typeChildren = typeChildren;
+        this.directed = directed == null || directed;
+        this.weightType = weightType;
+        this.format = format;
+        this.javaType = javaType;
+    }
+
+    public boolean isWeighted() {
+        return weightType != null;
+    }
+
+    public boolean isAdjacencyList() {
+        return "adjacency_list".equals(format);
     }
 
     @Override
@@ -27,6 +59,10 @@ public class AbstractType {
         return "AbstractType{" +
                 "type='" + type + '\'' +
                 ", typeChildren=" + typeChildren +
+                ", directed=" + directed +
+                ", weightType=" + weightType +
+                ", format='" + format + '\'' +
+                ", javaType='" + javaType + '\'' +
                 '}';
     }
 
@@ -36,6 +72,9 @@ public class AbstractType {
      * @return the corresponding Java Class<?> for this type.
      */
     public Class<?> getJavaType() {
+        if (javaType != null) {
+            return TypeConverter.javaClass(javaType);
+        }
         switch (type) {
             case "Integer":
                 return Integer.class;
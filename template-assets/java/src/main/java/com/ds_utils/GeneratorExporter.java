@@ -4,7 +4,6 @@ import java.util.ArrayList;
 import java.util.Arrays;
 import java.util.LinkedList;
 import java.util.List;
-import java.util.Map;
 import java.util.Queue;
 
 import com.fasterxml.jackson.databind.ObjectMapper;
@@ -87,37 +86,93 @@ public class GeneratorExporter {
     /**
      * Generates a graph from a list of edges.
      * 
-     * @param edges the list of edges, where each edge is represented by a list of two integers
+     * @param edges    the list of edges, each [u, v] or [u, v, w] when weighted
+     * @param directed whether each edge goes only from u to v
+     * @param weighted whether each edge carries a weight as its third element
      * @return the generated graph
-     * @throws IllegalArgumentException if any edge does not have exactly two elements
+     * @throws IllegalArgumentException if any edge does not have the expected number of elements
      */
-    public static Graph generateGraph(List<List<Integer>> edges) {
-        Graph graph = new Graph();
-        for (List<Integer> edge : edges) {
-            if (edge.size() != 2) {
-                throw new IllegalArgumentException("Each edge must have exactly two elements");
+    public static Graph generateGraph(List<List<Number>> edges, boolean directed, boolean weighted) {
+        Graph graph = new Graph(directed, weighted);
+        int edgeLength = weighted ? 3 : 2;
+        for (List<Number> edge : edges) {
+            if (edge.size() != edgeLength) {
+                throw new IllegalArgumentException("Each edge must have exactly " + edgeLength + " elements");
             }
-            graph.addEdge(edge.get(0), edge.get(1));
+            graph.addEdge(edge.get(0).intValue(), edge.get(1).intValue(), weighted ? edge.get(2) : null);
         }
         return graph;
     }
 
     /**
-     * Exports a graph to a list of edges.
+     * Generates a graph from an adjacency list where row i holds the neighbors of node i.
+     * Undirected rows already list both directions, so each edge is recorded once.
      * 
-     * @param graph the graph to be exported
-     * @return the list of edges, where each edge is represented by a list of two integers
+     * @param adjacency the rows of neighbors, each neighbor a node or [node, weight] when weighted
+     * @param directed  whether the rows describe directed arcs
+     * @param weighted  whether each neighbor carries a weight
+     * @return the generated graph
      */
-    public static List<List<Integer>> exportGraph(Graph graph) {
-        List<List<Integer>> edges = new ArrayList<>();
-        for (Map.Entry<Integer, List<Integer>> entry : graph.adjList.entrySet()) {
-            for (Integer neighbor : entry.getValue()) {
-                edges.add(Arrays.asList(entry.getKey(), neighbor));
+    public static Graph generateGraphFromAdjacencyList(List<List<Object>> adjacency, boolean directed, boolean weighted) {
+        Graph graph = new Graph(directed, weighted);
+        for (int u = 0; u < adjacency.size(); u++) {
+            graph.addNode(u);
+            for (Object neighbor : adjacency.get(u)) {
+                Integer v;
+                Number w = null;
+                if (weighted) {
+                    List<?> pair = (List<?>) neighbor;
+                    v = ((Number) pair.get(0)).intValue();
+                    w = (Number) pair.get(1);
+                } else {
+                    v = ((Number) neighbor).intValue();
+                }
+                if (directed || u <= v) {
+                    List<Number> edge = new ArrayList<>(Arrays.asList(u, v));
+                    if (w != null) {
+                        edge.add(w);
+                    }
+                    graph.edges.add(edge);
+                }
+                graph.addArc(u, v, w);
             }
         }
+        return graph;
+    }
+
+    /**
+     * Exports a graph to its list of edges, in the order they were added.
+     * 
+     * @param graph the graph to be exported
+     * @return the list of edges, each [u, v] or [u, v, w] when weighted
+     */
+    public static List<List<Number>> exportGraph(Graph graph) {
+        List<List<Number>> edges = new ArrayList<>();
+        for (List<Number> edge : graph.edges) {
+            edges.add(new ArrayList<>(edge));
+        }
         return edges;
     }
 
+    /**
+     * Exports a graph with nodes 0..n-1 to an adjacency list.
+     * 
+     * @param graph the graph to be exported
+     * @return the rows of neighbors, with [node, weight] pairs when weighted
+     */
+    public static List<List<Object>> exportAdjacencyList(Graph graph) {
+        List<List<Object>> rows = new ArrayList<>();
+        int size = graph.adjList.isEmpty() ? 0 : java.util.Collections.max(graph.adjList.keySet()) + 1;
+        for (int u = 0; u < size; u++) {
+            List<Object> row = new ArrayList<>();
+            for (Integer v : graph.neighbors(u)) {
+                row.add(graph.weighted ? Arrays.asList(v, graph.getWeight(u, v)) : v);
+            }
+            rows.add(row);
+        }
+        return rows;
+    }
+
     /**
      * Generates a linked list from a list of integer values.
      * 
@@ -1,30 +1,84 @@
 package com.ds_utils;
 import java.util.ArrayList;
+import java.util.Arrays;
 import java.util.HashMap;
+import java.util.HashSet;
 import java.util.List;
 import java.util.Map;
 import java.util.Objects;
 public class Graph {
     public Map<Integer, List<Integer>> adjList = new HashMap<>();
+    public Map<Integer, Map<Integer, Number>> weights = new HashMap<>();
+    public List<List<Number>> edges = new ArrayList<>(); // Edges in input order, as given
+    public boolean directed;
+    public boolean weighted;
+
+    public Graph() {
+        this(true, false);
+    }
+
+    public Graph(boolean directed, boolean weighted) {
+        this.directed = directed;
+        this.weighted = weighted;
+    }
+
+    public void addNode(Integer u) {
+        adjList.computeIfAbsent(u, k -> new ArrayList<>());
+    }
 
     public void addEdge(Integer u, Integer v) {
-        adjList.computeIfAbsent(u, k -> new ArrayList<>()).add(v);
+        addEdge(u, v, null);
+    }
+
+    public void addEdge(Integer u, Integer v, Number w) {
+        List<Number> edge = new ArrayList<>(Arrays.asList(u, v));
+        if (w != null) {
+            edge.add(w);
+        }
+        edges.add(edge);
+        addArc(u, v, w);
+        if (!directed && !u.equals(v)) {
+            addArc(v, u, w);
+        }
+    }
+
+    public void addArc(Integer u, Integer v, Number w) {
+        addNode(u);
+        addNode(v);
+        adjList.get(u).add(v);
+        if (w != null) {
+            weights.computeIfAbsent(u, k -> new HashMap<>()).put(v, w);
+        }
+    }
+
+    public List<Integer> neighbors(Integer u) {
+        return adjList.getOrDefault(u, new ArrayList<>());
+    }
+
+    public Number getWeight(Integer u, Integer v) {
+        Map<Integer, Number> row = weights.get(u);
+        return row == null ? null : row.get(v);
     }
 
     @Override
     public boolean equals(Object obj) {
         if (!(obj instanceof Graph)) return false;
         Graph other = (Graph) obj;
-        return Objects.equals(adjList, other.adjList);
+        if (!adjList.keySet().equals(other.adjList.keySet())) return false;
+        for (Map.Entry<Integer, List<Integer>> entry : adjList.entrySet()) {
+            // Neighbor order does not matter
+            if (!new HashSet<>(entry.getValue()).equals(new HashSet<>(other.adjList.get(entry.getKey())))) return false;
+        }
+        return Objects.equals(weights, other.weights);
     }
 
     @Override
     public int hashCode() {
-        return Objects.hash(adjList);
+        return Objects.hash(adjList.keySet(), weights);
     }
 
     @Override
     public String toString() {
-        return "Graph{" + "adjList=" + adjList + '}';
+        return "Graph{" + "directed=" + directed + ", weighted=" + weighted + ", adjList=" + adjList + ", weights=" + weights + '}';
     }
 }
@@ -1,9 +1,9 @@
 package com.ds_utils;
 
+import java.lang.reflect.Array;
+import java.util.ArrayList;
 import java.util.Arrays;
-import java.util.HashMap; // For JSON parsing
 import java.util.List; // For List, Map, HashMap, Arrays, and other utility classes
-import java.util.Map; // For stream and collect operations
 import java.util.stream.Collectors;
 
 import com.fasterxml.jackson.databind.ObjectMapper;
@@ -20,7 +20,7 @@ public class TypeConverter {
      * Converts a JSON string representation of a listy structure into a Java object
      * based on the provided abstract type.
      *
-     * @param stringyListyRep the JSON string representation of the listy structure
+     * @param stringyListyRep the JSON string representation of the listy structure (strings are quoted JSON literals)
      * @param abstractType    the abstract type describing the structure
      * @return the Java object representation of the listy structure
      * @throws IllegalArgumentException if the JSON parsing fails
@@ -29,7 +29,8 @@ public class TypeConverter {
         try {
             // Parse the JSON string representation into a Java Object
             Object listyRep = objectMapper.readValue(stringyListyRep, Object.class);
-            return convertListyToType(listyRep, abstractType);
+            Object value = convertListyToType(listyRep, abstractType);
+            return abstractType.javaType == null ? value : toDeclaredType(value, abstractType.javaType);
         } catch (com.fasterxml.jackson.core.JsonProcessingException e) {
             throw new IllegalArgumentException("Failed to parse JSON input: " + stringyListyRep, e);
         }
@@ -54,22 +55,23 @@ public class TypeConverter {
 
         // Handle composite types
         if ("Array".equals(baseType)) {
-            // Convert listyRep to a List of Integers and recursively convert each item
-            List<Integer> list = objectMapper.convertValue(listyRep,
-                    objectMapper.getTypeFactory().constructCollectionType(List.class, Integer.class));
+            // Convert listyRep to a List and recursively convert each item
+            List<Object> list = objectMapper.convertValue(listyRep,
+                    objectMapper.getTypeFactory().constructCollectionType(List.class, Object.class));
             return list.stream()
                     .map(item -> convertListyToType(item, typeChildren))
                     .collect(Collectors.toList());
         }
 
         if ("Matrix".equals(baseType)) {
-            // Convert listyRep to a List of Lists of Integers and recursively convert each row
-            List<List<Integer>> matrix = objectMapper.convertValue(listyRep,
+            // Convert listyRep to a List of Lists and recursively convert each cell
+            List<List<Object>> matrix = objectMapper.convertValue(listyRep,
                     objectMapper.getTypeFactory().constructCollectionType(List.class,
-                            objectMapper.getTypeFactory().constructCollectionType(List.class, Integer.class)));
+                            objectMapper.getTypeFactory().constructCollectionType(List.class, Object.class)));
             return matrix.stream()
-                    .map(row -> objectMapper.convertValue(row,
-                            objectMapper.getTypeFactory().constructCollectionType(List.class, Integer.class)))
+                    .map(row -> row.stream()
+                            .map(item -> convertListyToType(item, typeChildren))
+                            .collect(Collectors.toList()))
                     .collect(Collectors.toList());
         }
 
@@ -133,22 +135,207 @@ public class TypeConverter {
             if (!"Integer".equals(typeChildren.type)) {
                 throw new IllegalArgumentException("Graph can only be of type Integer");
             }
-            List<List<Integer>> listyRepAsList = objectMapper.convertValue(listyRep,
+            if (abstractType.isAdjacencyList()) {
+                List<List<Object>> adjacency = objectMapper.convertValue(listyRep,
+                        objectMapper.getTypeFactory().constructCollectionType(List.class,
+                                objectMapper.getTypeFactory().constructCollectionType(List.class, Object.class)));
+                return GeneratorExporter.generateGraphFromAdjacencyList(adjacency, abstractType.directed,
+                        abstractType.isWeighted());
+            }
+            List<List<Number>> listyRepAsList = objectMapper.convertValue(listyRep,
                     objectMapper.getTypeFactory().constructCollectionType(List.class,
-                            objectMapper.getTypeFactory().constructCollectionType(List.class, Integer.class)));
-            Graph graph = GeneratorExporter.generateGraph(listyRepAsList);
-            Map<Integer, List<Integer>> newAdjList = new HashMap<>();
-            for (Map.Entry<Integer, List<Integer>> entry : graph.adjList.entrySet()) {
-                Integer convertedU = (Integer) convertListyToType(entry.getKey(), typeChildren);
-                List<Integer> convertedNeighbors = entry.getValue().stream()
-                        .map(neighbor -> (Integer) convertListyToType(neighbor, typeChildren))
-                        .collect(Collectors.toList());
-                newAdjList.put(convertedU, convertedNeighbors);
+                            objectMapper.getTypeFactory().constructCollectionType(List.class, Number.class)));
+            return GeneratorExporter.generateGraph(listyRepAsList, abstractType.directed, abstractType.isWeighted());
+        }
+
+        throw new IllegalArgumentException("Unsupported type: " + baseType);
+    }
+
+    /**
+     * Converts a Java object back into its listy representation, the inverse of listyToType.
+     * The result holds only lists, numbers, strings, booleans and nulls, ready to be written as JSON.
+     *
+     * @param value        the Java object, e.g. a function's return value
+     * @param abstractType the abstract type describing the structure
+     * @return the listy representation of the value
+     * @throws IllegalArgumentException if the value does not match the abstract type
+     */
+    public static Object typeToListy(Object value, AbstractType abstractType) {
+        if (value == null) {
+            return null;
+        }
+        String baseType = abstractType.type;
+
+        // Values declared as primitives or arrays go back to their boxed form first
+        if ("String".equals(baseType) && value instanceof char[]) {
+            return new String((char[]) value);
+        }
+        value = fromDeclaredType(value);
+
+        if (Arrays.asList("Integer", "Boolean", "String", "Double").contains(baseType)) {
+            return value;
+        }
+
+        if ("Array".equals(baseType) || "Matrix".equals(baseType)) {
+            if (!(value instanceof List)) {
+                throw new IllegalArgumentException("Expected a List for " + baseType + ", got: " + value.getClass());
             }
-            graph.adjList = newAdjList;
-            return graph;
+            return ((List<?>) value).stream()
+                    .map(item -> typeToListy(item, abstractType.typeChildren))
+                    .collect(Collectors.toList());
+        }
+
+        if ("TreeNode".equals(baseType)) {
+            return GeneratorExporter.exportTree((TreeNode) value);
+        }
+
+        if ("ListNode".equals(baseType)) {
+            return GeneratorExporter.exportLinkedList((ListNode) value);
+        }
+
+        if ("Graph".equals(baseType)) {
+            if (abstractType.isAdjacencyList()) {
+                return GeneratorExporter.exportAdjacencyList((Graph) value);
+            }
+            return GeneratorExporter.exportGraph((Graph) value);
         }
 
         throw new IllegalArgumentException("Unsupported type: " + baseType);
     }
+
+    /**
+     * Writes a Java object as JSON in its listy representation.
+     *
+     * @param value        the Java object
+     * @param abstractType the abstract type describing the structure
+     * @return the JSON representation (strings are quoted JSON literals)
+     */
+    public static String typeToJson(Object value, AbstractType abstractType) {
+        try {
+            return objectMapper.writeValueAsString(typeToListy(value, abstractType));
+        } catch (com.fasterxml.jackson.core.JsonProcessingException e) {
+            throw new IllegalArgumentException("Failed to write JSON for: " + value, e);
+        }
+    }
+
+    /**
+     * Returns the class of a Java type as written in a signature, e.g. int.class for "int"
+     * or int[][].class for "int[][]".
+     *
+     * @param javaType the Java type, a primitive, a boxed type, a List or an array of those
+     * @return the class of the type
+     * @throws IllegalArgumentException if the type is not supported
+     */
+    public static Class<?> javaClass(String javaType) {
+        if (javaType.endsWith("[]")) {
+            Class<?> componentType = javaClass(javaType.substring(0, javaType.length() - 2));
+            return Array.newInstance(componentType, 0).getClass();
+        }
+        if (javaType.startsWith("List")) {
+            return List.class;
+        }
+        switch (javaType) {
+            case "int":
+                return int.class;
+            case "long":
+                return long.class;
+            case "double":
+                return double.class;
+            case "boolean":
+                return boolean.class;
+            case "char":
+                return char.class;
+            case "Integer":
+                return Integer.class;
+            case "Long":
+                return Long.class;
+            case "Double":
+                return Double.class;
+            case "Boolean":
+                return Boolean.class;
+            case "String":
+                return String.class;
+            case "TreeNode":
+                return TreeNode.class;
+            case "ListNode":
+                return ListNode.class;
+            case "Graph":
+                return Graph.class;
+            default:
+                throw new IllegalArgumentException("Unsupported Java type: " + javaType);
+        }
+    }
+
+    /**
+     * Converts a value in its boxed form (Integer, List) to the Java type it is declared as,
+     * e.g. a List of Integers to an int[] or a one-character String to a char.
+     *
+     * @param value    the value as converted from its listy representation
+     * @param javaType the declared Java type
+     * @return the value as an instance of the declared type, boxed for primitives
+     * @throws IllegalArgumentException if the value cannot be declared as the type
+     */
+    public static Object toDeclaredType(Object value, String javaType) {
+        if (value == null) {
+            return null;
+        }
+        if ("char[]".equals(javaType) && value instanceof String) {
+            return ((String) value).toCharArray();
+        }
+        if (javaType.endsWith("[]")) {
+            String componentType = javaType.substring(0, javaType.length() - 2);
+            List<?> list = (List<?>) value;
+            Object array = Array.newInstance(javaClass(componentType), list.size());
+            for (int i = 0; i < list.size(); i++) {
+                Array.set(array, i, toDeclaredType(list.get(i), componentType));
+            }
+            return array;
+        }
+        if (javaType.startsWith("List<")) {
+            String elementType = javaType.substring("List<".length(), javaType.length() - 1);
+            return ((List<?>) value).stream()
+                    .map(item -> toDeclaredType(item, elementType))
+                    .collect(Collectors.toList());
+        }
+        switch (javaType) {
+            case "int":
+            case "Integer":
+                return ((Number) value).intValue();
+            case "long":
+            case "Long":
+                return ((Number) value).longValue();
+            case "double":
+            case "Double":
+                return ((Number) value).doubleValue();
+            case "char":
+                String text = (String) value;
+                if (text.length() != 1) {
+                    throw new IllegalArgumentException("Expected a single character, got: \"" + text + "\"");
+                }
+                return text.charAt(0);
+            default:
+                return value;
+        }
+    }
+
+    /**
+     * Converts arrays to Lists and chars to Strings, the inverse of toDeclaredType for
+     * everything but whole Strings declared as char[].
+     *
+     * @param value the value as declared in the user's signature
+     * @return the value in its boxed form
+     */
+    private static Object fromDeclaredType(Object value) {
+        if (value instanceof Character) {
+            return String.valueOf(value);
+        }
+        if (value == null || !value.getClass().isArray()) {
+            return value;
+        }
+        List<Object> list = new ArrayList<>();
+        for (int i = 0; i < Array.getLength(value); i++) {
+            list.add(fromDeclaredType(Array.get(value, i)));
+        }
+        return list;
+    }
 }
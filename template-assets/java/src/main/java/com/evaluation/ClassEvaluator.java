@@ -0,0 +1,99 @@
+package com.evaluation;
+
+import java.lang.reflect.Constructor;
+import java.lang.reflect.Method;
+import java.util.ArrayList;
+import java.util.HashMap;
+import java.util.List;
+import java.util.Map;
+import java.util.Objects;
+
+import com.ds_utils.AbstractType;
+import com.ds_utils.TypeConverter;
+import com.fasterxml.jackson.core.type.TypeReference;
+import com.fasterxml.jackson.databind.ObjectMapper;
+
+/**
+ * Class for evaluating a user-provided class against operation sequences, e.g.
+ * ["LRUCache","put","get"] with arguments [[2],[1,1],[1]] and outputs [null,null,1].
+ */
+public class ClassEvaluator {
+
+    private static final ObjectMapper objectMapper = new ObjectMapper();
+
+    /**
+     * Evaluates the user-provided class against the given operation sequences.
+     *
+     * @param userCode         the Java source code provided by the user
+     * @param className        the name of the class to be tested
+     * @param testCases        the operation sequences, with the operations and their arguments as parameters
+     * @param constructorTypes the types of the constructor parameters
+     * @param methods          the configuration of each method, by its name in the operations
+     * @return a string indicating whether the tests passed or failed
+     */
+    public static String evaluateUserClass(String userCode, String className, List<TestCase> testCases,
+            List<AbstractType> constructorTypes, Map<String, FunctionConfig> methods) {
+        try {
+            // Compile and load the user's class
+            Class<?> userClass = JavaCompilerUtil.compileAndLoad(className, userCode);
+            Constructor<?> constructor = userClass.getConstructor(javaTypes(constructorTypes));
+            Map<String, Method> userMethods = new HashMap<>();
+            for (Map.Entry<String, FunctionConfig> entry : methods.entrySet()) {
+                FunctionConfig method = entry.getValue();
+                userMethods.put(entry.getKey(), userClass.getMethod(method.functionName, javaTypes(method.parameters)));
+            }
+
+            for (TestCase testCase : testCases) {
+                List<String> operations = objectMapper.readValue(testCase.parameters.get(0), new TypeReference<List<String>>() {});
+                List<List<Object>> arguments = objectMapper.readValue(testCase.parameters.get(1), new TypeReference<List<List<Object>>>() {});
+                List<Object> expectedOutputs = objectMapper.readValue(testCase.expectedOutput, new TypeReference<List<Object>>() {});
+
+                Object instance = constructor.newInstance(convertArguments(arguments.get(0), constructorTypes).toArray());
+                for (int i = 1; i < operations.size(); i++) {
+                    FunctionConfig method = methods.get(operations.get(i));
+                    Object output = userMethods.get(operations.get(i))
+                            .invoke(instance, convertArguments(arguments.get(i), method.parameters).toArray());
+
+                    // Void methods output null, whatever they return
+                    if (method.returnType == null) {
+                        continue;
+                    }
+                    Object expected = TypeConverter.listyToType(objectMapper.writeValueAsString(expectedOutputs.get(i)), method.returnType);
+                    if (!Objects.deepEquals(expected, output)) {
+                        return "Test failed!";
+                    }
+                }
+            }
+
+            return "All tests passed!";
+        } catch (Exception e) {
+            return "Error during evaluation: " + e.getMessage();
+        }
+    }
+
+    private static Class<?>[] javaTypes(List<AbstractType> types) {
+        if (types == null) {
+            return new Class<?>[0];
+        }
+        return types.stream().map(AbstractType::getJavaType).toArray(Class<?>[]::new);
+    }
+
+    /**
+     * Converts the arguments of one operation to the appropriate types.
+     *
+     * @param arguments the arguments as parsed from JSON
+     * @param types     the types of the parameters
+     * @return the arguments converted to the appropriate types
+     * @throws Exception if an argument cannot be written back to JSON
+     */
+    private static List<Object> convertArguments(List<Object> arguments, List<AbstractType> types) throws Exception {
+        List<Object> inputs = new ArrayList<>();
+        if (types == null) {
+            return inputs;
+        }
+        for (int i = 0; i < types.size(); i++) {
+            inputs.add(TypeConverter.listyToType(objectMapper.writeValueAsString(arguments.get(i)), types.get(i)));
+        }
+        return inputs;
+    }
+}
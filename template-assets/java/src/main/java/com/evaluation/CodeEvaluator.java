@@ -3,15 +3,19 @@ package com.evaluation;
 import java.lang.reflect.Method;
 import java.util.ArrayList;
 import java.util.List;
+import java.util.Objects;
 
 import com.ds_utils.AbstractType;
 import com.ds_utils.TypeConverter;
+import com.fasterxml.jackson.databind.ObjectMapper;
 
 /**
  * Class for evaluating user-provided code against a set of test cases.
  */
 public class CodeEvaluator {
 
+    private static final ObjectMapper objectMapper = new ObjectMapper();
+
     /**
      * Evaluates the user-provided code against the given test cases.
      *
@@ -21,7 +25,25 @@ public class CodeEvaluator {
      * @return a string indicating whether the tests passed or failed
      */
     public static String evaluateUserCode(String userCode, List<TestCase> testCases, FunctionConfig functionConfig) {
+        return evaluateUserCode(userCode, testCases, functionConfig, null);
+    }
+
+    /**
+     * Evaluates the user-provided code against the given test cases, matching outputs with the comparator.
+     *
+     * @param userCode       the Java source code provided by the user
+     * @param testCases      the list of test cases to evaluate the code against
+     * @param functionConfig the configuration of the function to be tested
+     * @param comparator     how outputs are matched, exact when null
+     * @return a string indicating whether the tests passed or failed
+     */
+    public static String evaluateUserCode(String userCode, List<TestCase> testCases, FunctionConfig functionConfig,
+            OutputComparator comparator) {
         try {
+            if (comparator != null) {
+                comparator.prepare();
+            }
+
             // Compile and load the user's code
             Class<?> userClass = JavaCompilerUtil.compileAndLoad("UserSolution", userCode);
             for (Method method : userClass.getDeclaredMethods()) {
@@ -44,17 +66,24 @@ public class CodeEvaluator {
                 throw e;
             }
 
+            AbstractType outputType = functionConfig.getOutputType();
+
             // Iterate over each test case and evaluate the user's code
             for (TestCase testCase : testCases) {
                 // Convert the test case inputs to the appropriate types
                 List<Object> inputs = convertInputs(testCase.parameters, functionConfig.parameters);
                 // Convert the expected output to the appropriate type
-                Object expected = TypeConverter.listyToType(testCase.expectedOutput, functionConfig.returnType);
+                Object expected = TypeConverter.listyToType(testCase.expectedOutput, outputType);
                 // Invoke the user's method with the test case inputs
-                Object result = userMethod.invoke(userInstance, inputs.toArray());
+                Object[] arguments = inputs.toArray();
+                Object result = userMethod.invoke(userInstance, arguments);
+                if (functionConfig.returnType == null && functionConfig.mutatedParameterIndex != null) {
+                    // A void method's output is the state of the parameter it modified
+                    result = arguments[functionConfig.mutatedParameterIndex];
+                }
 
                 // Check if the result matches the expected output
-                if (!result.equals(expected)) {
+                if (!outputsMatch(expected, result, outputType, comparator)) {
                     return "Test failed!";
                 }
             }
@@ -65,6 +94,34 @@ public class CodeEvaluator {
         }
     }
 
+    /**
+     * Compares an output with the expected one, on the objects for exact matching
+     * and on their listy representations otherwise.
+     *
+     * @param expected   the expected output
+     * @param actual     the user's output
+     * @param returnType the abstract type of the outputs
+     * @param comparator how outputs are matched, exact when null
+     * @return true if the outputs match
+     * @throws Exception if the custom comparator fails
+     */
+    private static boolean outputsMatch(Object expected, Object actual, AbstractType returnType,
+            OutputComparator comparator) throws Exception {
+        if (OutputComparator.isExact(comparator)) {
+            // Outputs declared as arrays are compared element by element
+            return Objects.deepEquals(expected, actual);
+        }
+        Object actualListy;
+        try {
+            actualListy = objectMapper.readValue(TypeConverter.typeToJson(actual, returnType), Object.class);
+        } catch (RuntimeException e) {
+            // The user returned something that is not of the return type
+            return false;
+        }
+        Object expectedListy = objectMapper.readValue(TypeConverter.typeToJson(expected, returnType), Object.class);
+        return comparator.matches(expectedListy, actualListy);
+    }
+
     /**
      * Converts the test case input parameters to the appropriate types.
      *
@@ -14,7 +14,11 @@ public class FunctionConfig {
     public List<AbstractType> parameters;
 
     @JsonProperty("return_type")
-    public AbstractType returnType;
+    public AbstractType returnType; // Null for void functions
+
+    // Parameters are listed by type only, so the parameter a void function modifies in place is given by index
+    @JsonProperty("mutated_parameter_index")
+    public Integer mutatedParameterIndex;
 
     // Default constructor for Jackson
     public FunctionConfig() {}
@@ -29,12 +33,26 @@ public class FunctionConfig {
         this.returnType = returnType;
     }
 
+    /**
+     * Returns the type of the outputs: the return type, or for a void function the type of
+     * the mutated parameter, whose state after the call is compared.
+     *
+     * @return the abstract type of the outputs
+     */
+    public AbstractType getOutputType() {
+        if (returnType == null && mutatedParameterIndex != null) {
+            return parameters.get(mutatedParameterIndex);
+        }
+        return returnType;
+    }
+
     @Override
     public String toString() {
         return "FunctionConfig{" +
                 "functionName='" + functionName + '\'' +
                 ", parameters=" + parameters +
                 ", returnType=" + returnType +
+                ", mutatedParameterIndex=" + mutatedParameterIndex +
                 '}';
     }
 }
@@ -0,0 +1,167 @@
+package com.evaluation;
+
+import java.lang.reflect.Method;
+import java.util.ArrayList;
+import java.util.Collections;
+import java.util.List;
+import java.util.Map;
+import java.util.TreeSet;
+
+import com.fasterxml.jackson.annotation.JsonCreator;
+import com.fasterxml.jackson.annotation.JsonIgnoreProperties;
+import com.fasterxml.jackson.annotation.JsonProperty;
+import com.fasterxml.jackson.core.JsonProcessingException;
+import com.fasterxml.jackson.databind.ObjectMapper;
+
+/**
+ * Decides whether an output matches the expected one, following the question's comparator config.
+ * Outputs are compared in their listy representation (lists, numbers, strings, booleans and nulls).
+ */
+@JsonIgnoreProperties(ignoreUnknown = true)
+public class OutputComparator {
+    public static final double DEFAULT_TOLERANCE = 1e-6;
+
+    private static final ObjectMapper objectMapper = new ObjectMapper();
+
+    public String type;
+    public Double tolerance;
+    public Map<String, String> code; // Custom comparator code per language
+
+    private Method customCompare;
+
+    // Default constructor for Jackson
+    public OutputComparator() {}
+
+    @JsonCreator
+    public OutputComparator(
+            @JsonProperty("type") String type,
+            @JsonProperty("tolerance") Double tolerance,
+            @JsonProperty("code") Map<String, String> code) {
+        this.type = type;
+        this.tolerance = tolerance;
+        this.code = code;
+    }
+
+    /**
+     * Returns whether the comparator uses plain equals on the converted objects.
+     *
+     * @param comparator the comparator, null meaning exact
+     * @return true for exact matching
+     */
+    public static boolean isExact(OutputComparator comparator) {
+        return comparator == null || comparator.type == null || "exact".equals(comparator.type);
+    }
+
+    /**
+     * Compiles custom comparator code, which must declare a class CustomComparator with
+     * public static boolean compare(Object expected, Object actual).
+     *
+     * @throws Exception if the code is missing or does not compile
+     */
+    public void prepare() throws Exception {
+        if (!"custom".equals(type)) {
+            return;
+        }
+        String javaCode = code == null ? null : code.get("Java");
+        if (javaCode == null || javaCode.isEmpty()) {
+            throw new IllegalArgumentException("custom comparator has no Java code");
+        }
+        Class<?> comparatorClass = JavaCompilerUtil.compileAndLoad("CustomComparator", javaCode);
+        customCompare = comparatorClass.getMethod("compare", Object.class, Object.class);
+    }
+
+    /**
+     * Compares two listy values.
+     *
+     * @param expected the expected output in listy representation
+     * @param actual   the actual output in listy representation
+     * @return true if the outputs match
+     * @throws Exception if the custom comparator fails
+     */
+    public boolean matches(Object expected, Object actual) throws Exception {
+        String comparatorType = type == null ? "exact" : type;
+        switch (comparatorType) {
+            case "exact":
+                return canonical(expected).equals(canonical(actual));
+            case "unordered":
+                return unordered(expected, false).equals(unordered(actual, false));
+            case "unordered_nested":
+                return unordered(expected, true).equals(unordered(actual, true));
+            case "set_of_sets":
+                return setOfSets(expected).equals(setOfSets(actual));
+            case "float_tolerance":
+                return close(expected, actual, tolerance == null ? DEFAULT_TOLERANCE : tolerance);
+            case "custom":
+                if (customCompare == null) {
+                    prepare();
+                }
+                return Boolean.TRUE.equals(customCompare.invoke(null, expected, actual));
+            default:
+                throw new IllegalArgumentException("Unsupported comparator: " + comparatorType);
+        }
+    }
+
+    private static String canonical(Object value) {
+        try {
+            return objectMapper.writeValueAsString(value);
+        } catch (JsonProcessingException e) {
+            throw new IllegalArgumentException("Failed to write JSON for: " + value, e);
+        }
+    }
+
+    // Sort a list, and every inner list when nested, so that order no longer matters
+    private static String unordered(Object value, boolean nested) {
+        if (!(value instanceof List)) {
+            return canonical(value);
+        }
+        List<String> items = new ArrayList<>();
+        for (Object item : (List<?>) value) {
+            items.add(nested ? unordered(item, true) : canonical(item));
+        }
+        Collections.sort(items);
+        return "[" + String.join(",", items) + "]";
+    }
+
+    private static String setOfSets(Object value) {
+        if (!(value instanceof List)) {
+            return canonical(value);
+        }
+        TreeSet<String> inner = new TreeSet<>();
+        for (Object item : (List<?>) value) {
+            if (item instanceof List) {
+                TreeSet<String> elements = new TreeSet<>();
+                for (Object element : (List<?>) item) {
+                    elements.add(canonical(element));
+                }
+                inner.add("[" + String.join(",", elements) + "]");
+            } else {
+                inner.add(canonical(item));
+            }
+        }
+        return "[" + String.join(",", inner) + "]";
+    }
+
+    // Compare recursively, numbers within an absolute or relative tolerance
+    private static boolean close(Object expected, Object actual, double tolerance) {
+        if (expected instanceof Number && actual instanceof Number) {
+            double e = ((Number) expected).doubleValue();
+            double a = ((Number) actual).doubleValue();
+            double difference = Math.abs(e - a);
+            return difference <= tolerance || difference <= tolerance * Math.max(Math.abs(e), Math.abs(a));
+        }
+        if (expected instanceof List && actual instanceof List) {
+            List<?> expectedList = (List<?>) expected;
+            List<?> actualList = (List<?>) actual;
+            if (expectedList.size() != actualList.size()) {
+                return false;
+            }
+            for (int i = 0; i < expectedList.size(); i++) {
+                if (!close(expectedList.get(i), actualList.get(i), tolerance)) {
+                    return false;
+                }
+            }
+            return true;
+        }
+        return canonical(expected).equals(canonical(actual));
+    }
+}
@@ -12,6 +12,7 @@ import com.ds_utils.ListNode;
 import com.ds_utils.TreeNode;
 import com.ds_utils.TypeConverter;
 import com.evaluation.FunctionConfig;
+import com.evaluation.OutputComparator;
 import com.evaluation.TestCase;
 import com.fasterxml.jackson.databind.ObjectMapper;
 import com.fasterxml.jackson.databind.PropertyNamingStrategies;
@@ -128,4 +129,64 @@ public class Tests {
         expected.adjList.put(2, Arrays.asList(0));
         assertEquals(converted, expected, "The converted object does not match the expected Graph<Integer>");
     }
+
+    @Test
+    public void testListyToType_StringsWithSpecialCharacters() {
+        AbstractType stringType = new AbstractType("String", null);
+        AbstractType arrayType = new AbstractType("Array", stringType);
+        assertEquals(TypeConverter.listyToType("\"a, b\"", stringType), "a, b");
+        assertEquals(TypeConverter.listyToType("\"say \\\"hi\\\"\"", stringType), "say \"hi\"");
+        assertEquals(TypeConverter.listyToType("\"line\\nbreak\"", stringType), "line\nbreak");
+        assertEquals(TypeConverter.listyToType("\"caf\\u00e9\"", stringType), "caf\u00e9");
+        assertEquals(TypeConverter.listyToType("[\"[x]\", \"a,b\", \"\"]", arrayType), Arrays.asList("[x]", "a,b", ""));
+    }
+
+    @Test
+    public void testTypeToListy_RoundTrip() {
+        AbstractType integerType = new AbstractType("Integer", null);
+        AbstractType treeType = new AbstractType("TreeNode", integerType);
+        AbstractType matrixType = new AbstractType("Matrix", integerType);
+        assertEquals(TypeConverter.typeToJson(TypeConverter.listyToType("[1,null,2,3]", treeType), treeType), "[1,null,2,3]");
+        assertEquals(TypeConverter.typeToJson(TypeConverter.listyToType("[[1,2],[3,4]]", matrixType), matrixType), "[[1,2],[3,4]]");
+    }
+
+    @Test
+    public void testListyToType_DeclaredJavaTypes() {
+        AbstractType integerType = new AbstractType("Integer", null);
+        AbstractType stringType = new AbstractType("String", null);
+        AbstractType intArray = new AbstractType("Array", integerType, false, null, null, "int[]");
+        AbstractType longMatrix = new AbstractType("Matrix", integerType, false, null, null, "long[][]");
+        AbstractType charBoard = new AbstractType("Matrix", stringType, false, null, null, "char[][]");
+        AbstractType charArray = new AbstractType("String", null, false, null, null, "char[]");
+
+        assertEquals((int[]) TypeConverter.listyToType("[1,2,3]", intArray), new int[] {1, 2, 3});
+        assertEquals((long[][]) TypeConverter.listyToType("[[1],[2]]", longMatrix), new long[][] {{1L}, {2L}});
+        assertEquals((char[][]) TypeConverter.listyToType("[[\"X\",\"O\"]]", charBoard), new char[][] {{'X', 'O'}});
+        assertEquals((char[]) TypeConverter.listyToType("\"abc\"", charArray), new char[] {'a', 'b', 'c'});
+        assertEquals(intArray.getJavaType(), int[].class);
+        assertEquals(charBoard.getJavaType(), char[][].class);
+    }
+
+    @Test
+    public void testTypeToListy_DeclaredJavaTypes() {
+        AbstractType integerType = new AbstractType("Integer", null);
+        AbstractType stringType = new AbstractType("String", null);
+        assertEquals(TypeConverter.typeToJson(new int[] {3, 1}, new AbstractType("Array", integerType)), "[3,1]");
+        assertEquals(TypeConverter.typeToJson(new char[][] {{'a'}}, new AbstractType("Matrix", stringType)), "[[\"a\"]]");
+        assertEquals(TypeConverter.typeToJson(new char[] {'h', 'i'}, stringType), "\"hi\"");
+    }
+
+    @Test
+    public void testOutputComparator() throws Exception {
+        List<Integer> ascending = Arrays.asList(0, 1);
+        List<Integer> descending = Arrays.asList(1, 0);
+        assertEquals(new OutputComparator("exact", null, null).matches(ascending, descending), false);
+        assertEquals(new OutputComparator("unordered", null, null).matches(ascending, descending), true);
+        assertEquals(new OutputComparator("unordered_nested", null, null).matches(
+                Arrays.asList(Arrays.asList(1, 2), Arrays.asList(3)), Arrays.asList(Arrays.asList(3), Arrays.asList(2, 1))), true);
+        assertEquals(new OutputComparator("set_of_sets", null, null).matches(
+                Arrays.asList(Arrays.asList(1, 2), Arrays.asList(2, 1)), Arrays.asList(Arrays.asList(2, 1))), true);
+        assertEquals(new OutputComparator("float_tolerance", 1e-3, null).matches(Arrays.asList(1.0, 2.0), Arrays.asList(1.0004, 1.9996)), true);
+        assertEquals(new OutputComparator("float_tolerance", 1e-3, null).matches(Arrays.asList(1.0, 2.0), Arrays.asList(1.01, 2.0)), false);
+    }
 }
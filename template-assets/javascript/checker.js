@@ -0,0 +1,49 @@
+const converter = require('./converter');
+
+function runChecker(checkerCode, cases, functionConfig) {
+    /**
+     * Run a question's checker over the outputs of the user's code.
+     *
+     * Args:
+     *     checkerCode (str): The checker code, defining check(inputs, expected, actual)
+     *         that returns a boolean or a [boolean, message] pair.
+     *     cases (list): A list of cases, each containing parameters, expected_output and actual_output.
+     *     functionConfig (dict): The function configuration, to convert the values to their types.
+     *
+     * Returns:
+     *     dict: The verdict of each case, and the error if the checker itself failed.
+     */
+    let check;
+    try {
+        const wrappedCode = `
+        const utils = require('./ds_utils.js');
+        ${checkerCode}
+        return check;
+        `;
+        check = new Function("require", wrappedCode)(require);
+    } catch (e) {
+        return { verdicts: [], error: `checker compilation: ${e.message}` };
+    }
+    if (typeof check !== "function") {
+        return { verdicts: [], error: "check is not defined or not a function" };
+    }
+
+    const returnType = converter.outputType(functionConfig);
+    const verdicts = cases.map((checkerCase) => {
+        try {
+            const inputs = checkerCase.parameters.map((param, index) => converter.listyToType(param, functionConfig.parameters[index].param_type));
+            const expectedOutput = converter.listyToType(checkerCase.expected_output, returnType);
+            const actualOutput = converter.listyToType(checkerCase.actual_output, returnType);
+
+            const verdict = check(inputs, expectedOutput, actualOutput);
+            const [passed, message] = Array.isArray(verdict) ? verdict : [verdict, ""];
+            return { status: passed ? "pass" : "fail", message: String(message || "") };
+        } catch (e) {
+            return { status: "fail", message: `Checker error: ${e.message}` };
+        }
+    });
+
+    return { verdicts, error: null };
+}
+
+module.exports = { runChecker };
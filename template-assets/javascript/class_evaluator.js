@@ -0,0 +1,148 @@
+const converter = require('./converter');
+const { loadSchema, initializeAjv, validateResponse } = require('./evaluator');
+
+function runOperationSequences(UserClass, testCases, validate, classConfig, methodNames) {
+  const results = [];
+  let allPassed = true;
+  let runtime = 0; // Milliseconds spent in the user's class, without the conversions and comparisons
+  const methods = new Map(classConfig.methods.map(method => [method.name, method]));
+  // Every object inherits a constructor property, only an own one holds the constructor parameters
+  const constructorParameters = Object.prototype.hasOwnProperty.call(classConfig, "constructor") ? classConfig.constructor || [] : [];
+
+  for (const testCase of testCases) {
+    const operations = JSON.parse(testCase.parameters[0]);
+    const args = JSON.parse(testCase.parameters[1]);
+    const expectedOutputs = JSON.parse(testCase.expected_output);
+    const actualOutputs = [];
+    let passed;
+    let actualOutput;
+    try {
+      let instance = null;
+      operations.forEach((operation, i) => {
+        const method = i === 0 ? null : methods.get(operation);
+        const parameters = i === 0 ? constructorParameters : method.parameters || [];
+        const returnType = i === 0 ? null : method.return_type;
+        const inputs = parameters.map((param, j) => converter.listyToType(JSON.stringify(args[i][j]), param.param_type));
+
+        const started = performance.now();
+        let output;
+        try {
+          if (i === 0) {
+            instance = new UserClass(...inputs);
+          } else {
+            output = instance[methodNames[operation]](...inputs);
+          }
+        } finally {
+          runtime += performance.now() - started;
+        }
+        if (i === 0) {
+          actualOutputs.push(null);
+          return;
+        }
+
+        // Void methods output null, whatever they return
+        if (!returnType) {
+          actualOutputs.push(null);
+          return;
+        }
+        try {
+          actualOutputs.push(JSON.parse(converter.typeToListy(output, returnType)));
+        } catch (e) {
+          // The user returned something that is not of the return type
+          actualOutputs.push(String(output));
+        }
+        expectedOutputs[i] = JSON.parse(converter.typeToListy(converter.listyToType(JSON.stringify(expectedOutputs[i]), returnType), returnType));
+      });
+      actualOutput = JSON.stringify(actualOutputs);
+      passed = actualOutput === JSON.stringify(expectedOutputs);
+    } catch (e) {
+      passed = false;
+      actualOutput = `Error at operation ${actualOutputs.length}: ${e.message}`;
+    }
+
+    allPassed = allPassed && passed;
+    results.push({
+      status: passed ? "pass" : "fail",
+      parameters: testCase.parameters,
+      expected_output: JSON.stringify(expectedOutputs),
+      actual_output: actualOutput,
+    });
+  }
+
+  const response = {
+    status: allPassed ? "success" : "fail",
+    results,
+    error: allPassed ? null : "fail tests",
+    details: allPassed ? null : "Some test cases failed.",
+    runtime_ms: Math.round(runtime),
+  };
+
+  // Validate the response against the schema
+  const validationError = validateResponse(response, validate);
+  if (validationError) {
+    return validationError;
+  }
+
+  return response;
+}
+
+function evaluateUserClass(userCode, testCases, classConfig, methodNames) {
+  let UserClass;
+  let validate;
+  try {
+    const schema = loadSchema();
+    validate = initializeAjv(schema);
+  } catch (e) {
+    return {
+      status: "fail",
+      results: [],
+      error: "internal server error",
+      details: e.message,
+    };
+  }
+
+  try {
+    // The user code runs in its own block, so starters may require ds_utils themselves
+    const wrappedCode = `
+    const utils = require('./ds_utils.js');
+    {
+    ${userCode}
+    return ${classConfig.name};
+    }
+    `;
+
+    UserClass = new Function("require", wrappedCode)(require);
+  } catch (e) {
+    const response = {
+      status: "fail",
+      results: [],
+      error: "compilation",
+      details: e.message,
+    };
+
+    const validationError = validateResponse(response, validate);
+    if (validationError) {
+      return validationError;
+    }
+    return response;
+  }
+
+  if (typeof UserClass !== "function") {
+    const response = {
+      status: "fail",
+      results: [],
+      error: "compilation",
+      details: `${classConfig.name} is not defined or not a class`,
+    };
+
+    const validationError = validateResponse(response, validate);
+    if (validationError) {
+      return validationError;
+    }
+    return response;
+  }
+
+  return runOperationSequences(UserClass, testCases, validate, classConfig, methodNames);
+}
+
+module.exports = { evaluateUserClass };
@@ -0,0 +1,89 @@
+const DEFAULT_TOLERANCE = 1e-6;
+
+function makeComparator(comparator) {
+    /**
+     * Build the function that decides whether an output matches the expected one.
+     *
+     * Args:
+     *     comparator (dict): The question's comparator config with "type" and optional "tolerance" and "code".
+     *
+     * Returns:
+     *     function(expected, actual): boolean over JSON representations (plain arrays, numbers,
+     *     strings, booleans and null), or null for exact matching.
+     */
+    comparator = comparator || {};
+    const comparatorType = comparator.type || "exact";
+
+    if (comparatorType === "exact") {
+        return null;
+    }
+
+    if (comparatorType === "unordered") {
+        return (expected, actual) => unordered(expected, false) === unordered(actual, false);
+    }
+
+    if (comparatorType === "unordered_nested") {
+        return (expected, actual) => unordered(expected, true) === unordered(actual, true);
+    }
+
+    if (comparatorType === "set_of_sets") {
+        return (expected, actual) => setOfSets(expected) === setOfSets(actual);
+    }
+
+    if (comparatorType === "float_tolerance") {
+        const tolerance = comparator.tolerance || DEFAULT_TOLERANCE;
+        return (expected, actual) => close(expected, actual, tolerance);
+    }
+
+    if (comparatorType === "custom") {
+        const code = (comparator.code || {}).JavaScript;
+        if (!code) {
+            throw new Error("custom comparator has no JavaScript code");
+        }
+        const compare = new Function(`${code}\nreturn compare;`)();
+        if (typeof compare !== "function") {
+            throw new Error("custom comparator must define compare(expected, actual)");
+        }
+        return (expected, actual) => Boolean(compare(expected, actual));
+    }
+
+    throw new Error(`Unsupported comparator: ${comparatorType}`);
+}
+
+// Sort an array, and every inner array when nested, so that order no longer matters
+function unordered(value, nested) {
+    if (!Array.isArray(value)) {
+        return JSON.stringify(value);
+    }
+    const items = value.map(item => (nested ? unordered(item, true) : JSON.stringify(item)));
+    return `[${items.sort().join(",")}]`;
+}
+
+function setOfSets(value) {
+    if (!Array.isArray(value)) {
+        return JSON.stringify(value);
+    }
+    const inner = new Set(value.map(item => {
+        if (!Array.isArray(item)) {
+            return JSON.stringify(item);
+        }
+        return `[${[...new Set(item.map(element => JSON.stringify(element)))].sort().join(",")}]`;
+    }));
+    return `[${[...inner].sort().join(",")}]`;
+}
+
+// Compare recursively, numbers within an absolute or relative tolerance
+function close(expected, actual, tolerance) {
+    if (typeof expected === "number" && typeof actual === "number") {
+        const difference = Math.abs(expected - actual);
+        return difference <= tolerance || difference <= tolerance * Math.max(Math.abs(expected), Math.abs(actual));
+    }
+    if (Array.isArray(expected) && Array.isArray(actual)) {
+        return expected.length === actual.length && expected.every((item, i) => close(item, actual[i], tolerance));
+    }
+    return JSON.stringify(expected) === JSON.stringify(actual);
+}
+
+module.exports = {
+    makeComparator,
+};
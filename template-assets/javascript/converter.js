@@ -21,7 +21,7 @@ function listyToType(stringyListyRep, abstractType) {
     }
 
     const baseType = abstractType.type;
-    const typeChildren = abstractType.typeChildren;
+    const typeChildren = abstractType.type_children || abstractType.typeChildren;
 
     // Handle atomic types
     if (["Integer", "Double", "String", "Boolean"].includes(baseType)) {
@@ -71,14 +71,20 @@ function listyToType(stringyListyRep, abstractType) {
     }
 
     if (baseType === "Graph") {
+        const directed = abstractType.directed !== false; // Graphs are directed unless the type says otherwise
+        const weighted = Boolean(abstractType.weight_type);
+        if (abstractType.format === "adjacency_list") {
+            return dsUtils.generateGraphFromAdjacencyList(listyRep, directed, weighted);
+        }
+
         // Use the utility function to generate a Graph
-        let graph = dsUtils.generateGraph(listyRep);
+        let graph = dsUtils.generateGraph(listyRep, directed, weighted);
         if (typeChildren) {
             // Convert each node and its neighbors using the child type
-            let newAdjList = {};
-            graph.forEach((node, u) => {
+            let newAdjList = new Map();
+            graph.adjList.forEach((neighbors, u) => {
                 let convertedU = listyToType(JSON.stringify(u), typeChildren);
-                newAdjList[convertedU] = node.neighbors.map(neighbor => listyToType(JSON.stringify(neighbor.val), typeChildren));
+                newAdjList.set(convertedU, neighbors.map(neighbor => listyToType(JSON.stringify(neighbor), typeChildren)));
             });
             graph.adjList = newAdjList;
         }
@@ -88,6 +94,83 @@ function listyToType(stringyListyRep, abstractType) {
     throw new Error(`Unsupported type: ${baseType}`);
 }
 
+function typeToListy(value, abstractType) {
+    /**
+     * Convert a data structure back into its JSON representation, the inverse of listyToType.
+     *
+     * Args:
+     *     value (any): The data structure, e.g. a function's return value.
+     *     abstractType (dict): The abstract type definition with "type" and optional "type_children".
+     *
+     * Returns:
+     *     str: The JSON representation (strings are quoted JSON literals).
+     */
+    const listy = toListy(value, abstractType);
+    return listy === undefined ? "null" : JSON.stringify(listy);
+}
+
+function toListy(value, abstractType) {
+    if (value === null || value === undefined) {
+        return null;
+    }
+
+    const baseType = abstractType.type;
+    const typeChildren = abstractType.type_children || abstractType.typeChildren;
+
+    if (["Integer", "Double", "String", "Boolean"].includes(baseType)) {
+        return value;
+    }
+
+    if (baseType === "Array" || baseType === "Matrix") {
+        return value.map(item => toListy(item, typeChildren));
+    }
+
+    if (baseType === "TreeNode") {
+        return dsUtils.exportTree(value);
+    }
+
+    if (baseType === "ListNode") {
+        return dsUtils.exportLinkedList(value);
+    }
+
+    if (baseType === "Graph") {
+        if (abstractType.format === "adjacency_list") {
+            return dsUtils.exportAdjacencyList(value);
+        }
+        return dsUtils.exportGraph(value);
+    }
+
+    throw new Error(`Unsupported type: ${baseType}`);
+}
+
+function mutatedParameterIndex(functionConfig) {
+    /**
+     * Return the position of the parameter a void function modifies in place, or -1.
+     *
+     * Args:
+     *     functionConfig (dict): The function configuration with "parameters" and optional "mutated_parameter".
+     */
+    if (functionConfig.return_type) {
+        return -1;
+    }
+    return (functionConfig.parameters || []).findIndex(param => param.name === functionConfig.mutated_parameter);
+}
+
+function outputType(functionConfig) {
+    /**
+     * Return the abstract type of the outputs: the return type, or for a void function
+     * the type of the mutated parameter, whose state after the call is compared.
+     *
+     * Args:
+     *     functionConfig (dict): The function configuration.
+     */
+    const index = mutatedParameterIndex(functionConfig);
+    return index === -1 ? functionConfig.return_type : functionConfig.parameters[index].param_type;
+}
+
 module.exports = {
     listyToType,
+    typeToListy,
+    mutatedParameterIndex,
+    outputType,
 };
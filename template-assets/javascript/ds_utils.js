@@ -1,16 +1,47 @@
 class Graph {
     /**
-     * A node in a graph.
-     * @param {any} val - The value of the node.
-     * @param {Graph[]} neighbors - List of neighbor nodes.
+     * A graph stored as an adjacency list.
+     * @param {boolean} directed - Whether each edge goes only from u to v.
+     * @param {boolean} weighted - Whether each edge carries a weight.
      */
-    constructor(val = null, neighbors = []) {
-        this.val = val;
-        this.neighbors = neighbors;
+    constructor(directed = true, weighted = false) {
+        this.directed = directed;
+        this.weighted = weighted;
+        this.adjList = new Map(); // node -> array of neighbor nodes
+        this.weights = new Map(); // "u,v" -> weight
+        this.edges = []; // Edges in input order, as given
+    }
+
+    addNode(u) {
+        if (!this.adjList.has(u)) this.adjList.set(u, []);
+    }
+
+    addEdge(u, v, w = null) {
+        this.edges.push(w === null ? [u, v] : [u, v, w]);
+        this.addArc(u, v, w);
+        if (!this.directed && u !== v) {
+            this.addArc(v, u, w);
+        }
+    }
+
+    addArc(u, v, w = null) {
+        this.addNode(u);
+        this.addNode(v);
+        this.adjList.get(u).push(v);
+        if (w !== null) this.weights.set(`${u},${v}`, w);
+    }
+
+    neighbors(u) {
+        return this.adjList.get(u) || [];
+    }
+
+    weight(u, v) {
+        const key = `${u},${v}`;
+        return this.weights.has(key) ? this.weights.get(key) : null;
     }
 
     toString() {
-        return `Graph(val=${this.val})`;
+        return `Graph(directed=${this.directed}, weighted=${this.weighted}, nodes=${this.adjList.size})`;
     }
 
     hashCode() {
@@ -23,8 +54,9 @@ class Graph {
             }
             return hash;
         };
-        const nodeHashes = Array.from(this.neighbors, (neighbor) => neighbor.hashCode()).sort();
-        return hash(`${this.val}:${nodeHashes.join(',')}`);
+        const nodeHashes = Array.from(this.adjList, ([u, neighbors]) =>
+            `${u}:${neighbors.map((v) => `${v}/${this.weight(u, v)}`).sort().join(',')}`).sort();
+        return hash(nodeHashes.join(';'));
     }
 }
 
@@ -177,40 +209,56 @@ function exportLinkedList(head) {
     return result;
 }
 
-// Generate a graph from a list of edges
-function generateGraph(edges, directed = false) {
-    const nodes = new Map();
-
-    edges.forEach(([u, v]) => {
-        if (!nodes.has(u)) nodes.set(u, new Graph(u));
-        if (!nodes.has(v)) nodes.set(v, new Graph(v));
+// Generate a graph from a list of edges ([u, v] or [u, v, w] when weighted)
+function generateGraph(edges, directed = true, weighted = false) {
+    const graph = new Graph(directed, weighted);
+    const edgeLength = weighted ? 3 : 2;
 
-        nodes.get(u).neighbors.push(nodes.get(v));
-        if (!directed) {
-            nodes.get(v).neighbors.push(nodes.get(u));
+    edges.forEach((edge) => {
+        if (edge.length !== edgeLength) {
+            throw new Error(`Invalid edge format: ${JSON.stringify(edge)}. Each edge must have exactly ${edgeLength} elements.`);
         }
+        graph.addEdge(edge[0], edge[1], weighted ? edge[2] : null);
     });
 
-    return nodes;
+    return graph;
 }
 
-// Export a graph to a list of edges
-function exportGraph(nodes, directed = false) {
-    const edges = [];
-    const visited = new Set();
-
-    nodes.forEach((node) => {
-        node.neighbors.forEach((neighbor) => {
-            if (!visited.has(`${neighbor.val}-${node.val}`)) {
-                edges.push([node.val, neighbor.val]);
-                if (!directed) {
-                    visited.add(`${node.val}-${neighbor.val}`);
-                }
+// Generate a graph from an adjacency list where row i holds the neighbors of node i
+function generateGraphFromAdjacencyList(adjacency, directed = true, weighted = false) {
+    const graph = new Graph(directed, weighted);
+
+    adjacency.forEach((row, u) => {
+        graph.addNode(u);
+        row.forEach((neighbor) => {
+            const [v, w] = weighted ? neighbor : [neighbor, null];
+            // Undirected rows already list both directions, keep one copy of each edge
+            if (directed || u <= v) {
+                graph.edges.push(w === null ? [u, v] : [u, v, w]);
             }
+            graph.addArc(u, v, w);
         });
     });
 
-    return edges;
+    return graph;
+}
+
+// Export a graph to its list of edges, in the order they were added
+function exportGraph(graph) {
+    return graph.edges.map((edge) => [...edge]);
+}
+
+// Export a graph with nodes 0..n-1 to an adjacency list
+function exportAdjacencyList(graph) {
+    const size = graph.adjList.size === 0 ? 0 : Math.max(...graph.adjList.keys()) + 1;
+    const rows = [];
+
+    for (let u = 0; u < size; u++) {
+        const neighbors = graph.neighbors(u);
+        rows.push(graph.weighted ? neighbors.map((v) => [v, graph.weight(u, v)]) : [...neighbors]);
+    }
+
+    return rows;
 }
 
 // Exports
@@ -223,5 +271,7 @@ module.exports = {
     generateLinkedList,
     exportLinkedList,
     generateGraph,
+    generateGraphFromAdjacencyList,
     exportGraph,
+    exportAdjacencyList,
 };
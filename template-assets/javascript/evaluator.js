@@ -2,6 +2,7 @@ const Ajv = require("ajv");
 const fs = require("fs");
 const path = require('path');
 const converter = require('./converter');
+const { makeComparator } = require('./comparator');
 
 function loadSchema() {
   try {
@@ -56,31 +57,61 @@ function validateResponse(response, validate) {
   }
 }
 
-function runTestCases(userFunction, testCases, validate,functionConfig) {
+function runTestCases(userFunction, testCases, validate,functionConfig, compare) {
   const results = [];
   let allPassed = true;
+  let runtime = 0; // Milliseconds spent in the user's function, without the conversions and comparisons
 
+  const returnType = converter.outputType(functionConfig);
+  const mutatedIndex = converter.mutatedParameterIndex(functionConfig);
   for (const testCase of testCases) {
     try {
       const inputs = testCase.parameters.map((param, index) => converter.listyToType(param, functionConfig.parameters[index].param_type));
-      const expectedOutput = converter.listyToType(testCase.expected_output, functionConfig.return_type);
+      const expectedOutput = converter.listyToType(testCase.expected_output, returnType);
+
+      const started = performance.now();
+      let actualOutput;
+      try {
+        actualOutput = userFunction(...inputs);
+      } finally {
+        runtime += performance.now() - started;
+      }
+      if (mutatedIndex !== -1) {
+        // A void function's output is the state of the parameter it modified
+        actualOutput = inputs[mutatedIndex];
+      }
 
-      const actualOutput = userFunction(...inputs);
+      // Outputs are reported as JSON, in the same format as the test cases
+      const expectedJson = converter.typeToListy(expectedOutput, returnType);
+      let actualJson;
+      let matches = null;
+      try {
+        actualJson = converter.typeToListy(actualOutput, returnType);
+      } catch (e) {
+        // The user returned something that is not of the return type
+        actualJson = String(actualOutput);
+        matches = false;
+      }
 
-      if (JSON.stringify(actualOutput) === JSON.stringify(expectedOutput)) {
+      if (matches === null) {
+        matches = compare === null
+          ? JSON.stringify(actualOutput) === JSON.stringify(expectedOutput)
+          : compare(JSON.parse(expectedJson), JSON.parse(actualJson));
+      }
+      if (matches) {
         results.push({
           status: "pass",
           parameters: testCase.parameters,
-          expected_output: String(expectedOutput),
-          actual_output: String(actualOutput),
+          expected_output: expectedJson,
+          actual_output: actualJson,
         });
       } else {
         allPassed = false;
         results.push({
           status: "fail",
           parameters: testCase.parameters,
-          expected_output: String(expectedOutput),
-          actual_output: String(actualOutput),
+          expected_output: expectedJson,
+          actual_output: actualJson,
         });
       }
     } catch (e) {
@@ -99,6 +130,7 @@ function runTestCases(userFunction, testCases, validate,functionConfig) {
     results,
     error: allPassed ? null : "fail tests",
     details: allPassed ? null : "Some test cases failed.",
+    runtime_ms: Math.round(runtime),
   };
 
   // Validate the response against the schema
@@ -110,9 +142,10 @@ function runTestCases(userFunction, testCases, validate,functionConfig) {
   return response;
 }
 
-function evaluateUserCode(userCode, testCases, functionName, functionConfig) {
+function evaluateUserCode(userCode, testCases, functionName, functionConfig, comparatorConfig = null) {
   let userFunction;
   let validate;
+  let compare;
   try {
     const schema = loadSchema();
     validate = initializeAjv(schema);
@@ -126,10 +159,24 @@ function evaluateUserCode(userCode, testCases, functionName, functionConfig) {
   }
 
   try {
+    compare = makeComparator(comparatorConfig);
+  } catch (e) {
+    return {
+      status: "fail",
+      results: [],
+      error: "internal server error",
+      details: `Invalid comparator: ${e.message}`,
+    };
+  }
+
+  try {
+    // The user code runs in its own block, so starters may require ds_utils themselves
     const wrappedCode = `
     const utils = require('./ds_utils.js');
+    {
     ${userCode}
     return ${functionName};
+    }
     `;
 
     userFunction = new Function("require", wrappedCode)(require);
@@ -164,7 +211,7 @@ function evaluateUserCode(userCode, testCases, functionName, functionConfig) {
     return response;
   }
 
-  return runTestCases(userFunction, testCases, validate,functionConfig);
+  return runTestCases(userFunction, testCases, validate,functionConfig, compare);
 }
 
-module.exports = { evaluateUserCode };
+module.exports = { evaluateUserCode, loadSchema, initializeAjv, validateResponse };
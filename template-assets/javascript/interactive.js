@@ -0,0 +1,177 @@
+const { spawn } = require('child_process');
+const fs = require('fs');
+const os = require('os');
+const path = require('path');
+const readline = require('readline');
+const { loadSchema, initializeAjv, validateResponse } = require('./evaluator');
+
+class QueryLimitExceeded extends Error {}
+
+class ProgramExited extends Error {}
+
+class Interactor {
+    /**
+     * The judge's side of the conversation with the user's program, one line at a time.
+     * Every line the program sends counts as a query.
+     */
+    constructor(child, queryLimit) {
+        this.child = child;
+        this.queryLimit = queryLimit;
+        this.queries = 0;
+        this.lines = [];
+        this.waiting = [];
+        this.closed = false;
+
+        const lines = readline.createInterface({ input: child.stdout });
+        lines.on('line', (line) => {
+            const resolve = this.waiting.shift();
+            if (resolve) {
+                resolve(line);
+            } else {
+                this.lines.push(line);
+            }
+        });
+        lines.on('close', () => {
+            this.closed = true;
+            this.waiting.splice(0).forEach(resolve => resolve(null));
+        });
+        // Writing to a program that exited is reported by write
+        child.stdin.on('error', () => {});
+    }
+
+    async read() {
+        // Read the next line sent by the user's program, without its line ending
+        let line;
+        if (this.lines.length > 0) {
+            line = this.lines.shift();
+        } else if (this.closed) {
+            line = null;
+        } else {
+            line = await new Promise(resolve => this.waiting.push(resolve));
+        }
+        if (line === null) {
+            throw new ProgramExited("the program exited without answering");
+        }
+        this.queries += 1;
+        if (this.queryLimit && this.queries > this.queryLimit) {
+            throw new QueryLimitExceeded(`query limit of ${this.queryLimit} exceeded`);
+        }
+        return line.replace(/\r$/, "");
+    }
+
+    write(line) {
+        // Send a line to the user's program
+        if (this.child.exitCode !== null || this.child.signalCode !== null || !this.child.stdin.writable) {
+            throw new ProgramExited("the program exited before reading the judge's answer");
+        }
+        this.child.stdin.write(`${line}\n`);
+    }
+}
+
+async function runInteractive(userCode, judgeCode, testCases, queryLimit, timeLimit) {
+    /**
+     * Run the user's program against the judge on every test case, connected over stdin/stdout.
+     *
+     * Args:
+     *     userCode (str): The user's full program.
+     *     judgeCode (str): The judge code, defining judge(testInput, interactor), possibly async, that talks to the
+     *         program with await interactor.read() and interactor.write(line), and returns a boolean or a [boolean, message] pair.
+     *     testCases (list): A list of test cases, each containing parameters with the judge's input as JSON.
+     *     queryLimit (number): The most lines the program may send per test case, 0 for unlimited.
+     *     timeLimit (number): The seconds a test case may take before the program is killed.
+     *
+     * Returns:
+     *     dict: The overall status, results of each test case, and error details if any.
+     */
+    let judge;
+    try {
+        judge = new Function(`${judgeCode}\nreturn judge;`)();
+    } catch (e) {
+        return { status: "fail", results: [], error: "internal server error", details: `Invalid judge: ${e.message}` };
+    }
+    if (typeof judge !== "function") {
+        return { status: "fail", results: [], error: "internal server error", details: "judge is not defined or not a function" };
+    }
+
+    try {
+        new Function("require", userCode);
+    } catch (e) {
+        return { status: "fail", results: [], error: "compilation", details: e.message };
+    }
+
+    const directory = fs.mkdtempSync(path.join(os.tmpdir(), "interactive-"));
+    const programPath = path.join(directory, "solution.js");
+    fs.writeFileSync(programPath, userCode);
+
+    const results = [];
+    let allPassed = true;
+    try {
+        for (const testCase of testCases) {
+            const child = spawn(process.execPath, [programPath], { stdio: ["pipe", "pipe", "ignore"] });
+            const exited = new Promise(resolve => child.on('close', resolve));
+            // Kill the program when it runs out of time, which also unblocks the judge
+            let timedOut = false;
+            const timer = setTimeout(() => {
+                timedOut = true;
+                child.kill("SIGKILL");
+            }, timeLimit * 1000);
+
+            const interactor = new Interactor(child, queryLimit);
+            let passed;
+            let message;
+            try {
+                const verdict = await judge(JSON.parse(testCase.parameters[0]), interactor);
+                [passed, message] = Array.isArray(verdict) ? verdict : [verdict, ""];
+                passed = Boolean(passed);
+                message = String(message || "");
+            } catch (e) {
+                passed = false;
+                message = e instanceof QueryLimitExceeded || e instanceof ProgramExited ? e.message : `Judge error: ${e.message}`;
+            } finally {
+                clearTimeout(timer);
+                child.kill("SIGKILL");
+                await exited;
+            }
+            if (timedOut) {
+                passed = false;
+                message = `time limit of ${timeLimit}s exceeded`;
+            }
+
+            allPassed = allPassed && passed;
+            results.push({
+                status: passed ? "pass" : "fail",
+                parameters: testCase.parameters,
+                expected_output: "accepted",
+                actual_output: passed ? "accepted" : "rejected",
+                message,
+                queries: interactor.queries,
+            });
+        }
+    } finally {
+        fs.rmSync(directory, { recursive: true, force: true });
+    }
+
+    return {
+        status: allPassed ? "success" : "fail",
+        results,
+        error: allPassed ? null : "fail tests",
+        details: null,
+    };
+}
+
+async function evaluateInteractive(userCode, judgeCode, testCases, queryLimit, timeLimit) {
+    try {
+        const validate = initializeAjv(loadSchema());
+        const response = await runInteractive(userCode, judgeCode, testCases, queryLimit, timeLimit);
+        return validateResponse(response, validate) || response;
+    } catch (e) {
+        return {
+            status: "fail",
+            results: [],
+            error: "internal server error",
+            details: e.message,
+        };
+    }
+}
+
+module.exports = { runInteractive, evaluateInteractive };
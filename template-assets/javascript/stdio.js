@@ -0,0 +1,129 @@
+const { spawnSync } = require('child_process');
+const fs = require('fs');
+const os = require('os');
+const path = require('path');
+const { loadSchema, initializeAjv, validateResponse } = require('./evaluator');
+
+function normalizeOutput(text, whitespace, keepCarriageReturns) {
+    /**
+     * Normalize a program output before it is compared.
+     *
+     * Args:
+     *     text (str): The output.
+     *     whitespace (str): "exact", "trailing" to ignore trailing spaces of every line and trailing blank lines,
+     *         or "tokens" to match any run of whitespace with any other.
+     *     keepCarriageReturns (bool): When false, \r\n and \r are read as \n.
+     *
+     * Returns:
+     *     str: The normalized output.
+     */
+    if (!keepCarriageReturns) {
+        text = text.replace(/\r\n?/g, "\n");
+    }
+    if (whitespace === "exact") {
+        return text;
+    }
+    if (whitespace === "tokens") {
+        return text.split(/\s+/).filter(token => token !== "").join(" ");
+    }
+    const lines = text.split("\n").map(line => line.replace(/[ \t]+$/, ""));
+    while (lines.length > 0 && lines[lines.length - 1] === "") {
+        lines.pop();
+    }
+    return lines.join("\n");
+}
+
+function runPrograms(userCode, testCases, stdioConfig) {
+    /**
+     * Run the user's program on the stdin of every test case and compare its stdout with the expected one.
+     *
+     * Args:
+     *     userCode (str): The user's full program.
+     *     testCases (list): A list of test cases, each containing parameters with the stdin text and expected_output.
+     *     stdioConfig (dict): The question's configuration with whitespace, keep_carriage_returns and time_limit.
+     *
+     * Returns:
+     *     dict: The overall status, results of each test case, and error details if any.
+     */
+    try {
+        new Function("require", userCode);
+    } catch (e) {
+        return { status: "fail", results: [], error: "compilation", details: e.message };
+    }
+
+    const whitespace = stdioConfig.whitespace || "trailing";
+    const keepCarriageReturns = Boolean(stdioConfig.keep_carriage_returns);
+    const timeLimit = stdioConfig.time_limit || 2;
+
+    const directory = fs.mkdtempSync(path.join(os.tmpdir(), "stdio-"));
+    const programPath = path.join(directory, "solution.js");
+    fs.writeFileSync(programPath, userCode);
+
+    const results = [];
+    let allPassed = true;
+    let runtime = 0; // Milliseconds the user's program ran, over all test cases
+    try {
+        for (const testCase of testCases) {
+            const started = performance.now();
+            const completed = spawnSync(process.execPath, [programPath], {
+                input: testCase.parameters[0],
+                encoding: "utf8",
+                timeout: timeLimit * 1000,
+                killSignal: "SIGKILL",
+            });
+            runtime += performance.now() - started;
+            const actualOutput = completed.stdout || "";
+            let message = null;
+            if (completed.error && completed.error.code === "ETIMEDOUT") {
+                message = `time limit of ${timeLimit}s exceeded`;
+            } else if (completed.error) {
+                throw completed.error;
+            } else if (completed.status !== 0) {
+                const errors = (completed.stderr || "").trim().split("\n").filter(line => line.trim() !== "");
+                const lastError = errors.find(line => /^\w*Error\b/.test(line)) || errors[errors.length - 1];
+                message = `Runtime error: ${lastError || `exit code ${completed.status}`}`;
+            }
+
+            const passed = message === null &&
+                normalizeOutput(actualOutput, whitespace, keepCarriageReturns) === normalizeOutput(testCase.expected_output, whitespace, keepCarriageReturns);
+            allPassed = allPassed && passed;
+            const result = {
+                status: passed ? "pass" : "fail",
+                parameters: testCase.parameters,
+                expected_output: testCase.expected_output,
+                actual_output: actualOutput,
+            };
+            if (message !== null) {
+                result.message = message;
+            }
+            results.push(result);
+        }
+    } finally {
+        fs.rmSync(directory, { recursive: true, force: true });
+    }
+
+    return {
+        status: allPassed ? "success" : "fail",
+        results,
+        error: allPassed ? null : "fail tests",
+        details: null,
+        runtime_ms: Math.round(runtime),
+    };
+}
+
+function evaluateProgram(userCode, testCases, stdioConfig) {
+    try {
+        const validate = initializeAjv(loadSchema());
+        const response = runPrograms(userCode, testCases, stdioConfig);
+        return validateResponse(response, validate) || response;
+    } catch (e) {
+        return {
+            status: "fail",
+            results: [],
+            error: "internal server error",
+            details: e.message,
+        };
+    }
+}
+
+module.exports = { normalizeOutput, runPrograms, evaluateProgram };
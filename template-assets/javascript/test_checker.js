@@ -0,0 +1,61 @@
+const assert = require('assert');
+const { runChecker } = require('./checker');
+
+const functionConfig = {
+    parameters: [
+        { name: "n", param_type: { type: "Integer" } },
+        { name: "edges", param_type: { type: "Array", type_children: { type: "Array", type_children: { type: "Integer" } } } },
+    ],
+    return_type: { type: "Array", type_children: { type: "Integer" } },
+};
+
+const topologicalChecker = `
+function check(inputs, expected, actual) {
+    const [n, edges] = inputs;
+    const position = new Map(actual.map((node, i) => [node, i]));
+    if (actual.length !== n || position.size !== n) {
+        return [false, "not a permutation of the nodes"];
+    }
+    for (const [u, v] of edges) {
+        if (position.get(u) > position.get(v)) {
+            return [false, \`\${u} must come before \${v}\`];
+        }
+    }
+    return [true, "valid order"];
+}
+`;
+
+const checkerCase = (actual) => ({ parameters: ["3", "[[0,1],[0,2]]"], expected_output: "[0,1,2]", actual_output: actual });
+
+describe('runChecker', function() {
+    it('should accept any valid answer', function() {
+        const results = runChecker(topologicalChecker, [checkerCase("[0,1,2]"), checkerCase("[0,2,1]")], functionConfig);
+        assert.strictEqual(results.error, null);
+        assert.deepStrictEqual(results.verdicts.map(v => v.status), ["pass", "pass"]);
+        assert.strictEqual(results.verdicts[0].message, "valid order");
+    });
+
+    it('should reject with the checker message', function() {
+        const results = runChecker(topologicalChecker, [checkerCase("[1,0,2]")], functionConfig);
+        assert.deepStrictEqual(results.verdicts, [{ status: "fail", message: "0 must come before 1" }]);
+    });
+
+    it('should accept a plain boolean', function() {
+        const code = "function check(inputs, expected, actual) { return actual.join() === expected.join(); }";
+        const results = runChecker(code, [checkerCase("[0,1,2]"), checkerCase("[0,2,1]")], functionConfig);
+        assert.deepStrictEqual(results.verdicts.map(v => v.status), ["pass", "fail"]);
+    });
+
+    it('should fail the case when the checker throws', function() {
+        const code = "function check() { throw new Error('boom'); }";
+        const results = runChecker(code, [checkerCase("[0,1,2]")], functionConfig);
+        assert.strictEqual(results.verdicts[0].status, "fail");
+        assert(results.verdicts[0].message.includes("boom"));
+    });
+
+    it('should report a missing check function', function() {
+        const results = runChecker("const x = 1;", [checkerCase("[0,1,2]")], functionConfig);
+        assert.deepStrictEqual(results.verdicts, []);
+        assert.notStrictEqual(results.error, null);
+    });
+});
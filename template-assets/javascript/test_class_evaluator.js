@@ -0,0 +1,80 @@
+const assert = require('assert');
+const { evaluateUserClass } = require('./class_evaluator');
+
+const integer = { type: "Integer" };
+const classConfig = {
+    name: "LRUCache",
+    constructor: [{ name: "capacity", param_type: integer }],
+    methods: [
+        { name: "get", parameters: [{ name: "key", param_type: integer }], return_type: integer },
+        { name: "put", parameters: [{ name: "key", param_type: integer }, { name: "value", param_type: integer }] },
+    ],
+};
+const methodNames = { get: "get", put: "put" };
+
+const lruCache = `
+class LRUCache {
+    constructor(capacity) {
+        this.capacity = capacity;
+        this.entries = new Map();
+    }
+
+    get(key) {
+        if (!this.entries.has(key)) {
+            return -1;
+        }
+        const value = this.entries.get(key);
+        this.entries.delete(key);
+        this.entries.set(key, value);
+        return value;
+    }
+
+    put(key, value) {
+        this.entries.delete(key);
+        this.entries.set(key, value);
+        if (this.entries.size > this.capacity) {
+            this.entries.delete(this.entries.keys().next().value);
+        }
+    }
+}
+`;
+
+const sequence = {
+    parameters: [
+        JSON.stringify(["LRUCache", "put", "put", "get", "put", "get", "get"]),
+        "[[2],[1,1],[2,2],[1],[3,3],[2],[3]]",
+    ],
+    expected_output: "[null,null,null,1,null,-1,3]",
+};
+
+describe('evaluateUserClass', function() {
+    it('should pass a correct operation sequence', function() {
+        const results = evaluateUserClass(lruCache, [sequence], classConfig, methodNames);
+        assert.strictEqual(results.status, "success");
+        assert.strictEqual(results.results[0].actual_output, "[null,null,null,1,null,-1,3]");
+    });
+
+    it('should report the runtime of the class', function() {
+        const code = lruCache.replace("return -1;", "const until = Date.now() + 50;\n            while (Date.now() < until) {}\n            return -1;");
+        assert(evaluateUserClass(code, [sequence], classConfig, methodNames).runtime_ms >= 50);
+        assert(evaluateUserClass(lruCache, [sequence], classConfig, methodNames).runtime_ms < 50);
+    });
+
+    it('should fail when an output differs', function() {
+        const code = lruCache.replace("this.entries.delete(key);\n        this.entries.set(key, value);\n        return value;", "return value;");
+        const results = evaluateUserClass(code, [sequence], classConfig, methodNames);
+        assert.strictEqual(results.status, "fail");
+        assert.strictEqual(results.results[0].actual_output, "[null,null,null,1,null,2,3]");
+    });
+
+    it('should report the failing operation', function() {
+        const code = lruCache.replace("return -1;", "throw new Error('missing');");
+        const results = evaluateUserClass(code, [sequence], classConfig, methodNames);
+        assert(results.results[0].actual_output.startsWith("Error at operation 5"));
+    });
+
+    it('should report a missing class as a compilation error', function() {
+        const results = evaluateUserClass("class Cache {}", [sequence], classConfig, methodNames);
+        assert.strictEqual(results.error, "compilation");
+    });
+});
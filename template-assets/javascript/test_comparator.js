@@ -0,0 +1,48 @@
+const assert = require('assert');
+const { makeComparator } = require('./comparator');
+
+describe('makeComparator', function() {
+    it('should use native equality for exact', function() {
+        assert.strictEqual(makeComparator(null), null);
+        assert.strictEqual(makeComparator({ type: "exact" }), null);
+    });
+
+    it('should ignore the outer order for unordered', function() {
+        const compare = makeComparator({ type: "unordered" });
+        assert(compare([1, 0], [0, 1]));
+        assert(compare([[1, 2], [3, 4]], [[3, 4], [1, 2]]));
+        assert(!compare([[1, 2], [3, 4]], [[4, 3], [1, 2]]));
+        assert(!compare([1, 1, 2], [1, 2, 2]));
+    });
+
+    it('should ignore the order at every depth for unordered_nested', function() {
+        const compare = makeComparator({ type: "unordered_nested" });
+        assert(compare([[1, 2], [3, 4]], [[4, 3], [2, 1]]));
+        assert(!compare([[1, 2], [3]], [[1], [2, 3]]));
+    });
+
+    it('should compare sets of sets', function() {
+        const compare = makeComparator({ type: "set_of_sets" });
+        assert(compare([[1, 2], [2, 1], [3]], [[3], [2, 1]]));
+        assert(!compare([[1, 2]], [[1, 2], [3]]));
+    });
+
+    it('should compare numbers within the tolerance', function() {
+        const compare = makeComparator({ type: "float_tolerance", tolerance: 1e-3 });
+        assert(compare(0.3333, 1 / 3));
+        assert(compare([1.0, 2.0], [1.0004, 1.9996]));
+        assert(!compare([1.0, 2.0], [1.01, 2.0]));
+        assert(!compare(true, 1));
+    });
+
+    it('should run custom compare code', function() {
+        const compare = makeComparator({ type: "custom", code: { JavaScript: "function compare(expected, actual) { return expected.length === actual.length; }" } });
+        assert(compare([1, 2], [3, 4]));
+        assert(!compare([1, 2], [3]));
+        assert.throws(() => makeComparator({ type: "custom", code: { Python: "def compare(e, a): return True" } }), Error);
+    });
+
+    it('should throw error for invalid type', function() {
+        assert.throws(() => makeComparator({ type: "Unknown" }), Error);
+    });
+});
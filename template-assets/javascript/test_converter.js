@@ -1,7 +1,7 @@
 const assert = require('assert');
 const dsUtils = require('./ds_utils');
 const { TreeNode, Graph, ListNode } = dsUtils;
-const { listyToType } = require('./converter');
+const { listyToType, typeToListy, outputType } = require('./converter');
 
 describe('listyToType Integration Tests', function() {
     it('should convert to TreeNode', function() {
@@ -34,6 +34,45 @@ describe('listyToType Integration Tests', function() {
         assert.deepStrictEqual(dsUtils.exportGraph(graph), [[1, 2], [2, 3], [3, 1]]);
     });
 
+    it('should convert to a directed weighted Graph', function() {
+        const stringyInput = "[[1, 2, 4], [2, 3, 1.5]]";
+        const abstractType = {
+            type: "Graph",
+            directed: true,
+            weight_type: { type: "Double" },
+            typeChildren: {
+                type: "Integer"
+            }
+        };
+
+        const graph = listyToType(stringyInput, abstractType);
+        assert(graph instanceof Graph);
+        assert.deepStrictEqual(graph.neighbors(1), [2]);
+        assert.deepStrictEqual(graph.neighbors(3), []);
+        assert.strictEqual(graph.weight(2, 3), 1.5);
+        assert.strictEqual(graph.weight(3, 2), null);
+        assert.deepStrictEqual(dsUtils.exportGraph(graph), [[1, 2, 4], [2, 3, 1.5]]);
+    });
+
+    it('should convert an adjacency list to Graph', function() {
+        const stringyInput = "[[[1, 7]], [[0, 7]], []]";
+        const abstractType = {
+            type: "Graph",
+            format: "adjacency_list",
+            directed: false,
+            weight_type: { type: "Integer" },
+            typeChildren: {
+                type: "Integer"
+            }
+        };
+
+        const graph = listyToType(stringyInput, abstractType);
+        assert.deepStrictEqual(graph.neighbors(2), []);
+        assert.strictEqual(graph.weight(1, 0), 7);
+        assert.deepStrictEqual(dsUtils.exportGraph(graph), [[0, 1, 7]]);
+        assert.deepStrictEqual(dsUtils.exportAdjacencyList(graph), [[[1, 7]], [[0, 7]], []]);
+    });
+
     it('should convert to ListNode', function() {
         const stringyInput = "[1, 2, 3, 4]";
         const abstractType = {
@@ -76,9 +115,46 @@ describe('listyToType Integration Tests', function() {
         assert.deepStrictEqual(matrix, [[1, 2], [3, 4], [5, 6]]);
     });
 
+    it('should convert Strings with special characters', function() {
+        const abstractType = {
+            type: "Array",
+            typeChildren: {
+                type: "String"
+            }
+        };
+
+        assert.strictEqual(listyToType('"a, b"', { type: "String" }), "a, b");
+        assert.strictEqual(listyToType('"say \\"hi\\""', { type: "String" }), 'say "hi"');
+        assert.strictEqual(listyToType('"line\\nbreak"', { type: "String" }), "line\nbreak");
+        assert.strictEqual(listyToType('"caf\\u00e9"', { type: "String" }), "caf\u00e9");
+        assert.deepStrictEqual(listyToType('["[x]", "a,b", ""]', abstractType), ["[x]", "a,b", ""]);
+    });
+
+    it('should convert values back to their JSON representation', function() {
+        const cases = [
+            ['"a, \\"b\\""', { type: "String" }],
+            ["[[1, 2], [3, 4]]", { type: "Matrix", type_children: { type: "Integer" } }],
+            ["[1, null, 2, 3]", { type: "TreeNode", type_children: { type: "Integer" } }],
+            ["[1, 2, 3]", { type: "ListNode", type_children: { type: "Integer" } }],
+            ["[[0, 1, 5], [1, 2, 7]]", { type: "Graph", type_children: { type: "Integer" }, directed: true, weight_type: { type: "Integer" } }],
+            ["[[1], [0, 2], [1]]", { type: "Graph", type_children: { type: "Integer" }, format: "adjacency_list" }],
+        ];
+        cases.forEach(([stringyInput, abstractType]) => {
+            const value = listyToType(stringyInput, abstractType);
+            assert.deepStrictEqual(JSON.parse(typeToListy(value, abstractType)), JSON.parse(stringyInput));
+        });
+    });
+
     it('should throw error for invalid type', function() {
         const stringyInput = "[1, 2, 3]";
         const abstractType = { type: "Unknown" };
         assert.throws(() => listyToType(stringyInput, abstractType), Error);
     });
+
+    it('should use the mutated parameter type as the output type of void functions', function() {
+        const array = { type: "Array", type_children: { type: "Integer" } };
+        const parameters = [{ name: "nums", param_type: array }, { name: "k", param_type: { type: "Integer" } }];
+        assert.deepStrictEqual(outputType({ parameters, return_type: { type: "Integer" } }), { type: "Integer" });
+        assert.deepStrictEqual(outputType({ parameters, mutated_parameter: "nums" }), array);
+    });
 });
@@ -0,0 +1,82 @@
+const assert = require('assert');
+const { runInteractive } = require('./interactive');
+
+const guessJudge = `
+async function judge(secret, interactor) {
+    while (true) {
+        const guess = Number(await interactor.read());
+        if (guess === secret) {
+            interactor.write("correct");
+            return [true, "found the number"];
+        }
+        interactor.write(guess < secret ? "higher" : "lower");
+    }
+}
+`;
+
+const binarySearch = `
+const readline = require('readline');
+const lines = readline.createInterface({ input: process.stdin });
+let low = 1, high = 100, guess = 50;
+console.log(guess);
+lines.on('line', (answer) => {
+    if (answer === "correct") {
+        lines.close();
+        return;
+    }
+    if (answer === "higher") {
+        low = guess + 1;
+    } else {
+        high = guess - 1;
+    }
+    guess = Math.floor((low + high) / 2);
+    console.log(guess);
+});
+`;
+
+const linearSearch = `
+const readline = require('readline');
+const lines = readline.createInterface({ input: process.stdin });
+let guess = 1;
+console.log(guess);
+lines.on('line', (answer) => {
+    if (answer === "correct") {
+        lines.close();
+        return;
+    }
+    guess += 1;
+    console.log(guess);
+});
+`;
+
+const cases = (...secrets) => secrets.map(secret => ({ parameters: [JSON.stringify(secret)], expected_output: "" }));
+
+describe('runInteractive', function() {
+    it('should accept within the query limit', async function() {
+        const results = await runInteractive(binarySearch, guessJudge, cases(1, 37, 100), 7, 5);
+        assert.strictEqual(results.status, "success");
+        assert.strictEqual(results.results[0].message, "found the number");
+        assert(results.results.every(result => result.queries <= 7));
+    });
+
+    it('should stop at the query limit', async function() {
+        const results = await runInteractive(linearSearch, guessJudge, cases(50), 7, 5);
+        assert.strictEqual(results.status, "fail");
+        assert(results.results[0].message.includes("query limit"));
+    });
+
+    it('should kill a program out of time', async function() {
+        const results = await runInteractive("setTimeout(() => {}, 10000);", guessJudge, cases(50), 0, 0.5);
+        assert(results.results[0].message.includes("time limit"));
+    });
+
+    it('should report a program that exits', async function() {
+        const results = await runInteractive("console.log(1);", guessJudge, cases(50), 0, 5);
+        assert(results.results[0].message.includes("exited"));
+    });
+
+    it('should report syntax errors as compilation errors', async function() {
+        const results = await runInteractive("function (", guessJudge, cases(50), 0, 5);
+        assert.strictEqual(results.error, "compilation");
+    });
+});
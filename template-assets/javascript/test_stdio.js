@@ -0,0 +1,59 @@
+const assert = require('assert');
+const { normalizeOutput, runPrograms } = require('./stdio');
+
+const sumLines = `
+const input = require('fs').readFileSync(0, 'utf8');
+for (const line of input.split('\\n').filter(line => line.trim() !== '')) {
+    const [a, b] = line.split(' ').map(Number);
+    console.log(a + b);
+}
+`;
+
+describe('normalizeOutput', function() {
+    it('should ignore trailing whitespace by default', function() {
+        assert.strictEqual(normalizeOutput("3  \r\n7\t\n\n", "trailing", false), "3\n7");
+        assert.notStrictEqual(normalizeOutput("3\n 7", "trailing", false), "3\n7");
+    });
+
+    it('should keep the output as is for exact', function() {
+        assert.strictEqual(normalizeOutput("3\r\n", "exact", false), "3\n");
+        assert.strictEqual(normalizeOutput("3\r\n", "exact", true), "3\r\n");
+    });
+
+    it('should compare tokens', function() {
+        assert.strictEqual(normalizeOutput(" 1  2\n3\n", "tokens", false), "1 2 3");
+    });
+});
+
+describe('runPrograms', function() {
+    it('should compare the normalized output', function() {
+        const cases = [
+            { parameters: ["1 2\n3 4\n"], expected_output: "3\n7" },
+            { parameters: ["1 1\n"], expected_output: "3\n" },
+        ];
+        const results = runPrograms(sumLines, cases, {});
+        assert.deepStrictEqual(results.results.map(result => result.status), ["pass", "fail"]);
+        assert.strictEqual(results.results[1].actual_output, "2\n");
+    });
+
+    it('should report the runtime of the program', function() {
+        const results = runPrograms("const until = Date.now() + 50;\nwhile (Date.now() < until) {}\n", [{ parameters: [""], expected_output: "" }], {});
+        assert(results.runtime_ms >= 50);
+    });
+
+    it('should report runtime errors', function() {
+        const results = runPrograms("throw new Error('bad input');", [{ parameters: [""], expected_output: "" }], {});
+        assert.strictEqual(results.results[0].status, "fail");
+        assert(results.results[0].message.includes("bad input"));
+    });
+
+    it('should kill a program out of time', function() {
+        const results = runPrograms("setTimeout(() => {}, 5000);", [{ parameters: [""], expected_output: "" }], { time_limit: 0.5 });
+        assert(results.results[0].message.includes("time limit"));
+    });
+
+    it('should report syntax errors as compilation errors', function() {
+        const results = runPrograms("console.log(", [{ parameters: [""], expected_output: "" }], {});
+        assert.strictEqual(results.error, "compilation");
+    });
+});
@@ -0,0 +1,41 @@
+import converter
+
+
+def run_checker(checker_code, cases, function_config):
+    """
+    Run a question's checker over the outputs of the user's code.
+
+    Args:
+        checker_code (str): The checker code, defining check(inputs, expected, actual)
+            that returns a bool or a (bool, message) tuple.
+        cases (list): A list of cases, each containing 'parameters', 'expected_output' and 'actual_output'.
+        function_config (dict): The function configuration, to convert the values to their types.
+
+    Returns:
+        dict: The verdict of each case, and the error if the checker itself failed.
+    """
+    namespace = {}
+    try:
+        exec(compile("import ds_utils as utils\n" + checker_code, filename="<checker>", mode="exec"), namespace)
+    except Exception as e:
+        return {"verdicts": [], "error": f"checker compilation: {str(e)}"}
+
+    check = namespace.get("check")
+    if not callable(check):
+        return {"verdicts": [], "error": "check is not defined or callable"}
+
+    return_type = converter.output_type(function_config)
+    verdicts = []
+    for case in cases:
+        try:
+            inputs = [converter.listy_to_type(case["parameters"][i], function_config["parameters"][i]["param_type"]) for i in range(len(case["parameters"]))]
+            expected_output = converter.listy_to_type(case["expected_output"], return_type)
+            actual_output = converter.listy_to_type(case["actual_output"], return_type)
+
+            verdict = check(inputs, expected_output, actual_output)
+            passed, message = verdict if isinstance(verdict, tuple) else (verdict, "")
+            verdicts.append({"status": "pass" if passed else "fail", "message": str(message or "")})
+        except Exception as e:
+            verdicts.append({"status": "fail", "message": f"Checker error: {str(e)}"})
+
+    return {"verdicts": verdicts, "error": None}
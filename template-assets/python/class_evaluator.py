@@ -0,0 +1,152 @@
+import json
+import os
+import time
+import converter
+from evaluator import validate_results
+
+
+def run_operation_sequences(compiled_code, test_cases, class_config, method_names):
+    """
+    Run operation sequences against the compiled user class.
+
+    Args:
+        compiled_code (code): The compiled user code.
+        test_cases (list): A list of test cases, each containing 'parameters' (the operations and
+            their arguments, as JSON arrays) and 'expected_output' (the output of each operation).
+        class_config (dict): The class configuration with "name", "constructor" and "methods".
+        method_names (dict): The Python name of each method, by its name in the operations.
+
+    Returns:
+        dict: A dictionary containing the overall status, results of each test case, and error details if any.
+    """
+    results = []
+    namespace = {}
+    all_passed = True  # Track if all test cases pass
+    runtime = 0.0  # Seconds spent in the user's class, without the conversions and comparisons
+
+    try:
+        exec(compiled_code, namespace)  # Execute user code in a separate namespace
+    except Exception as e:
+        return {
+            "status": "fail",
+            "error": "compilation",
+            "details": str(e),
+            "results": [],
+        }
+
+    class_name = class_config["name"]
+    user_class = namespace.get(class_name)
+    if not isinstance(user_class, type):
+        return {
+            "status": "fail",
+            "error": "compilation",
+            "details": f"{class_name} is not defined or not a class",
+            "results": [],
+        }
+
+    methods = {method["name"]: method for method in class_config["methods"]}
+    for case in test_cases:
+        operations = json.loads(case["parameters"][0])
+        arguments = json.loads(case["parameters"][1])
+        expected_outputs = json.loads(case["expected_output"])
+        actual_outputs = []
+        try:
+            instance = None
+            for i, operation in enumerate(operations):
+                if i == 0:
+                    parameters, return_type = class_config.get("constructor") or [], None
+                else:
+                    parameters, return_type = methods[operation].get("parameters") or [], methods[operation].get("return_type")
+                inputs = [converter.listy_to_type(json.dumps(arguments[i][j]), parameters[j]["param_type"]) for j in range(len(parameters))]
+
+                started = time.perf_counter()
+                try:
+                    if i == 0:
+                        instance = user_class(*inputs)
+                    else:
+                        output = getattr(instance, method_names[operation])(*inputs)
+                finally:
+                    runtime += time.perf_counter() - started
+                if i == 0:
+                    actual_outputs.append(None)
+                    continue
+
+                # Void methods output null, whatever they return
+                if return_type is None:
+                    actual_outputs.append(None)
+                    continue
+                try:
+                    actual_outputs.append(json.loads(converter.type_to_listy(output, return_type)))
+                except (TypeError, ValueError, AttributeError):
+                    # The user returned something that is not of the return type
+                    actual_outputs.append(str(output))
+                expected_outputs[i] = json.loads(converter.type_to_listy(converter.listy_to_type(json.dumps(expected_outputs[i]), return_type), return_type))
+
+            passed = actual_outputs == expected_outputs
+            actual_output = json.dumps(actual_outputs, ensure_ascii=False)
+        except Exception as e:
+            passed = False
+            actual_output = f"Error at operation {len(actual_outputs)}: {str(e)}"
+
+        all_passed = all_passed and passed
+        results.append(
+            {
+                "status": "pass" if passed else "fail",
+                "parameters": case["parameters"],
+                "expected_output": json.dumps(expected_outputs, ensure_ascii=False),
+                "actual_output": actual_output,
+            }
+        )
+
+    overall_status = "success" if all_passed else "fail"
+    return {
+        "status": overall_status,
+        "results": results,
+        "error": None if all_passed else "fail tests",
+        "details": None,
+        "runtime_ms": round(runtime * 1000),
+    }
+
+
+def evaluate_user_class(user_code, test_cases, class_config, method_names, schema_path="../feedback_schema.json"):
+    """
+    Evaluate the user's class by compiling it, running the operation sequences, and validating the results against a schema.
+
+    Args:
+        user_code (str): The user's code as a string.
+        test_cases (list): A list of operation sequences, each containing 'parameters' and 'expected_output'.
+        class_config (dict): The class configuration.
+        method_names (dict): The Python name of each method, by its name in the operations.
+        schema_path (str): The path to the JSON schema file for validation.
+
+    Returns:
+        dict: A dictionary containing the overall status, results of each test case, and error details if any.
+    """
+    try:
+        resolved_schema_path = os.path.join(os.path.dirname(os.path.abspath(__file__)), schema_path)
+
+        # Step 1: Compile the user's code
+        user_code = "import ds_utils as utils\n" + user_code
+        try:
+            compiled_code = compile(user_code, filename="<user_code>", mode="exec")
+        except SyntaxError as e:
+            return {
+                "status": "fail",
+                "error": "compilation",
+                "details": str(e),
+                "results": [],
+            }
+
+        # Step 2: Run the operation sequences
+        results = run_operation_sequences(compiled_code, test_cases, class_config, method_names)
+
+        # Step 3: Validate against schema
+        return validate_results(results, resolved_schema_path)
+    except Exception as e:
+        # Catch any unexpected errors and mark as internal server error
+        return {
+            "status": "fail",
+            "error": "internal server error",
+            "details": str(e),
+            "results": [],
+        }
@@ -0,0 +1,84 @@
+import json
+import math
+
+DEFAULT_TOLERANCE = 1e-6
+
+
+def make_comparator(comparator):
+    """
+    Build the function that decides whether an output matches the expected one.
+
+    Args:
+        comparator (dict): The question's comparator config with "type" and optional "tolerance" and "code".
+
+    Returns:
+        Callable[[Any, Any], bool]: compare(expected, actual) over JSON representations
+        (plain lists, numbers, strings, booleans and None), or None for exact matching,
+        where the converted values are compared with ==.
+    """
+    comparator = comparator or {}
+    comparator_type = comparator.get("type") or "exact"
+
+    if comparator_type == "exact":
+        return None
+
+    if comparator_type == "unordered":
+        return lambda expected, actual: _unordered(expected, nested=False) == _unordered(actual, nested=False)
+
+    if comparator_type == "unordered_nested":
+        return lambda expected, actual: _unordered(expected, nested=True) == _unordered(actual, nested=True)
+
+    if comparator_type == "set_of_sets":
+        return lambda expected, actual: _set_of_sets(expected) == _set_of_sets(actual)
+
+    if comparator_type == "float_tolerance":
+        tolerance = comparator.get("tolerance") or DEFAULT_TOLERANCE
+        return lambda expected, actual: _close(expected, actual, tolerance)
+
+    if comparator_type == "custom":
+        code = (comparator.get("code") or {}).get("Python")
+        if not code:
+            raise ValueError("custom comparator has no Python code")
+        namespace = {}
+        exec(compile(code, filename="<comparator>", mode="exec"), namespace)
+        compare = namespace.get("compare")
+        if not callable(compare):
+            raise ValueError("custom comparator must define compare(expected, actual)")
+        return lambda expected, actual: bool(compare(expected, actual))
+
+    raise ValueError(f"Unsupported comparator: {comparator_type}")
+
+
+def _canonical(value):
+    return json.dumps(value, sort_keys=True)
+
+
+def _unordered(value, nested):
+    """Sort a list, and every inner list when nested, so that order no longer matters."""
+    if not isinstance(value, list):
+        return _canonical(value)
+    items = [_unordered(item, nested) if nested else _canonical(item) for item in value]
+    return "[" + ",".join(sorted(items)) + "]"
+
+
+def _set_of_sets(value):
+    if not isinstance(value, list):
+        return _canonical(value)
+    inner = set()
+    for item in value:
+        if isinstance(item, list):
+            inner.add("[" + ",".join(sorted({_canonical(element) for element in item})) + "]")
+        else:
+            inner.add(_canonical(item))
+    return "[" + ",".join(sorted(inner)) + "]"
+
+
+def _close(expected, actual, tolerance):
+    """Compare recursively, numbers within an absolute or relative tolerance."""
+    if isinstance(expected, bool) or isinstance(actual, bool):
+        return isinstance(expected, bool) and isinstance(actual, bool) and expected == actual
+    if isinstance(expected, (int, float)) and isinstance(actual, (int, float)):
+        return math.isclose(expected, actual, rel_tol=tolerance, abs_tol=tolerance)
+    if isinstance(expected, list) and isinstance(actual, list):
+        return len(expected) == len(actual) and all(_close(e, a, tolerance) for e, a in zip(expected, actual))
+    return expected == actual
@@ -1,4 +1,4 @@
-import ast
+import json
 import ds_utils
 
 def listy_to_type(stringy_listry_rep, abstract_type):
@@ -7,16 +7,16 @@ def listy_to_type(stringy_listry_rep, abstract_type):
     based on the given abstract type.
 
     Args:
-        stringy_listry_rep (str): The stringy representation of the data.
+        stringy_listry_rep (str): The JSON representation of the data (strings are quoted JSON literals).
         abstract_type (dict): The abstract type definition with "type" and optional "type_children".
 
     Returns:
         Any: The converted data structure.
     """
     try:
-        # Safely parse the stringy representation into Python structures
-        listy_rep = ast.literal_eval(stringy_listry_rep)
-    except (ValueError, SyntaxError) as e:
+        # Parse the JSON representation into Python structures
+        listy_rep = json.loads(stringy_listry_rep)
+    except ValueError as e:
         raise ValueError(f"Failed to parse input: {stringy_listry_rep}. Error: {str(e)}")
 
     base_type = abstract_type["type"]
@@ -30,22 +30,22 @@ def listy_to_type(stringy_listry_rep, abstract_type):
     # Handle composite types
     if base_type == "Array":
         # Recursively convert each element using the child type
-        return [listy_to_type(repr(item), type_children) for item in listy_rep]
+        return [listy_to_type(json.dumps(item), type_children) for item in listy_rep]
 
     if base_type == "Matrix":
         # Recursively convert each row using the child type
-        return [listy_to_type(repr(row), type_children) for row in listy_rep]
+        return [listy_to_type(json.dumps(row), type_children) for row in listy_rep]
 
     if base_type == "TreeNode":
         # Use the utility function to generate a TreeNode
         tree = ds_utils.generate_tree(listy_rep)
         if type_children:
             # Convert the value and children using the child type
-            tree.val = listy_to_type(repr(tree.val), type_children)
+            tree.val = listy_to_type(json.dumps(tree.val), type_children)
             if tree.left:
-                tree.left = listy_to_type(repr(ds_utils.export_tree(tree.left)), abstract_type)
+                tree.left = listy_to_type(json.dumps(ds_utils.export_tree(tree.left)), abstract_type)
             if tree.right:
-                tree.right = listy_to_type(repr(ds_utils.export_tree(tree.right)), abstract_type)
+                tree.right = listy_to_type(json.dumps(ds_utils.export_tree(tree.right)), abstract_type)
         return tree
 
     if base_type == "ListNode":
@@ -55,20 +55,96 @@ def listy_to_type(stringy_listry_rep, abstract_type):
             # Convert each node value using the child type
             current = linked_list
             while current:
-                current.val = listy_to_type(repr(current.val), type_children)
+                current.val = listy_to_type(json.dumps(current.val), type_children)
                 current = current.next
         return linked_list
 
     if base_type == "Graph":
+        directed = abstract_type.get("directed", True)  # Graphs are directed unless the type says otherwise
+        weight_type = abstract_type.get("weight_type")
+        if abstract_type.get("format") == "adjacency_list":
+            return ds_utils.generate_graph_from_adjacency_list(listy_rep, directed, weight_type is not None)
+
         # Use the utility function to generate a Graph
-        graph = ds_utils.generate_graph(listy_rep)
+        graph = ds_utils.generate_graph(listy_rep, directed, weight_type is not None)
         if type_children:
             # Convert each node and its neighbors using the child type
             new_adj_list = {}
             for u, neighbors in graph.adj_list.items():
-                converted_u = listy_to_type(repr(u), type_children)
-                new_adj_list[converted_u] = [listy_to_type(repr(neighbor), type_children) for neighbor in neighbors]
+                converted_u = listy_to_type(json.dumps(u), type_children)
+                new_adj_list[converted_u] = [listy_to_type(json.dumps(neighbor), type_children) for neighbor in neighbors]
             graph.adj_list = new_adj_list
         return graph
 
     raise ValueError(f"Unsupported type: {base_type}")
+
+
+def type_to_listy(value, abstract_type):
+    """
+    Convert a data structure back into its JSON representation, the inverse of listy_to_type.
+
+    Args:
+        value (Any): The data structure, e.g. a function's return value.
+        abstract_type (dict): The abstract type definition with "type" and optional "type_children".
+
+    Returns:
+        str: The JSON representation (strings are quoted JSON literals).
+    """
+    return json.dumps(_to_listy(value, abstract_type), ensure_ascii=False)
+
+
+def _to_listy(value, abstract_type):
+    if value is None:
+        return None
+
+    base_type = abstract_type["type"]
+    type_children = abstract_type.get("type_children")
+
+    if base_type in ["Integer", "Double", "String", "Boolean"]:
+        return value
+
+    if base_type in ["Array", "Matrix"]:
+        return [_to_listy(item, type_children) for item in value]
+
+    if base_type == "TreeNode":
+        return ds_utils.export_tree(value)
+
+    if base_type == "ListNode":
+        return ds_utils.export_linked_list(value)
+
+    if base_type == "Graph":
+        if abstract_type.get("format") == "adjacency_list":
+            return ds_utils.export_adjacency_list(value)
+        return ds_utils.export_graph(value)
+
+    raise ValueError(f"Unsupported type: {base_type}")
+
+
+def mutated_parameter_index(function_config):
+    """
+    Return the position of the parameter a void function modifies in place, or None.
+
+    Args:
+        function_config (dict): The function configuration with "parameters" and optional "mutated_parameter".
+    """
+    if function_config.get("return_type") is not None:
+        return None
+    name = function_config.get("mutated_parameter")
+    for i, param in enumerate(function_config.get("parameters") or []):
+        if param["name"] == name:
+            return i
+    return None
+
+
+def output_type(function_config):
+    """
+    Return the abstract type of the outputs: the return type, or for a void function
+    the type of the mutated parameter, whose state after the call is compared.
+
+    Args:
+        function_config (dict): The function configuration.
+    """
+    index = mutated_parameter_index(function_config)
+    if index is None:
+        return function_config["return_type"]
+    return function_config["parameters"][index]["param_type"]
@@ -51,13 +51,35 @@ class ListNode(Generic[T]):
         return hash_value
 
 class Graph(Generic[T]):
-    def __init__(self):
+    def __init__(self, directed: bool = True, weighted: bool = False):
+        self.directed = directed
+        self.weighted = weighted
         self.adj_list: Dict[T, List[Union[T, 'Graph[T]', 'TreeNode[T]', 'ListNode[T]']]] = {}
+        self.weights: Dict[tuple, Union[int, float]] = {}
+        self.edges: List[list] = []  # Edges in input order, as given
 
-    def add_edge(self, u: T, v: Union[T, 'Graph[T]', 'TreeNode[T]', 'ListNode[T]']):
+    def add_node(self, u: T):
         if u not in self.adj_list:
             self.adj_list[u] = []
+
+    def add_edge(self, u: T, v: Union[T, 'Graph[T]', 'TreeNode[T]', 'ListNode[T]'], w: Union[int, float, None] = None):
+        self.edges.append([u, v] if w is None else [u, v, w])
+        self._add_arc(u, v, w)
+        if not self.directed and u != v:
+            self._add_arc(v, u, w)
+
+    def _add_arc(self, u, v, w):
+        self.add_node(u)
+        self.add_node(v)
         self.adj_list[u].append(v)
+        if w is not None:
+            self.weights[(u, v)] = w
+
+    def neighbors(self, u: T) -> List[T]:
+        return self.adj_list.get(u, [])
+
+    def weight(self, u: T, v: T) -> Union[int, float, None]:
+        return self.weights.get((u, v))
 
     def __eq__(self, other):
         if not isinstance(other, Graph):
@@ -67,10 +89,10 @@ class Graph(Generic[T]):
         for key in self.adj_list:
             if sorted(self.adj_list[key], key=str) != sorted(other.adj_list[key], key=str):
                 return False
-        return True
+        return self.weights == other.weights
 
     def __repr__(self):
-        return f"Graph(adj_list={self.adj_list})"
+        return f"Graph(directed={self.directed}, weighted={self.weighted}, adj_list={self.adj_list})"
 
     def __hash__(self):
         return hash(frozenset((u, frozenset(neighbors)) for u, neighbors in self.adj_list.items()))
@@ -106,20 +128,43 @@ def generate_tree(values: List[Optional[Union[T, dict]]]) -> Optional[TreeNode[T
     return root
 
 # Generate a graph that supports nested data structures
-def generate_graph(edges: List[List[Union[T, dict]]]) -> Graph[T]:
+def generate_graph(edges: List[List[Union[T, dict]]], directed: bool = True, weighted: bool = False) -> Graph[T]:
     """
-    Generate a graph from a list of directed edges with nested structures.
+    Generate a graph from a list of edges with nested structures.
 
-    :param edges: List of edges (e.g., [[1, 2], [3, {"val": 4}]]).
+    :param edges: List of edges (e.g., [[1, 2], [3, {"val": 4}]], or [[1, 2, 7]] when weighted).
+    :param directed: Whether each edge goes only from u to v.
+    :param weighted: Whether each edge carries a weight as its third element.
     :return: A Graph object represented as an adjacency list.
     """
-    graph = Graph[T]()
+    graph = Graph[T](directed, weighted)
+    edge_length = 3 if weighted else 2
     for edge in edges:
-        if len(edge) != 2:
-            raise ValueError(f"Invalid edge format: {edge}. Each edge must have exactly two elements.")
+        if len(edge) != edge_length:
+            raise ValueError(f"Invalid edge format: {edge}. Each edge must have exactly {edge_length} elements.")
         u = edge[0] if not isinstance(edge[0], dict) else TreeNode(**edge[0])
         v = edge[1] if not isinstance(edge[1], dict) else TreeNode(**edge[1])
-        graph.add_edge(u, v)
+        graph.add_edge(u, v, edge[2] if weighted else None)
+    return graph
+
+# Generate a graph from an adjacency list indexed by node
+def generate_graph_from_adjacency_list(adjacency: List[list], directed: bool = True, weighted: bool = False) -> Graph[int]:
+    """
+    Generate a graph from an adjacency list where row i holds the neighbors of node i.
+
+    :param adjacency: Rows of neighbors (e.g., [[1, 2], [0], [0]], or [[[1, 7]], [[0, 7]]] when weighted).
+    :param directed: Whether the rows describe directed arcs; undirected rows already list both directions.
+    :param weighted: Whether each neighbor is a [node, weight] pair.
+    :return: A Graph object represented as an adjacency list.
+    """
+    graph = Graph[int](directed, weighted)
+    for u, row in enumerate(adjacency):
+        graph.add_node(u)
+        for neighbor in row:
+            v, w = (neighbor[0], neighbor[1]) if weighted else (neighbor, None)
+            if directed or u <= v:
+                graph.edges.append([u, v] if w is None else [u, v, w])
+            graph._add_arc(u, v, w)
     return graph
 
 # Export tree structure back to a list
@@ -154,16 +199,28 @@ def export_tree(root: Optional[TreeNode[T]]) -> List[Optional[Union[T, dict]]]:
 # Export graph back to edges
 def export_graph(graph: Graph[T]) -> List[List[Union[T, dict]]]:
     """
-    Export a graph to a list of directed edges with nested structures.
+    Export a graph to its list of edges, in the order they were added.
+    Undirected edges are exported once, weighted edges as [u, v, w].
 
     :param graph: A Graph object represented as an adjacency list.
     :return: List of edges with nested structures.
     """
-    edges = []
-    for u, neighbors in graph.adj_list.items():
-        for v in neighbors:
-            edges.append([u, v])
-    return edges
+    return [list(edge) for edge in graph.edges]
+
+# Export graph back to an adjacency list indexed by node
+def export_adjacency_list(graph: Graph[int]) -> List[list]:
+    """
+    Export a graph with nodes 0..n-1 to an adjacency list.
+
+    :param graph: A Graph object represented as an adjacency list.
+    :return: Rows of neighbors, with [node, weight] pairs when weighted.
+    """
+    size = max(graph.adj_list.keys(), default=-1) + 1
+    rows = []
+    for u in range(size):
+        neighbors = graph.neighbors(u)
+        rows.append([[v, graph.weight(u, v)] for v in neighbors] if graph.weighted else list(neighbors))
+    return rows
 
 # Generate a linked list from a list of values
 def generate_linked_list(values: List[Optional[T]]) -> Optional[ListNode[T]]:
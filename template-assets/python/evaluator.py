@@ -1,8 +1,10 @@
 import ast
 import json
 import os
+import time
 from jsonschema import validate, ValidationError
 import converter
+import comparator
 
 
 
@@ -10,7 +12,7 @@ import converter
 
 
 
-def run_test_cases(compiled_code, test_cases, function_name,function_config):
+def run_test_cases(compiled_code, test_cases, function_name,function_config, comparator_config=None):
     """
     Run the provided test cases against the compiled user code.
 
@@ -18,6 +20,7 @@ def run_test_cases(compiled_code, test_cases, function_name,function_config):
         compiled_code (code): The compiled user code.
         test_cases (list): A list of test cases, each containing 'parameters' and 'expected_output'.
         function_name (str): The name of the function to test.
+        comparator_config (dict): How outputs are matched, exact when None.
 
     Returns:
         dict: A dictionary containing the overall status, results of each test case, and error details if any.
@@ -25,6 +28,7 @@ def run_test_cases(compiled_code, test_cases, function_name,function_config):
     results = []
     namespace = {}
     all_passed = True  # Track if all test cases pass
+    runtime = 0.0  # Seconds spent in the user's function, without the conversions and comparisons
 
     try:
         exec(compiled_code, namespace)  # Execute user code in a separate namespace
@@ -36,6 +40,16 @@ def run_test_cases(compiled_code, test_cases, function_name,function_config):
             "results": [],
         }
 
+    try:
+        compare = comparator.make_comparator(comparator_config)
+    except Exception as e:
+        return {
+            "status": "fail",
+            "error": "internal server error",
+            "details": f"Invalid comparator: {str(e)}",
+            "results": [],
+        }
+
     user_function = namespace.get(function_name)
     if not callable(user_function):
         return {
@@ -45,23 +59,47 @@ def run_test_cases(compiled_code, test_cases, function_name,function_config):
             "results": [],
         }
 
+    mutated_index = converter.mutated_parameter_index(function_config)
     for case in test_cases:
         try:
             # Parse each parameter string individually
             inputs = [converter.listy_to_type(case['parameters'][i],function_config['parameters'][i]['param_type']) for i in range(len(case["parameters"]))]
-            expected_output = converter.listy_to_type(case["expected_output"],function_config['return_type'])
+            return_type = converter.output_type(function_config)
+            expected_output = converter.listy_to_type(case["expected_output"], return_type)
 
             # Invoke the user's function
-            actual_output = user_function(*inputs)
+            started = time.perf_counter()
+            try:
+                actual_output = user_function(*inputs)
+            finally:
+                runtime += time.perf_counter() - started
+            if mutated_index is not None:
+                # A void function's output is the state of the parameter it modified
+                actual_output = inputs[mutated_index]
+
+            # Outputs are reported as JSON, in the same format as the test cases
+            expected_json = converter.type_to_listy(expected_output, return_type)
+            matches = None
+            try:
+                actual_json = converter.type_to_listy(actual_output, return_type)
+            except (TypeError, ValueError, AttributeError):
+                # The user returned something that is not of the return type
+                actual_json = str(actual_output)
+                matches = False
 
             # Compare outputs
-            if actual_output == expected_output:
+            if matches is None:
+                if compare is None:
+                    matches = actual_output == expected_output
+                else:
+                    matches = compare(json.loads(expected_json), json.loads(actual_json))
+            if matches:
                 results.append(
                     {
                         "status": "pass",   
                         "parameters": case["parameters"],
-                        "expected_output": str(expected_output),
-                        "actual_output": str(actual_output),
+                        "expected_output": expected_json,
+                        "actual_output": actual_json,
                     }
                 )
             else:
@@ -70,8 +108,8 @@ def run_test_cases(compiled_code, test_cases, function_name,function_config):
                     {
                         "status": "fail",
                         "parameters": case["parameters"],
-                        "expected_output": str(expected_output),
-                        "actual_output": str(actual_output),
+                        "expected_output": expected_json,
+                        "actual_output": actual_json,
                     }
                 )
         except Exception as e:
@@ -91,11 +129,12 @@ def run_test_cases(compiled_code, test_cases, function_name,function_config):
         "results": results,
         "error": None if all_passed else "fail tests",
         "details": None,
+        "runtime_ms": round(runtime * 1000),
     }
 
 
 def evaluate_user_code(
-    user_code, test_cases, function_name,function_config, schema_path="../feedback_schema.json"
+    user_code, test_cases, function_name,function_config, comparator_config=None, schema_path="../feedback_schema.json"
 ):
     """
     Evaluate the user's code by compiling it, running test cases, and validating the results against a schema.
@@ -104,6 +143,7 @@ def evaluate_user_code(
         user_code (str): The user's code as a string.
         test_cases (list): A list of test cases, each containing 'parameters' and 'expected_output'.
         function_name (str): The name of the function to test.
+        comparator_config (dict): How outputs are matched, exact when None.
         schema_path (str): The path to the JSON schema file for validation.
 
     Returns:
@@ -131,29 +171,10 @@ def evaluate_user_code(
             }
 
         # Step 2: Run test cases
-        results = run_test_cases(compiled_code, test_cases, function_name,function_config)
+        results = run_test_cases(compiled_code, test_cases, function_name,function_config, comparator_config)
 
         # Step 3: Validate against schema
-        try:
-            with open(resolved_schema_path, "r") as schema_file:
-                schema = json.load(schema_file)
-            validate(instance=results, schema=schema)
-        except FileNotFoundError:
-            return {
-                "status": "fail",
-                "error": "internal server error",
-                "details": f"Schema file not found at {resolved_schema_path}",
-                "results": [],
-            }
-        except ValidationError as e:
-            return {
-                "status": "fail",
-                "error": "internal server error",
-                "details": f"Schema validation error: {e.message}",
-                "results": [],
-            }
-
-        return results
+        return validate_results(results, resolved_schema_path)
     except Exception as e:
         # Catch any unexpected errors and mark as internal server error
         return {
@@ -162,3 +183,36 @@ def evaluate_user_code(
             "details": str(e),
             "results": [],
         }
+
+
+def validate_results(results, schema_path):
+    """
+    Validate the results against the feedback schema.
+
+    Args:
+        results (dict): The results of a test run.
+        schema_path (str): The resolved path to the JSON schema file.
+
+    Returns:
+        dict: The results, or an internal server error when they do not match the schema.
+    """
+    try:
+        with open(schema_path, "r") as schema_file:
+            schema = json.load(schema_file)
+        validate(instance=results, schema=schema)
+    except FileNotFoundError:
+        return {
+            "status": "fail",
+            "error": "internal server error",
+            "details": f"Schema file not found at {schema_path}",
+            "results": [],
+        }
+    except ValidationError as e:
+        return {
+            "status": "fail",
+            "error": "internal server error",
+            "details": f"Schema validation error: {e.message}",
+            "results": [],
+        }
+
+    return results
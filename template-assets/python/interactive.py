@@ -0,0 +1,169 @@
+import json
+import os
+import subprocess
+import sys
+import tempfile
+import threading
+from evaluator import validate_results
+
+
+class QueryLimitExceeded(Exception):
+    pass
+
+
+class ProgramExited(Exception):
+    pass
+
+
+class Interactor:
+    """
+    The judge's side of the conversation with the user's program, one line at a time.
+    Every line the program sends counts as a query.
+    """
+
+    def __init__(self, process, query_limit):
+        self.process = process
+        self.query_limit = query_limit
+        self.queries = 0
+
+    def read(self):
+        """Read the next line sent by the user's program, without its line ending."""
+        line = self.process.stdout.readline()
+        if line == "":
+            raise ProgramExited("the program exited without answering")
+        self.queries += 1
+        if self.query_limit and self.queries > self.query_limit:
+            raise QueryLimitExceeded(f"query limit of {self.query_limit} exceeded")
+        return line.rstrip("\r\n")
+
+    def write(self, line):
+        """Send a line to the user's program."""
+        try:
+            self.process.stdin.write(f"{line}\n")
+            self.process.stdin.flush()
+        except (BrokenPipeError, OSError):
+            raise ProgramExited("the program exited before reading the judge's answer")
+
+
+def run_interactive(user_code, judge_code, test_cases, query_limit, time_limit):
+    """
+    Run the user's program against the judge on every test case, connected over stdin/stdout.
+
+    Args:
+        user_code (str): The user's full program.
+        judge_code (str): The judge code, defining judge(test_input, interactor) that talks to the
+            program with interactor.read() and interactor.write(line), and returns a bool or a (bool, message) tuple.
+        test_cases (list): A list of test cases, each containing 'parameters' with the judge's input as JSON.
+        query_limit (int): The most lines the program may send per test case, 0 for unlimited.
+        time_limit (float): The seconds a test case may take before the program is killed.
+
+    Returns:
+        dict: A dictionary containing the overall status, results of each test case, and error details if any.
+    """
+    namespace = {}
+    try:
+        exec(compile(judge_code, filename="<judge>", mode="exec"), namespace)
+    except Exception as e:
+        return {"status": "fail", "error": "internal server error", "details": f"Invalid judge: {str(e)}", "results": []}
+    judge = namespace.get("judge")
+    if not callable(judge):
+        return {"status": "fail", "error": "internal server error", "details": "judge is not defined or callable", "results": []}
+
+    try:
+        compile(user_code, filename="<user_code>", mode="exec")
+    except SyntaxError as e:
+        return {"status": "fail", "error": "compilation", "details": str(e), "results": []}
+
+    results = []
+    all_passed = True
+    with tempfile.TemporaryDirectory() as directory:
+        program_path = os.path.join(directory, "solution.py")
+        with open(program_path, "w") as program_file:
+            program_file.write(user_code)
+
+        for case in test_cases:
+            process = subprocess.Popen(
+                [sys.executable, "-u", program_path],
+                stdin=subprocess.PIPE,
+                stdout=subprocess.PIPE,
+                stderr=subprocess.DEVNULL,
+                text=True,
+                bufsize=1,
+            )
+            # Kill the program when it runs out of time, which also unblocks the judge
+            timed_out = threading.Event()
+
+            def kill(process=process, timed_out=timed_out):
+                timed_out.set()
+                process.kill()
+
+            timer = threading.Timer(time_limit, kill)
+            timer.start()
+            interactor = Interactor(process, query_limit)
+            try:
+                verdict = judge(json.loads(case["parameters"][0]), interactor)
+                passed, message = verdict if isinstance(verdict, tuple) else (verdict, "")
+                message = str(message or "")
+            except (QueryLimitExceeded, ProgramExited) as e:
+                passed, message = False, str(e)
+            except Exception as e:
+                passed, message = False, f"Judge error: {str(e)}"
+            finally:
+                timer.cancel()
+                process.kill()
+                process.wait()
+                for stream in (process.stdin, process.stdout):
+                    try:
+                        stream.close()
+                    except OSError:
+                        pass
+            if timed_out.is_set():
+                passed, message = False, f"time limit of {time_limit}s exceeded"
+
+            all_passed = all_passed and passed
+            results.append(
+                {
+                    "status": "pass" if passed else "fail",
+                    "parameters": case["parameters"],
+                    "expected_output": "accepted",
+                    "actual_output": "accepted" if passed else "rejected",
+                    "message": message,
+                    "queries": interactor.queries,
+                }
+            )
+
+    return {
+        "status": "success" if all_passed else "fail",
+        "results": results,
+        "error": None if all_passed else "fail tests",
+        "details": None,
+    }
+
+
+def evaluate_interactive(user_code, judge_code, test_cases, query_limit, time_limit, schema_path="../feedback_schema.json"):
+    """
+    Evaluate the user's interactive program and validate the results against a schema.
+
+    Args:
+        user_code (str): The user's full program.
+        judge_code (str): The judge code.
+        test_cases (list): A list of test cases, each containing 'parameters' with the judge's input.
+        query_limit (int): The most lines the program may send per test case, 0 for unlimited.
+        time_limit (float): The seconds a test case may take.
+        schema_path (str): The path to the JSON schema file for validation.
+
+    Returns:
+        dict: A dictionary containing the overall status, results of each test case, and error details if any.
+    """
+    try:
+        resolved_schema_path = os.path.join(os.path.dirname(os.path.abspath(__file__)), schema_path)
+        results = run_interactive(user_code, judge_code, test_cases, query_limit, time_limit)
+        return validate_results(results, resolved_schema_path)
+    except Exception as e:
+        # Catch any unexpected errors and mark as internal server error
+        return {
+            "status": "fail",
+            "error": "internal server error",
+            "details": str(e),
+            "results": [],
+        }
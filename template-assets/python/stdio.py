@@ -0,0 +1,130 @@
+import os
+import subprocess
+import sys
+import tempfile
+import time
+from evaluator import validate_results
+
+
+def normalize_output(text, whitespace, keep_carriage_returns):
+    """
+    Normalize a program output before it is compared.
+
+    Args:
+        text (str): The output.
+        whitespace (str): "exact", "trailing" to ignore trailing spaces of every line and trailing blank lines,
+            or "tokens" to match any run of whitespace with any other.
+        keep_carriage_returns (bool): When False, \\r\\n and \\r are read as \\n.
+
+    Returns:
+        str: The normalized output.
+    """
+    if not keep_carriage_returns:
+        text = text.replace("\r\n", "\n").replace("\r", "\n")
+    if whitespace == "exact":
+        return text
+    if whitespace == "tokens":
+        return " ".join(text.split())
+    lines = [line.rstrip(" \t") for line in text.split("\n")]
+    while lines and lines[-1] == "":
+        lines.pop()
+    return "\n".join(lines)
+
+
+def run_programs(user_code, test_cases, stdio_config):
+    """
+    Run the user's program on the stdin of every test case and compare its stdout with the expected one.
+
+    Args:
+        user_code (str): The user's full program.
+        test_cases (list): A list of test cases, each containing 'parameters' with the stdin text and 'expected_output'.
+        stdio_config (dict): The question's configuration with "whitespace", "keep_carriage_returns" and "time_limit".
+
+    Returns:
+        dict: A dictionary containing the overall status, results of each test case, and error details if any.
+    """
+    try:
+        compile(user_code, filename="<user_code>", mode="exec")
+    except SyntaxError as e:
+        return {"status": "fail", "error": "compilation", "details": str(e), "results": []}
+
+    whitespace = stdio_config.get("whitespace") or "trailing"
+    keep_carriage_returns = stdio_config.get("keep_carriage_returns", False)
+    time_limit = stdio_config.get("time_limit") or 2
+
+    results = []
+    all_passed = True
+    runtime = 0.0  # Seconds the user's program ran, over all test cases
+    with tempfile.TemporaryDirectory() as directory:
+        program_path = os.path.join(directory, "solution.py")
+        with open(program_path, "w") as program_file:
+            program_file.write(user_code)
+
+        for case in test_cases:
+            message = None
+            started = time.perf_counter()
+            try:
+                # Bytes rather than text, which would read \r\n as \n whatever keep_carriage_returns says
+                completed = subprocess.run(
+                    [sys.executable, program_path],
+                    input=case["parameters"][0].encode(),
+                    capture_output=True,
+                    timeout=time_limit,
+                )
+                actual_output = completed.stdout.decode(errors="replace")
+                if completed.returncode != 0:
+                    errors = completed.stderr.decode(errors="replace").strip().splitlines()
+                    message = f"Runtime error: {errors[-1] if errors else f'exit code {completed.returncode}'}"
+            except subprocess.TimeoutExpired as e:
+                actual_output = e.stdout.decode() if isinstance(e.stdout, bytes) else (e.stdout or "")
+                message = f"time limit of {time_limit}s exceeded"
+            runtime += time.perf_counter() - started
+
+            passed = message is None and normalize_output(actual_output, whitespace, keep_carriage_returns) == normalize_output(
+                case["expected_output"], whitespace, keep_carriage_returns
+            )
+            all_passed = all_passed and passed
+            result = {
+                "status": "pass" if passed else "fail",
+                "parameters": case["parameters"],
+                "expected_output": case["expected_output"],
+                "actual_output": actual_output,
+            }
+            if message is not None:
+                result["message"] = message
+            results.append(result)
+
+    return {
+        "status": "success" if all_passed else "fail",
+        "results": results,
+        "error": None if all_passed else "fail tests",
+        "details": None,
+        "runtime_ms": round(runtime * 1000),
+    }
+
+
+def evaluate_program(user_code, test_cases, stdio_config, schema_path="../feedback_schema.json"):
+    """
+    Evaluate the user's program on every test case and validate the results against a schema.
+
+    Args:
+        user_code (str): The user's full program.
+        test_cases (list): A list of test cases, each containing 'parameters' with the stdin text and 'expected_output'.
+        stdio_config (dict): The question's configuration.
+        schema_path (str): The path to the JSON schema file for validation.
+
+    Returns:
+        dict: A dictionary containing the overall status, results of each test case, and error details if any.
+    """
+    try:
+        resolved_schema_path = os.path.join(os.path.dirname(os.path.abspath(__file__)), schema_path)
+        results = run_programs(user_code, test_cases, stdio_config)
+        return validate_results(results, resolved_schema_path)
+    except Exception as e:
+        # Catch any unexpected errors and mark as internal server error
+        return {
+            "status": "fail",
+            "error": "internal server error",
+            "details": str(e),
+            "results": [],
+        }
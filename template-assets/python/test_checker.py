@@ -0,0 +1,59 @@
+import unittest
+
+from checker import run_checker
+
+FUNCTION_CONFIG = {
+    "parameters": [
+        {"name": "n", "param_type": {"type": "Integer"}},
+        {"name": "edges", "param_type": {"type": "Array", "type_children": {"type": "Array", "type_children": {"type": "Integer"}}}},
+    ],
+    "return_type": {"type": "Array", "type_children": {"type": "Integer"}},
+}
+
+TOPOLOGICAL_CHECKER = """
+def check(inputs, expected, actual):
+    n, edges = inputs
+    if sorted(actual) != list(range(n)):
+        return False, "not a permutation of the nodes"
+    position = {node: i for i, node in enumerate(actual)}
+    for u, v in edges:
+        if position[u] > position[v]:
+            return False, f"{u} must come before {v}"
+    return True, "valid order"
+"""
+
+
+def case(actual):
+    return {"parameters": ["3", "[[0,1],[0,2]]"], "expected_output": "[0,1,2]", "actual_output": actual}
+
+
+class TestChecker(unittest.TestCase):
+    def test_accepts_any_valid_answer(self):
+        results = run_checker(TOPOLOGICAL_CHECKER, [case("[0,1,2]"), case("[0,2,1]")], FUNCTION_CONFIG)
+        self.assertIsNone(results["error"])
+        self.assertEqual([v["status"] for v in results["verdicts"]], ["pass", "pass"])
+        self.assertEqual(results["verdicts"][0]["message"], "valid order")
+
+    def test_rejects_with_message(self):
+        results = run_checker(TOPOLOGICAL_CHECKER, [case("[1,0,2]")], FUNCTION_CONFIG)
+        self.assertEqual(results["verdicts"], [{"status": "fail", "message": "0 must come before 1"}])
+
+    def test_plain_boolean(self):
+        code = "def check(inputs, expected, actual):\n    return actual == expected\n"
+        results = run_checker(code, [case("[0,1,2]"), case("[0,2,1]")], FUNCTION_CONFIG)
+        self.assertEqual([v["status"] for v in results["verdicts"]], ["pass", "fail"])
+
+    def test_exception_fails_the_case(self):
+        code = "def check(inputs, expected, actual):\n    raise ValueError('boom')\n"
+        results = run_checker(code, [case("[0,1,2]")], FUNCTION_CONFIG)
+        self.assertEqual(results["verdicts"][0]["status"], "fail")
+        self.assertIn("boom", results["verdicts"][0]["message"])
+
+    def test_missing_check(self):
+        results = run_checker("x = 1", [case("[0,1,2]")], FUNCTION_CONFIG)
+        self.assertEqual(results["verdicts"], [])
+        self.assertIsNotNone(results["error"])
+
+
+if __name__ == "__main__":
+    unittest.main()
@@ -0,0 +1,73 @@
+import json
+import unittest
+
+from class_evaluator import run_operation_sequences
+
+INTEGER = {"type": "Integer"}
+CLASS_CONFIG = {
+    "name": "MinStack",
+    "methods": [
+        {"name": "push", "parameters": [{"name": "val", "param_type": INTEGER}]},
+        {"name": "pop", "parameters": []},
+        {"name": "getMin", "parameters": [], "return_type": INTEGER},
+    ],
+}
+METHOD_NAMES = {"push": "push", "pop": "pop", "getMin": "get_min"}
+
+MIN_STACK = """
+class MinStack:
+    def __init__(self):
+        self.stack = []
+
+    def push(self, val):
+        self.stack.append(val)
+
+    def pop(self):
+        return self.stack.pop()
+
+    def get_min(self):
+        return min(self.stack)
+"""
+
+SEQUENCE = {
+    "parameters": [json.dumps(["MinStack", "push", "push", "getMin", "pop", "getMin"]), "[[],[2],[1],[],[],[]]"],
+    "expected_output": "[null,null,null,1,null,2]",
+}
+
+
+def run(code, test_cases):
+    return run_operation_sequences(compile(code, "<user_code>", "exec"), test_cases, CLASS_CONFIG, METHOD_NAMES)
+
+
+class TestClassEvaluator(unittest.TestCase):
+    def test_passes_sequence(self):
+        results = run(MIN_STACK, [SEQUENCE])
+        self.assertEqual(results["status"], "success")
+        # Void methods output null, even pop which returns the popped value
+        self.assertEqual(json.loads(results["results"][0]["actual_output"]), [None, None, None, 1, None, 2])
+
+    def test_reports_runtime_of_the_class(self):
+        code = MIN_STACK.replace("self.stack = []", "self.stack = []\n        import time; time.sleep(0.05)")
+        results = run(code, [SEQUENCE])
+        self.assertGreaterEqual(results["runtime_ms"], 50)
+        self.assertLess(run(MIN_STACK, [SEQUENCE])["runtime_ms"], 50)
+
+    def test_fails_wrong_output(self):
+        code = MIN_STACK.replace("min(self.stack)", "max(self.stack)")
+        results = run(code, [SEQUENCE])
+        self.assertEqual(results["status"], "fail")
+        self.assertEqual(json.loads(results["results"][0]["actual_output"]), [None, None, None, 2, None, 2])
+
+    def test_reports_failing_operation(self):
+        code = MIN_STACK.replace("return min(self.stack)", "raise IndexError('empty')")
+        results = run(code, [SEQUENCE])
+        self.assertEqual(results["results"][0]["status"], "fail")
+        self.assertTrue(results["results"][0]["actual_output"].startswith("Error at operation 3"))
+
+    def test_missing_class(self):
+        results = run("class Stack:\n    pass\n", [SEQUENCE])
+        self.assertEqual(results["error"], "compilation")
+
+
+if __name__ == "__main__":
+    unittest.main()
@@ -0,0 +1,47 @@
+import unittest
+from comparator import make_comparator
+
+
+class TestMakeComparator(unittest.TestCase):
+    def test_exact_uses_native_equality(self):
+        self.assertIsNone(make_comparator(None))
+        self.assertIsNone(make_comparator({"type": "exact"}))
+
+    def test_unordered(self):
+        compare = make_comparator({"type": "unordered"})
+        self.assertTrue(compare([1, 0], [0, 1]))
+        self.assertTrue(compare([[1, 2], [3, 4]], [[3, 4], [1, 2]]))
+        self.assertFalse(compare([[1, 2], [3, 4]], [[4, 3], [1, 2]]))
+        self.assertFalse(compare([1, 1, 2], [1, 2, 2]))
+
+    def test_unordered_nested(self):
+        compare = make_comparator({"type": "unordered_nested"})
+        self.assertTrue(compare([[1, 2], [3, 4]], [[4, 3], [2, 1]]))
+        self.assertFalse(compare([[1, 2], [3]], [[1], [2, 3]]))
+
+    def test_set_of_sets(self):
+        compare = make_comparator({"type": "set_of_sets"})
+        self.assertTrue(compare([[1, 2], [2, 1], [3]], [[3], [2, 1]]))
+        self.assertFalse(compare([[1, 2]], [[1, 2], [3]]))
+
+    def test_float_tolerance(self):
+        compare = make_comparator({"type": "float_tolerance", "tolerance": 1e-3})
+        self.assertTrue(compare(0.3333, 1 / 3))
+        self.assertTrue(compare([1.0, 2.0], [1.0004, 1.9996]))
+        self.assertFalse(compare([1.0, 2.0], [1.01, 2.0]))
+        self.assertFalse(compare(True, 1))
+
+    def test_custom(self):
+        compare = make_comparator({"type": "custom", "code": {"Python": "def compare(expected, actual):\n    return len(expected) == len(actual)\n"}})
+        self.assertTrue(compare([1, 2], [3, 4]))
+        self.assertFalse(compare([1, 2], [3]))
+        with self.assertRaises(ValueError):
+            make_comparator({"type": "custom", "code": {"JavaScript": "function compare() { return true; }"}})
+
+    def test_invalid_type(self):
+        with self.assertRaises(ValueError):
+            make_comparator({"type": "Unknown"})
+
+
+if __name__ == "__main__":
+    unittest.main()
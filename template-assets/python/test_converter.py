@@ -1,7 +1,8 @@
+import json
 import unittest
 import ds_utils
 from ds_utils import TreeNode, Graph, ListNode
-from converter import listy_to_type  # Import your `listy_to_type` function here
+from converter import listy_to_type, type_to_listy, output_type  # Import your `listy_to_type` function here
 
 
 class TestListyToTypeIntegration(unittest.TestCase):
@@ -33,6 +34,48 @@ class TestListyToTypeIntegration(unittest.TestCase):
         self.assertTrue(isinstance(graph, Graph))
         self.assertEqual(ds_utils.export_graph(graph), [[1, 2], [2, 3], [3, 1]])
 
+    def test_directed_weighted_graph(self):
+        stringy_input = "[[1, 2, 4], [2, 3, 1.5]]"
+        abstract_type = {
+            "type": "Graph",
+            "directed": True,
+            "weight_type": {"type": "Double"},
+            "type_children": {
+                "type": "Integer"
+            }
+        }
+
+        graph = listy_to_type(stringy_input, abstract_type)
+        self.assertTrue(isinstance(graph, Graph))
+        self.assertEqual(graph.neighbors(1), [2])
+        self.assertEqual(graph.neighbors(2), [3])
+        self.assertEqual(graph.weight(2, 3), 1.5)
+        self.assertIsNone(graph.weight(3, 2))
+        self.assertEqual(ds_utils.export_graph(graph), [[1, 2, 4], [2, 3, 1.5]])
+
+    def test_undirected_graph_is_symmetric(self):
+        graph = listy_to_type("[[1, 2]]", {"type": "Graph", "directed": False, "type_children": {"type": "Integer"}})
+        self.assertEqual(graph.neighbors(1), [2])
+        self.assertEqual(graph.neighbors(2), [1])
+
+    def test_adjacency_list_graph(self):
+        stringy_input = "[[[1, 7]], [[0, 7]], []]"
+        abstract_type = {
+            "type": "Graph",
+            "format": "adjacency_list",
+            "directed": False,
+            "weight_type": {"type": "Integer"},
+            "type_children": {
+                "type": "Integer"
+            }
+        }
+
+        graph = listy_to_type(stringy_input, abstract_type)
+        self.assertEqual(graph.neighbors(2), [])
+        self.assertEqual(graph.weight(1, 0), 7)
+        self.assertEqual(ds_utils.export_graph(graph), [[0, 1, 7]])
+        self.assertEqual(ds_utils.export_adjacency_list(graph), [[[1, 7]], [[0, 7]], []])
+
     def test_list_node(self):
         stringy_input = "[1, 2, 3, 4]"
         abstract_type = {
@@ -98,12 +141,49 @@ class TestListyToTypeIntegration(unittest.TestCase):
     #         [[TreeNode(1), TreeNode(2)]]
     #     )
 
+    def test_strings_with_special_characters(self):
+        abstract_type = {
+            "type": "Array",
+            "type_children": {
+                "type": "String"
+            }
+        }
+
+        self.assertEqual(listy_to_type('"a, b"', {"type": "String"}), "a, b")
+        self.assertEqual(listy_to_type('"say \\"hi\\""', {"type": "String"}), 'say "hi"')
+        self.assertEqual(listy_to_type('"line\\nbreak"', {"type": "String"}), "line\nbreak")
+        self.assertEqual(listy_to_type('"caf\\u00e9"', {"type": "String"}), "caf\u00e9")
+        self.assertEqual(listy_to_type('["[x]", "a,b", ""]', abstract_type), ["[x]", "a,b", ""])
+
+    def test_json_literals(self):
+        self.assertEqual(listy_to_type("true", {"type": "Boolean"}), True)
+        self.assertEqual(listy_to_type("[1, null, 2]", {"type": "Array", "type_children": {"type": "Integer"}}), [1, None, 2])
+
+    def test_type_to_listy_round_trip(self):
+        cases = [
+            ('"a, \\"b\\""', {"type": "String"}),
+            ("[[1, 2], [3, 4]]", {"type": "Matrix", "type_children": {"type": "Integer"}}),
+            ("[1, null, 2, 3]", {"type": "TreeNode", "type_children": {"type": "Integer"}}),
+            ("[1, 2, 3]", {"type": "ListNode", "type_children": {"type": "Integer"}}),
+            ("[[0, 1, 5], [1, 2, 7]]", {"type": "Graph", "type_children": {"type": "Integer"}, "directed": True, "weight_type": {"type": "Integer"}}),
+            ("[[1], [0, 2], [1]]", {"type": "Graph", "type_children": {"type": "Integer"}, "format": "adjacency_list"}),
+        ]
+        for stringy_input, abstract_type in cases:
+            self.assertEqual(json.loads(type_to_listy(listy_to_type(stringy_input, abstract_type), abstract_type)), json.loads(stringy_input))
+
     def test_invalid_type(self):
         stringy_input = "[1, 2, 3]"
         abstract_type = {"type": "Unknown"}
         with self.assertRaises(ValueError):
             listy_to_type(stringy_input, abstract_type)
 
+    def test_output_type(self):
+        array = {"type": "Array", "type_children": {"type": "Integer"}}
+        parameters = [{"name": "nums", "param_type": array}, {"name": "k", "param_type": {"type": "Integer"}}]
+        self.assertEqual(output_type({"parameters": parameters, "return_type": {"type": "Integer"}}), {"type": "Integer"})
+        # A void function's outputs are the mutated parameter's state
+        self.assertEqual(output_type({"parameters": parameters, "return_type": None, "mutated_parameter": "nums"}), array)
+
 
 if __name__ == "__main__":
     unittest.main()
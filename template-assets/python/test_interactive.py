@@ -0,0 +1,72 @@
+import json
+import unittest
+
+from interactive import run_interactive
+
+GUESS_JUDGE = """
+def judge(secret, interactor):
+    while True:
+        guess = int(interactor.read())
+        if guess == secret:
+            interactor.write("correct")
+            return True, "found the number"
+        interactor.write("higher" if guess < secret else "lower")
+"""
+
+BINARY_SEARCH = """
+low, high = 1, 100
+while True:
+    guess = (low + high) // 2
+    print(guess, flush=True)
+    answer = input()
+    if answer == "correct":
+        break
+    if answer == "higher":
+        low = guess + 1
+    else:
+        high = guess - 1
+"""
+
+LINEAR_SEARCH = """
+guess = 1
+while True:
+    print(guess, flush=True)
+    if input() == "correct":
+        break
+    guess += 1
+"""
+
+
+def cases(*secrets):
+    return [{"parameters": [json.dumps(secret)], "expected_output": ""} for secret in secrets]
+
+
+class TestInteractive(unittest.TestCase):
+    def test_accepts_within_limit(self):
+        results = run_interactive(BINARY_SEARCH, GUESS_JUDGE, cases(1, 37, 100), 7, 5)
+        self.assertEqual(results["status"], "success")
+        self.assertEqual(results["results"][0]["message"], "found the number")
+        self.assertTrue(all(result["queries"] <= 7 for result in results["results"]))
+
+    def test_query_limit(self):
+        results = run_interactive(LINEAR_SEARCH, GUESS_JUDGE, cases(50), 7, 5)
+        self.assertEqual(results["status"], "fail")
+        self.assertIn("query limit", results["results"][0]["message"])
+
+    def test_time_limit(self):
+        code = "import time\ntime.sleep(10)\n"
+        results = run_interactive(code, GUESS_JUDGE, cases(50), 0, 0.5)
+        self.assertEqual(results["results"][0]["status"], "fail")
+        self.assertIn("time limit", results["results"][0]["message"])
+
+    def test_program_exits(self):
+        results = run_interactive("print(1)\n", GUESS_JUDGE, cases(50), 0, 5)
+        self.assertIn("exited", results["results"][0]["message"])
+
+    def test_syntax_error(self):
+        results = run_interactive("def (", GUESS_JUDGE, cases(50), 0, 5)
+        self.assertEqual(results["error"], "compilation")
+
+
+if __name__ == "__main__":
+    unittest.main()
@@ -0,0 +1,56 @@
+import unittest
+
+from stdio import normalize_output, run_programs
+
+SUM_LINES = """
+import sys
+for line in sys.stdin:
+    a, b = map(int, line.split())
+    print(a + b)
+"""
+
+
+class TestNormalizeOutput(unittest.TestCase):
+    def test_trailing(self):
+        self.assertEqual(normalize_output("3  \r\n7\t\n\n", "trailing", False), "3\n7")
+        self.assertNotEqual(normalize_output("3\n 7", "trailing", False), "3\n7")
+
+    def test_exact(self):
+        self.assertEqual(normalize_output("3\r\n", "exact", False), "3\n")
+        self.assertEqual(normalize_output("3\r\n", "exact", True), "3\r\n")
+
+    def test_tokens(self):
+        self.assertEqual(normalize_output(" 1  2\n3\n", "tokens", False), "1 2 3")
+
+
+class TestRunPrograms(unittest.TestCase):
+    def test_compares_normalized_output(self):
+        cases = [
+            {"parameters": ["1 2\n3 4\n"], "expected_output": "3\n7"},
+            {"parameters": ["1 1\n"], "expected_output": "3\n"},
+        ]
+        results = run_programs(SUM_LINES, cases, {})
+        self.assertEqual([result["status"] for result in results["results"]], ["pass", "fail"])
+        self.assertEqual(results["results"][1]["actual_output"], "2\n")
+
+    def test_reports_runtime(self):
+        results = run_programs("import time\ntime.sleep(0.05)\n", [{"parameters": [""], "expected_output": ""}] * 2, {})
+        self.assertGreaterEqual(results["runtime_ms"], 100)
+
+    def test_runtime_error(self):
+        results = run_programs("raise ValueError('bad input')\n", [{"parameters": [""], "expected_output": ""}], {})
+        self.assertEqual(results["results"][0]["status"], "fail")
+        self.assertIn("bad input", results["results"][0]["message"])
+
+    def test_time_limit(self):
+        code = "import time\ntime.sleep(5)\n"
+        results = run_programs(code, [{"parameters": [""], "expected_output": ""}], {"time_limit": 0.5})
+        self.assertIn("time limit", results["results"][0]["message"])
+
+    def test_syntax_error(self):
+        results = run_programs("print(", [{"parameters": [""], "expected_output": ""}], {})
+        self.assertEqual(results["error"], "compilation")
+
+
+if __name__ == "__main__":
+    unittest.main()